@@ -5,11 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"os/exec"
 	"runtime"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/adpena/reproq-tui/internal/auth"
+	"github.com/adpena/reproq-tui/internal/config"
 	"github.com/adpena/reproq-tui/pkg/client"
 	"github.com/spf13/cobra"
 )
@@ -21,6 +25,16 @@ type loginOptions struct {
 	MaxWait     time.Duration
 	OpenBrowser bool
 	AuthFile    string
+	Device      auth.DeviceConfig
+	TLS         client.Options
+}
+
+// newClient builds an HTTP client for Django with the login TLS settings.
+func (o loginOptions) newClient(headers map[string]string) *client.Client {
+	opts := o.TLS
+	opts.Timeout = o.Timeout
+	opts.Headers = headers
+	return client.New(opts)
 }
 
 var loginCmd = &cobra.Command{
@@ -31,7 +45,13 @@ var loginCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		store := authStore(opts.AuthFile)
+		store, err := authStore(cmd)
+		if err != nil {
+			return err
+		}
+		if opts.Device.Enabled() {
+			return runDeviceLoginFlow(opts, store)
+		}
 		return runLoginFlow(opts, store)
 	},
 }
@@ -40,8 +60,10 @@ var logoutCmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Clear the stored TUI token",
 	RunE: func(cmd *cobra.Command, _ []string) error {
-		authFile, _ := cmd.Flags().GetString("auth-file")
-		store := authStore(authFile)
+		store, err := authStore(cmd)
+		if err != nil {
+			return err
+		}
 		if err := store.Clear(); err != nil {
 			return err
 		}
@@ -50,17 +72,65 @@ var logoutCmd = &cobra.Command{
 	},
 }
 
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Inspect stored TUI tokens",
+}
+
+var authListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List auth profiles with their Django URL, expiry, and worker",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		store, err := authStore(cmd)
+		if err != nil {
+			return err
+		}
+		profiles, err := store.Profiles()
+		if err != nil {
+			return err
+		}
+		if len(profiles) == 0 {
+			fmt.Println("No stored tokens. Run `reproq-tui login --django-url ...` to add one.")
+			return nil
+		}
+		now := time.Now()
+		out := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(out, "PROFILE\tDJANGO URL\tEXPIRES\tWORKER")
+		for _, profile := range profiles {
+			token, err := store.WithProfile(profile).Load()
+			if err != nil {
+				fmt.Fprintf(out, "%s\t-\t%v\t-\n", profile, err)
+				continue
+			}
+			fmt.Fprintf(out, "%s\t%s\t%s\t%s\n", profile, dashIfEmpty(token.DjangoURL), tokenExpiry(token, now), dashIfEmpty(tokenWorker(token)))
+		}
+		return out.Flush()
+	},
+}
+
 func init() {
 	loginCmd.Flags().String("django-url", "", "Base Django URL (required)")
 	loginCmd.Flags().Duration("timeout", 2*time.Second, "HTTP request timeout")
 	loginCmd.Flags().Duration("poll", time.Second, "Polling interval for approval")
 	loginCmd.Flags().Duration("max-wait", 10*time.Minute, "Max time to wait for approval")
 	loginCmd.Flags().Bool("open-browser", true, "Open the approval URL in a browser")
-	loginCmd.Flags().String("auth-file", "", "Override auth token store path")
+	loginCmd.Flags().String("oidc-issuer", "", "OIDC issuer URL; use the OAuth device flow instead of Django pairing")
+	loginCmd.Flags().String("oidc-client-id", "", "OAuth client ID for the device flow")
+	loginCmd.Flags().String("oidc-scopes", "openid,offline_access", "Comma-separated OAuth scopes")
+	addTLSFlags(loginCmd)
+	addAuthStoreFlags(loginCmd)
 	RootCmd.AddCommand(loginCmd)
 
-	logoutCmd.Flags().String("auth-file", "", "Override auth token store path")
+	addAuthStoreFlags(logoutCmd)
 	RootCmd.AddCommand(logoutCmd)
+
+	addAuthStoreFlags(authListCmd)
+	authCmd.AddCommand(authListCmd)
+	RootCmd.AddCommand(authCmd)
+
+	for _, cmd := range []*cobra.Command{loginCmd, logoutCmd, authListCmd} {
+		cmd.Flags().String("config", "", "Config file to read auth_store, auth_passphrase_file and profile from")
+	}
 }
 
 func readLoginOptions(cmd *cobra.Command) (loginOptions, error) {
@@ -71,7 +141,19 @@ func readLoginOptions(cmd *cobra.Command) (loginOptions, error) {
 	opts.MaxWait, _ = cmd.Flags().GetDuration("max-wait")
 	opts.OpenBrowser, _ = cmd.Flags().GetBool("open-browser")
 	opts.AuthFile, _ = cmd.Flags().GetString("auth-file")
-	if opts.DjangoURL == "" {
+	opts.TLS = readTLSFlags(cmd)
+	opts.Device.Issuer, _ = cmd.Flags().GetString("oidc-issuer")
+	opts.Device.ClientID, _ = cmd.Flags().GetString("oidc-client-id")
+	scopes, _ := cmd.Flags().GetString("oidc-scopes")
+	for _, scope := range strings.Split(scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			opts.Device.Scopes = append(opts.Device.Scopes, scope)
+		}
+	}
+	if opts.Device.Issuer != "" && opts.Device.ClientID == "" {
+		return opts, errors.New("oidc-client-id is required with oidc-issuer")
+	}
+	if opts.DjangoURL == "" && !opts.Device.Enabled() {
 		return opts, errors.New("django-url is required")
 	}
 	if opts.Poll <= 0 {
@@ -86,15 +168,43 @@ func readLoginOptions(cmd *cobra.Command) (loginOptions, error) {
 	return opts, nil
 }
 
-func authStore(path string) *auth.Store {
-	if path != "" {
-		return auth.NewStore(path)
+func addTLSFlags(cmd *cobra.Command) {
+	cmd.Flags().String("ca-file", "", "PEM CA bundle used instead of the system roots")
+	cmd.Flags().String("client-cert", "", "PEM client certificate for mutual TLS")
+	cmd.Flags().String("client-key", "", "PEM client key for mutual TLS (default: --client-cert)")
+	cmd.Flags().String("tls-server-name", "", "Override the TLS server name (SNI and certificate check)")
+}
+
+func readTLSFlags(cmd *cobra.Command) client.Options {
+	opts := client.Options{}
+	opts.CAFile, _ = cmd.Flags().GetString("ca-file")
+	opts.CertFile, _ = cmd.Flags().GetString("client-cert")
+	opts.KeyFile, _ = cmd.Flags().GetString("client-key")
+	opts.ServerName, _ = cmd.Flags().GetString("tls-server-name")
+	return opts
+}
+
+func addAuthStoreFlags(cmd *cobra.Command) {
+	cmd.Flags().String("auth-file", "", "Override auth token store path")
+	cmd.Flags().String("auth-store", "", "Token store backend: file, keyring, encrypted, or auto (default file)")
+	cmd.Flags().String("auth-passphrase-file", "", "Passphrase file for the encrypted token store")
+	cmd.Flags().String("profile", "", "Auth profile name (default \"default\")")
+}
+
+// authStore opens the token store the dashboard would use, so auth_store and
+// auth_passphrase_file from the config file and environment apply here too.
+func authStore(cmd *cobra.Command) (auth.TokenStore, error) {
+	cfg, err := config.LoadAuthStore(cmd)
+	if err != nil {
+		return nil, err
 	}
-	return auth.DefaultStore()
+	opts := auth.StoreOptions{Backend: cfg.AuthStore, PassphraseFile: cfg.AuthPassphraseFile, Profile: cfg.Profile}
+	opts.Path, _ = cmd.Flags().GetString("auth-file")
+	return auth.OpenStore(opts)
 }
 
-func runLoginFlow(opts loginOptions, store *auth.Store) error {
-	httpClient := client.New(client.Options{Timeout: opts.Timeout})
+func runLoginFlow(opts loginOptions, store auth.TokenStore) error {
+	httpClient := opts.newClient(nil)
 	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
 	pair, err := auth.StartPair(ctx, httpClient, opts.DjangoURL)
 	cancel()
@@ -134,13 +244,14 @@ func runLoginFlow(opts loginOptions, store *auth.Store) error {
 		switch status.Status {
 		case "approved":
 			token := auth.Token{Value: status.Token, ExpiresAt: status.ExpiresAt, DjangoURL: opts.DjangoURL}
+			token.Config = fetchTokenConfig(opts, token)
 			if err := store.Save(token); err != nil {
 				return err
 			}
 			if token.ExpiresAt.IsZero() {
-				fmt.Println("Signed in.")
+				fmt.Printf("Signed in as profile %q.\n", store.Profile())
 			} else {
-				fmt.Printf("Signed in (expires %s).\n", token.ExpiresAt.Format(time.RFC3339))
+				fmt.Printf("Signed in as profile %q (expires %s).\n", store.Profile(), token.ExpiresAt.Format(time.RFC3339))
 			}
 			return nil
 		case "pending":
@@ -151,6 +262,111 @@ func runLoginFlow(opts loginOptions, store *auth.Store) error {
 	}
 }
 
+func startDeviceLogin(opts loginOptions, httpClient *client.Client) (auth.DeviceConfig, auth.DeviceAuthorization, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*opts.Timeout)
+	defer cancel()
+	device, err := auth.DiscoverDevice(ctx, httpClient, opts.Device)
+	if err != nil {
+		return device, auth.DeviceAuthorization{}, err
+	}
+	start, err := auth.StartDevice(ctx, httpClient, device)
+	return device, start, err
+}
+
+// runDeviceLoginFlow signs in through an OIDC provider with the RFC 8628
+// device authorization grant.
+func runDeviceLoginFlow(opts loginOptions, store auth.TokenStore) error {
+	httpClient := client.New(client.Options{Timeout: opts.Timeout, CAFile: opts.TLS.CAFile})
+	device, start, err := startDeviceLogin(opts, httpClient)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Open: %s\n", start.VerifyURL)
+	fmt.Printf("Code: %s\n", start.UserCode)
+	if opts.OpenBrowser {
+		if err := openBrowser(start.VerifyURL); err != nil {
+			fmt.Printf("Browser open failed: %v\n", err)
+		}
+	}
+
+	deadline := start.ExpiresAt
+	if deadline.IsZero() || time.Now().Add(opts.MaxWait).Before(deadline) {
+		deadline = time.Now().Add(opts.MaxWait)
+	}
+	interval := start.Interval
+	for {
+		time.Sleep(interval)
+		if time.Now().After(deadline) {
+			return errors.New("login timed out")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+		token, err := auth.PollDevice(ctx, httpClient, device, start.DeviceCode)
+		cancel()
+		switch {
+		case err == nil:
+			token.DjangoURL = opts.DjangoURL
+			if opts.DjangoURL != "" {
+				token.Config = fetchTokenConfig(opts, token)
+			}
+			if err := store.Save(token); err != nil {
+				return err
+			}
+			fmt.Printf("Signed in as profile %q via %s.\n", store.Profile(), opts.Device.Issuer)
+			return nil
+		case auth.IsOAuthError(err, "authorization_pending", "slow_down"):
+			interval = auth.NextDeviceInterval(interval, err)
+		case auth.IsOAuthError(err, "expired_token"):
+			return errors.New("login expired")
+		case auth.IsOAuthError(err, "access_denied"):
+			return errors.New("login denied")
+		default:
+			return err
+		}
+	}
+}
+
+// fetchTokenConfig asks Django for the worker endpoints tied to the new token;
+// a missing config endpoint is not a login failure.
+func fetchTokenConfig(opts loginOptions, token auth.Token) *auth.TUIConfig {
+	httpClient := opts.newClient(map[string]string{"Authorization": "Bearer " + token.Value})
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+	cfg, err := auth.FetchConfig(ctx, httpClient, opts.DjangoURL)
+	if err != nil {
+		return nil
+	}
+	return &cfg
+}
+
+func tokenExpiry(token auth.Token, now time.Time) string {
+	switch {
+	case token.ExpiresAt.IsZero():
+		return "never"
+	case !token.Valid(now):
+		return "expired " + token.ExpiresAt.Local().Format(time.RFC3339)
+	default:
+		return token.ExpiresAt.Local().Format(time.RFC3339)
+	}
+}
+
+func tokenWorker(token auth.Token) string {
+	if token.Config == nil {
+		return ""
+	}
+	if token.Config.WorkerMetricsURL != "" {
+		return token.Config.WorkerMetricsURL
+	}
+	return token.Config.WorkerURL
+}
+
+func dashIfEmpty(val string) string {
+	if val == "" {
+		return "-"
+	}
+	return val
+}
+
 func openBrowser(url string) error {
 	var cmd *exec.Cmd
 	switch runtime.GOOS {
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/internal/events"
 	"github.com/adpena/reproq-tui/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -9,7 +10,11 @@ import (
 func runDashboard(cfg config.Config) error {
 	model := ui.NewModel(cfg)
 	defer model.Close()
-	program := tea.NewProgram(model, tea.WithAltScreen())
+	options := []tea.ProgramOption{tea.WithAltScreen()}
+	if events.IsStdinSource(cfg.EventsURL) {
+		options = append(options, tea.WithInputTTY())
+	}
+	program := tea.NewProgram(model, options...)
 	_, err := program.Run()
 	return err
 }
@@ -19,6 +19,10 @@ type setupConfig struct {
 	EventsURL        string `yaml:"events_url,omitempty"`
 	DjangoURL        string `yaml:"django_url,omitempty"`
 	DjangoStatsURL   string `yaml:"django_stats_url,omitempty"`
+	CAFile           string `yaml:"ca_file,omitempty"`
+	ClientCertFile   string `yaml:"client_cert,omitempty"`
+	ClientKeyFile    string `yaml:"client_key,omitempty"`
+	TLSServerName    string `yaml:"tls_server_name,omitempty"`
 }
 
 var setupCmd = &cobra.Command{
@@ -62,12 +66,17 @@ var setupCmd = &cobra.Command{
 			}
 		}
 
+		tlsOpts := readTLSFlags(cmd)
 		cfg := setupConfig{
 			WorkerMetricsURL: metricsURL,
 			WorkerHealthURL:  healthURL,
 			EventsURL:        eventsURL,
 			DjangoURL:        djangoURL,
 			DjangoStatsURL:   djangoStatsURL,
+			CAFile:           tlsOpts.CAFile,
+			ClientCertFile:   tlsOpts.CertFile,
+			ClientKeyFile:    tlsOpts.KeyFile,
+			TLSServerName:    tlsOpts.ServerName,
 		}
 		if explicitWorkerURL {
 			cfg.WorkerURL = workerURL
@@ -94,8 +103,12 @@ var setupCmd = &cobra.Command{
 				MaxWait:     10 * time.Minute,
 				OpenBrowser: openBrowser,
 				AuthFile:    authFile,
+				TLS:         tlsOpts,
+			}
+			store, err := authStore(cmd)
+			if err != nil {
+				return err
 			}
-			store := authStore(authFile)
 			if err := runLoginFlow(opts, store); err != nil {
 				return err
 			}
@@ -114,7 +127,8 @@ func init() {
 	setupCmd.Flags().String("django-stats-url", "", "Django stats URL (optional)")
 	setupCmd.Flags().Bool("login", true, "Run login flow after writing config")
 	setupCmd.Flags().Bool("open-browser", true, "Open the approval URL in a browser")
-	setupCmd.Flags().String("auth-file", "", "Override auth token store path")
+	addAuthStoreFlags(setupCmd)
+	addTLSFlags(setupCmd)
 	setupCmd.Flags().Bool("force", false, "Overwrite the config file if it exists")
 	RootCmd.AddCommand(setupCmd)
 }
@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/internal/control"
 	"github.com/adpena/reproq-tui/internal/health"
 	"github.com/adpena/reproq-tui/internal/metrics"
 	"github.com/adpena/reproq-tui/internal/stats"
@@ -22,9 +24,13 @@ func TestDemoServerMetricsAndHealth(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	if _, err := metrics.Scrape(ctx, httpClient, server.MetricsURL, metrics.DefaultCatalog()); err != nil {
+	scraped, err := metrics.Scrape(ctx, httpClient, server.MetricsURL, metrics.DefaultCatalog())
+	if err != nil {
 		t.Fatalf("scrape metrics: %v", err)
 	}
+	if _, ok := scraped.Workers["worker-1"][metrics.MetricConcurrencyInUse]; !ok {
+		t.Fatalf("expected per-worker concurrency in demo metrics, got %v", scraped.Workers)
+	}
 	status, err := health.Fetch(ctx, httpClient, server.HealthURL)
 	if err != nil && status.CheckedAt.IsZero() {
 		t.Fatalf("fetch health: %v", err)
@@ -32,4 +38,41 @@ func TestDemoServerMetricsAndHealth(t *testing.T) {
 	if _, err := stats.Fetch(ctx, httpClient, server.StatsURL); err != nil {
 		t.Fatalf("fetch stats: %v", err)
 	}
+
+	baseURL := config.DeriveDjangoURL(server.StatsURL)
+	if _, err := control.PauseQueue(ctx, httpClient, baseURL, control.QueueRequest{Queue: "default", Reason: "demo"}); err != nil {
+		t.Fatalf("pause queue: %v", err)
+	}
+	if _, err := control.ResumeQueue(ctx, httpClient, baseURL, control.QueueRequest{Queue: "slow"}); err != nil {
+		t.Fatalf("resume queue: %v", err)
+	}
+	snapshot, err := stats.Fetch(ctx, httpClient, server.StatsURL)
+	if err != nil {
+		t.Fatalf("fetch stats: %v", err)
+	}
+	paused := map[string]bool{}
+	for _, c := range snapshot.QueueControls {
+		paused[c.QueueName] = c.Paused
+	}
+	if !paused["default"] || paused["slow"] {
+		t.Fatalf("expected default paused and slow resumed, got %v", paused)
+	}
+
+	failed, err := control.ListTasks(ctx, httpClient, baseURL, control.TaskQuery{TaskPath: "app.tasks.sync_inventory", Status: "FAILED"})
+	if err != nil || len(failed) == 0 {
+		t.Fatalf("list failed tasks: %v (%d)", err, len(failed))
+	}
+	if _, err := control.GetTask(ctx, httpClient, baseURL, failed[0].ResultID); err != nil {
+		t.Fatalf("get task: %v", err)
+	}
+	if _, err := control.SetPeriodicEnabled(ctx, httpClient, baseURL, control.PeriodicRequest{Name: "cleanup"}, false); err != nil {
+		t.Fatalf("disable periodic: %v", err)
+	}
+	if run, err := control.RunPeriodicNow(ctx, httpClient, baseURL, control.PeriodicRequest{Name: "cleanup"}); err != nil || run.ResultID == 0 {
+		t.Fatalf("run periodic: %v", err)
+	}
+	result, err := control.RetryTasks(ctx, httpClient, baseURL, control.TaskActionRequest{TaskPath: "app.tasks.sync_inventory"})
+	if err != nil || result.Affected != len(failed) {
+		t.Fatalf("retry tasks: %v (%d)", err, result.Affected)
+	}
 }
@@ -10,9 +10,13 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/adpena/reproq-tui/internal/cron"
 )
 
 type Server struct {
@@ -41,7 +45,7 @@ func Start() (*Server, error) {
 		MetricsURL: baseURL + "/metrics",
 		HealthURL:  baseURL + "/healthz",
 		EventsURL:  baseURL + "/events",
-		StatsURL:   baseURL + "/stats",
+		StatsURL:   baseURL + "/reproq/stats/",
 		state:      state,
 	}
 
@@ -50,6 +54,11 @@ func Start() (*Server, error) {
 	mux.HandleFunc("/healthz", server.handleHealth)
 	mux.HandleFunc("/events", server.handleEvents)
 	mux.HandleFunc("/stats", server.handleStats)
+	mux.HandleFunc("/reproq/stats/", server.handleStats)
+	mux.HandleFunc("/reproq/queues/pause/", server.handleQueueControl(true))
+	mux.HandleFunc("/reproq/queues/resume/", server.handleQueueControl(false))
+	mux.HandleFunc("/reproq/tasks/", server.handleTasks)
+	mux.HandleFunc("/reproq/periodic/", server.handlePeriodic)
 
 	server.HTTPServer = &http.Server{
 		Handler: mux,
@@ -79,12 +88,21 @@ func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
 	health := s.state.health
 	s.state.mu.RUnlock()
 
-	payload := map[string]string{
+	broker := "ok"
+	if health.httpStatus != http.StatusOK {
+		broker = "fail"
+	}
+	payload := map[string]interface{}{
 		"status":  health.status,
 		"version": "demo",
 		"build":   "dev",
 		"commit":  "demo",
 		"message": health.message,
+		"checks": map[string]interface{}{
+			"db":        "ok",
+			"broker":    map[string]string{"status": broker, "message": health.message},
+			"scheduler": "ok",
+		},
 	}
 	if health.httpStatus != http.StatusOK {
 		w.WriteHeader(health.httpStatus)
@@ -190,20 +208,7 @@ func (s *Server) handleStats(w http.ResponseWriter, _ *http.Request) {
 		})
 	}
 
-	periodic := []map[string]interface{}{
-		{
-			"name":        "cleanup",
-			"cron_expr":   "*/5 * * * *",
-			"enabled":     true,
-			"next_run_at": time.Now().Add(2 * time.Minute),
-		},
-		{
-			"name":        "sync-analytics",
-			"cron_expr":   "*/15 * * * *",
-			"enabled":     true,
-			"next_run_at": time.Now().Add(6 * time.Minute),
-		},
-	}
+	periodic := s.state.periodicList(time.Now())
 
 	payload := map[string]interface{}{
 		"tasks": map[string]int64{
@@ -213,19 +218,11 @@ func (s *Server) handleStats(w http.ResponseWriter, _ *http.Request) {
 			"FAILED":     failed,
 			"SUCCESSFUL": success,
 		},
-		"queues":   queues,
-		"workers":  workers,
-		"periodic": periodic,
-		"queue_controls": []map[string]interface{}{
-			{
-				"queue_name": "slow",
-				"paused":     true,
-				"paused_at":  time.Now().Add(-10 * time.Minute),
-				"reason":     "demo pause",
-				"updated_at": time.Now().Add(-2 * time.Minute),
-				"database":   "default",
-			},
-		},
+		"queues":         queues,
+		"workers":        workers,
+		"periodic":       periodic,
+		"queue_controls": s.state.queueControlList(),
+		"top_failing":    s.state.topFailing(),
 		"worker_health": map[string]int{
 			"alive": len(workers),
 			"dead":  0,
@@ -252,6 +249,317 @@ func (s *Server) handleStats(w http.ResponseWriter, _ *http.Request) {
 	_ = json.NewEncoder(w).Encode(payload)
 }
 
+func (s *Server) handleQueueControl(paused bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var req struct {
+			QueueName string `json:"queue_name"`
+			Database  string `json:"database"`
+			Reason    string `json:"reason"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.QueueName == "" {
+			http.Error(w, "queue_name is required", http.StatusBadRequest)
+			return
+		}
+		if req.Database == "" {
+			req.Database = "default"
+		}
+		control := s.state.setQueuePaused(req.QueueName, req.Database, req.Reason, paused)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(control)
+	}
+}
+
+type queueControl struct {
+	QueueName string    `json:"queue_name"`
+	Paused    bool      `json:"paused"`
+	PausedAt  time.Time `json:"paused_at,omitempty"`
+	Reason    string    `json:"reason"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Database  string    `json:"database"`
+}
+
+func (s *state) setQueuePaused(queue, database, reason string, paused bool) queueControl {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := database + "/" + queue
+	control := s.queueControls[key]
+	control.QueueName = queue
+	control.Database = database
+	control.Paused = paused
+	control.Reason = reason
+	control.UpdatedAt = time.Now()
+	if paused {
+		control.PausedAt = control.UpdatedAt
+	}
+	s.queueControls[key] = control
+	return control
+}
+
+func (s *state) queueControlList() []queueControl {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]queueControl, 0, len(s.queueControls))
+	for _, control := range s.queueControls {
+		out = append(out, control)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].QueueName < out[j].QueueName
+	})
+	return out
+}
+
+func (s *Server) handleTasks(w http.ResponseWriter, r *http.Request) {
+	rest := strings.Trim(strings.TrimPrefix(r.URL.Path, "/reproq/tasks/"), "/")
+	switch {
+	case rest == "" && r.Method == http.MethodGet:
+		query := r.URL.Query()
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		tasks := s.state.failedTasks(query.Get("task_path"), query.Get("status"), limit)
+		writeJSON(w, map[string]interface{}{"tasks": tasks})
+	case (rest == "retry" || rest == "cancel") && r.Method == http.MethodPost:
+		var req struct {
+			ResultIDs []int64 `json:"result_ids"`
+			TaskPath  string  `json:"task_path"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || (len(req.ResultIDs) == 0 && req.TaskPath == "") {
+			http.Error(w, "result_ids or task_path is required", http.StatusBadRequest)
+			return
+		}
+		affected := 0
+		if rest == "retry" {
+			affected = s.state.retryTasks(req.ResultIDs, req.TaskPath)
+		} else {
+			affected = s.state.cancelQueued(req.TaskPath)
+		}
+		writeJSON(w, map[string]int{"affected": affected})
+	case r.Method == http.MethodGet:
+		id, err := strconv.ParseInt(rest, 10, 64)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		task, ok := s.state.failedTask(id)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, task)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handlePeriodic(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	action := strings.Trim(strings.TrimPrefix(r.URL.Path, "/reproq/periodic/"), "/")
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+	switch action {
+	case "enable", "disable":
+		task, ok := s.state.setPeriodicEnabled(req.Name, action == "enable")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, task)
+	case "run":
+		id, ok := s.state.runPeriodic(req.Name)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, map[string]int64{"result_id": id})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+type demoPeriodic struct {
+	Name      string    `json:"name"`
+	CronExpr  string    `json:"cron_expr"`
+	Enabled   bool      `json:"enabled"`
+	NextRunAt time.Time `json:"next_run_at"`
+}
+
+func (s *state) periodicList(now time.Time) []demoPeriodic {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]demoPeriodic, 0, len(s.periodic))
+	for _, task := range s.periodic {
+		if schedule, err := cron.Parse(task.CronExpr, time.UTC); err == nil {
+			task.NextRunAt = schedule.Next(now)
+		}
+		out = append(out, task)
+	}
+	return out
+}
+
+func (s *state) setPeriodicEnabled(name string, enabled bool) (demoPeriodic, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.periodic {
+		if s.periodic[i].Name == name {
+			s.periodic[i].Enabled = enabled
+			return s.periodic[i], true
+		}
+	}
+	return demoPeriodic{}, false
+}
+
+func (s *state) runPeriodic(name string) (int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, task := range s.periodic {
+		if task.Name == name {
+			s.nextResultID++
+			s.queueDepth++
+			return s.nextResultID, true
+		}
+	}
+	return 0, false
+}
+
+func writeJSON(w http.ResponseWriter, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+type demoTask struct {
+	ResultID   int64                  `json:"result_id"`
+	TaskPath   string                 `json:"task_path"`
+	QueueName  string                 `json:"queue_name"`
+	Status     string                 `json:"status"`
+	Attempts   int                    `json:"attempts"`
+	Exception  string                 `json:"exception"`
+	Traceback  string                 `json:"traceback"`
+	Args       []interface{}          `json:"args"`
+	Kwargs     map[string]interface{} `json:"kwargs"`
+	EnqueuedAt time.Time              `json:"enqueued_at"`
+	FinishedAt time.Time              `json:"finished_at"`
+	WorkerID   string                 `json:"worker_id"`
+}
+
+func seedFailedTasks(now time.Time) []demoTask {
+	tasks := []demoTask{}
+	for i := 0; i < 6; i++ {
+		path, queue, exception := "app.tasks.send_email", "default", "SMTPServerDisconnected: Connection unexpectedly closed"
+		if i%3 == 2 {
+			path, queue, exception = "app.tasks.sync_inventory", "slow", "TimeoutError: upstream inventory API timed out"
+		}
+		tasks = append(tasks, demoTask{
+			ResultID:   int64(1000 + i),
+			TaskPath:   path,
+			QueueName:  queue,
+			Status:     "FAILED",
+			Attempts:   3,
+			Exception:  exception,
+			Traceback:  "Traceback (most recent call last):\n  File \"app/tasks.py\", line 42, in " + path[strings.LastIndex(path, ".")+1:] + "\n    client.send(payload)\n" + exception,
+			Args:       []interface{}{fmt.Sprintf("user-%d", 40+i)},
+			Kwargs:     map[string]interface{}{"priority": "normal"},
+			EnqueuedAt: now.Add(-time.Duration(30-i) * time.Minute),
+			FinishedAt: now.Add(-time.Duration(25-i) * time.Minute),
+			WorkerID:   fmt.Sprintf("worker-%d", i%3+1),
+		})
+	}
+	return tasks
+}
+
+func (s *state) failedTasks(taskPath, status string, limit int) []demoTask {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := []demoTask{}
+	if status != "" && !strings.EqualFold(status, "FAILED") {
+		return out
+	}
+	for i := len(s.failed) - 1; i >= 0; i-- {
+		task := s.failed[i]
+		if taskPath != "" && task.TaskPath != taskPath {
+			continue
+		}
+		out = append(out, task)
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+	}
+	return out
+}
+
+func (s *state) failedTask(id int64) (demoTask, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, task := range s.failed {
+		if task.ResultID == id {
+			return task, true
+		}
+	}
+	return demoTask{}, false
+}
+
+func (s *state) retryTasks(ids []int64, taskPath string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wanted := map[int64]bool{}
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	kept := s.failed[:0]
+	affected := 0
+	for _, task := range s.failed {
+		if wanted[task.ResultID] || (len(ids) == 0 && task.TaskPath == taskPath) {
+			affected++
+			continue
+		}
+		kept = append(kept, task)
+	}
+	s.failed = kept
+	s.queueDepth += float64(affected)
+	return affected
+}
+
+func (s *state) cancelQueued(taskPath string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	affected := s.queuedByPath[taskPath]
+	delete(s.queuedByPath, taskPath)
+	s.queueDepth = math.Max(0, s.queueDepth-float64(affected))
+	return affected
+}
+
+func (s *state) topFailing() []map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	counts := map[string]int64{}
+	for _, task := range s.failed {
+		counts[task.TaskPath]++
+	}
+	out := make([]map[string]interface{}, 0, len(counts))
+	for path, count := range counts {
+		out = append(out, map[string]interface{}{"task_path": path, "count": count})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		ci, cj := out[i]["count"].(int64), out[j]["count"].(int64)
+		if ci == cj {
+			return out[i]["task_path"].(string) < out[j]["task_path"].(string)
+		}
+		return ci > cj
+	})
+	return out
+}
+
 type state struct {
 	mu               sync.RWMutex
 	rnd              *rand.Rand
@@ -265,6 +573,11 @@ type state struct {
 	concurrencyLimit float64
 	latencyP95       float64
 	health           healthState
+	queueControls    map[string]queueControl
+	failed           []demoTask
+	queuedByPath     map[string]int
+	periodic         []demoPeriodic
+	nextResultID     int64
 	stopCh           chan struct{}
 }
 
@@ -286,7 +599,25 @@ func newState() *state {
 			httpStatus: http.StatusOK,
 			json:       true,
 		},
-		stopCh: make(chan struct{}),
+		queueControls: map[string]queueControl{
+			"default/slow": {
+				QueueName: "slow",
+				Paused:    true,
+				PausedAt:  time.Now().Add(-10 * time.Minute),
+				Reason:    "demo pause",
+				UpdatedAt: time.Now().Add(-2 * time.Minute),
+				Database:  "default",
+			},
+		},
+		failed:       seedFailedTasks(time.Now()),
+		queuedByPath: map[string]int{"app.tasks.send_email": 4, "app.tasks.sync_inventory": 2},
+		periodic: []demoPeriodic{
+			{Name: "cleanup", CronExpr: "*/5 * * * *", Enabled: true},
+			{Name: "sync-analytics", CronExpr: "*/15 * * * *", Enabled: true},
+			{Name: "nightly-report", CronExpr: "30 2 * * *", Enabled: false},
+		},
+		nextResultID: 5000,
+		stopCh:       make(chan struct{}),
 	}
 }
 
@@ -332,9 +663,10 @@ func (s *state) writeMetrics(w http.ResponseWriter) {
 	fmt.Fprintf(w, "# TYPE reproq_queue_depth gauge\n")
 	fmt.Fprintf(w, "reproq_queue_depth %.0f\n", s.queueDepth)
 
+	workerTotal := int(math.Max(1, math.Round(s.workerCount)))
 	fmt.Fprintf(w, "# HELP reproq_tasks_running Running tasks\n")
 	fmt.Fprintf(w, "# TYPE reproq_tasks_running gauge\n")
-	fmt.Fprintf(w, "reproq_tasks_running %.0f\n", s.tasksRunning)
+	writeWorkerGauge(w, "reproq_tasks_running", s.tasksRunning, workerTotal)
 
 	fmt.Fprintf(w, "# HELP reproq_tasks_processed_total Total tasks processed\n")
 	fmt.Fprintf(w, "# TYPE reproq_tasks_processed_total counter\n")
@@ -351,7 +683,7 @@ func (s *state) writeMetrics(w http.ResponseWriter) {
 
 	fmt.Fprintf(w, "# HELP reproq_concurrency_in_use Concurrency in use\n")
 	fmt.Fprintf(w, "# TYPE reproq_concurrency_in_use gauge\n")
-	fmt.Fprintf(w, "reproq_concurrency_in_use %.0f\n", s.concurrencyInUse)
+	writeWorkerGauge(w, "reproq_concurrency_in_use", s.concurrencyInUse, workerTotal)
 
 	fmt.Fprintf(w, "# HELP reproq_concurrency_limit Concurrency limit\n")
 	fmt.Fprintf(w, "# TYPE reproq_concurrency_limit gauge\n")
@@ -362,6 +694,17 @@ func (s *state) writeMetrics(w http.ResponseWriter) {
 	s.writeExecHistogram(w)
 }
 
+// writeWorkerGauge spreads a fleet-wide gauge across worker_id series so the
+// sum still matches the unlabelled value.
+func writeWorkerGauge(w http.ResponseWriter, name string, total float64, workers int) {
+	remaining := math.Round(total)
+	for i := 0; i < workers; i++ {
+		share := math.Ceil(remaining / float64(workers-i))
+		remaining -= share
+		fmt.Fprintf(w, "%s{worker_id=\"worker-%d\"} %.0f\n", name, i+1, share)
+	}
+}
+
 func (s *state) writeExecHistogram(w http.ResponseWriter) {
 	bounds := []float64{0.05, 0.1, 0.2, 0.35, 0.5, 0.75, 1.0, 1.5, 2.0}
 	total := int64(math.Max(1, math.Round(s.tasksTotal)))
@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	BackendFile      = "file"
+	BackendKeyring   = "keyring"
+	BackendEncrypted = "encrypted"
+	BackendAuto      = "auto"
+)
+
+const (
+	backendEnv    = "REPROQ_TUI_AUTH_STORE"
+	PassphraseEnv = "REPROQ_TUI_AUTH_PASSPHRASE"
+)
+
+type StoreOptions struct {
+	// Backend is file, keyring, encrypted, or auto (keyring when available,
+	// otherwise file). Empty falls back to REPROQ_TUI_AUTH_STORE, then file.
+	Backend string
+	// Path is the plaintext token file; it is also the migration source for
+	// the other backends.
+	Path string
+	// PassphraseFile is read when REPROQ_TUI_AUTH_PASSPHRASE is unset.
+	PassphraseFile string
+	// Profile selects the named token; empty means the default profile.
+	Profile string
+	// Keyring overrides the Secret Service, mainly for tests.
+	Keyring Keyring
+}
+
+// OpenStore builds the configured token store for opts.Profile. Non-file
+// backends absorb any plaintext tokens left by the file store and delete the
+// plaintext copies.
+func OpenStore(opts StoreOptions) (TokenStore, error) {
+	if err := checkProfile(opts.Profile); err != nil {
+		return nil, err
+	}
+	plain := DefaultStore()
+	if opts.Path != "" {
+		plain = NewStore(opts.Path)
+	}
+	plain = plain.forProfile(opts.Profile)
+	backend := strings.ToLower(strings.TrimSpace(opts.Backend))
+	if backend == "" {
+		backend = strings.ToLower(strings.TrimSpace(os.Getenv(backendEnv)))
+	}
+	var target TokenStore
+	switch backend {
+	case "", BackendFile:
+		return plain, nil
+	case BackendKeyring, BackendAuto:
+		keyring := opts.Keyring
+		if keyring == nil {
+			var err error
+			keyring, err = SecretServiceKeyring()
+			if err != nil {
+				if backend == BackendAuto {
+					return plain, nil
+				}
+				return nil, err
+			}
+		}
+		target = NewKeyringStore(keyring, opts.Profile)
+	case BackendEncrypted:
+		passphrase, err := readPassphrase(opts.PassphraseFile)
+		if err != nil {
+			return nil, err
+		}
+		target = NewEncryptedStore(encryptedPath(plain.base), passphrase).WithProfile(opts.Profile)
+	default:
+		return nil, fmt.Errorf("unknown auth store %q (want file, keyring, encrypted, or auto)", opts.Backend)
+	}
+	profiles, err := plain.Profiles()
+	if err != nil {
+		return nil, err
+	}
+	for _, profile := range profiles {
+		source := plain.forProfile(profile)
+		if _, err := MigratePlaintext(source, target.WithProfile(profile)); err != nil {
+			return nil, fmt.Errorf("migrate plaintext token from %s: %w", source.Path(), err)
+		}
+	}
+	return target, nil
+}
+
+// MigratePlaintext moves a plaintext token into target and removes the file.
+// A token already in target wins; the plaintext copy is removed either way.
+// It reports whether a plaintext file was found.
+func MigratePlaintext(plain *Store, target TokenStore) (bool, error) {
+	token, err := plain.Load()
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if _, err := target.Load(); errors.Is(err, ErrNotFound) {
+		if err := target.Save(token); err != nil {
+			return false, err
+		}
+	} else if err != nil {
+		return false, err
+	}
+	return true, plain.Clear()
+}
+
+func readPassphrase(path string) (string, error) {
+	if val := os.Getenv(PassphraseEnv); val != "" {
+		return val, nil
+	}
+	if path == "" {
+		return "", ErrPassphraseRequired
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read auth passphrase file: %w", err)
+	}
+	passphrase := strings.TrimRight(string(data), "\r\n")
+	if passphrase == "" {
+		return "", ErrPassphraseRequired
+	}
+	return passphrase, nil
+}
+
+func encryptedPath(plainPath string) string {
+	ext := filepath.Ext(plainPath)
+	return strings.TrimSuffix(plainPath, ext) + ".enc"
+}
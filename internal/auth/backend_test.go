@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type memoryKeyring struct {
+	secrets map[string]string
+}
+
+func newMemoryKeyring() *memoryKeyring {
+	return &memoryKeyring{secrets: map[string]string{}}
+}
+
+func (k *memoryKeyring) Get(service, account string) (string, error) {
+	secret, ok := k.secrets[service+"/"+account]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return secret, nil
+}
+
+func (k *memoryKeyring) Set(service, account, _ string, secret string) error {
+	k.secrets[service+"/"+account] = secret
+	return nil
+}
+
+func (k *memoryKeyring) Delete(service, account string) error {
+	delete(k.secrets, service+"/"+account)
+	return nil
+}
+
+func (k *memoryKeyring) List(service string) ([]string, error) {
+	accounts := []string{}
+	for key := range k.secrets {
+		if name, ok := strings.CutPrefix(key, service+"/"); ok {
+			accounts = append(accounts, name)
+		}
+	}
+	return accounts, nil
+}
+
+func TestKeyringStoreRoundTrip(t *testing.T) {
+	keyring := newMemoryKeyring()
+	store := NewKeyringStore(keyring, "")
+	if _, err := store.Load(); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	token := Token{Value: "secret-1", DjangoURL: "https://django.example.com"}
+	if err := store.Save(token); err != nil {
+		t.Fatalf("save token: %v", err)
+	}
+	if raw := keyring.secrets["reproq-tui/default"]; !strings.Contains(raw, "secret-1") {
+		t.Fatalf("expected token in keyring, got %q", raw)
+	}
+	loaded, err := store.Load()
+	if err != nil || loaded.Value != token.Value || loaded.DjangoURL != token.DjangoURL {
+		t.Fatalf("unexpected token %+v (%v)", loaded, err)
+	}
+	if err := store.Clear(); err != nil {
+		t.Fatalf("clear token: %v", err)
+	}
+	if err := store.Clear(); err != nil {
+		t.Fatalf("clear twice: %v", err)
+	}
+}
+
+func TestSecretToolCommands(t *testing.T) {
+	var calls []string
+	var stdinSeen string
+	stored := ""
+	tool := &secretTool{timeout: time.Second, run: func(_ context.Context, stdin, name string, args ...string) (string, error) {
+		calls = append(calls, name+" "+strings.Join(args, " "))
+		switch args[0] {
+		case "store":
+			stdinSeen = stdin
+			stored = stdin
+		case "lookup":
+			if stored == "" {
+				return "", &exec.ExitError{}
+			}
+			return stored, nil
+		case "clear":
+			stored = ""
+		}
+		return "", nil
+	}}
+	if _, err := tool.Get("reproq-tui", "default"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected not found for empty lookup, got %v", err)
+	}
+	if err := tool.Set("reproq-tui", "default", "label", `{"token":"t"}`); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if stdinSeen != `{"token":"t"}` {
+		t.Fatalf("expected secret on stdin, got %q", stdinSeen)
+	}
+	if got, err := tool.Get("reproq-tui", "default"); err != nil || got != `{"token":"t"}` {
+		t.Fatalf("unexpected lookup %q (%v)", got, err)
+	}
+	if err := tool.Delete("reproq-tui", "default"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	want := "secret-tool store --label=label service reproq-tui account default"
+	if calls[1] != want {
+		t.Fatalf("expected %q, got %q", want, calls[1])
+	}
+}
+
+func TestEncryptedStore(t *testing.T) {
+	encryptedIterations = 1000
+	t.Cleanup(func() { encryptedIterations = 600_000 })
+	path := filepath.Join(t.TempDir(), "auth.enc")
+	store := NewEncryptedStore(path, "correct horse")
+	token := Token{Value: "secret-2", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := store.Save(token); err != nil {
+		t.Fatalf("save token: %v", err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read encrypted file: %v", err)
+	}
+	if strings.Contains(string(raw), "secret-2") {
+		t.Fatalf("expected token to be encrypted on disk")
+	}
+	loaded, err := store.Load()
+	if err != nil || loaded.Value != token.Value {
+		t.Fatalf("unexpected token %+v (%v)", loaded, err)
+	}
+	if _, err := NewEncryptedStore(path, "wrong").Load(); !errors.Is(err, ErrBadPassphrase) {
+		t.Fatalf("expected bad passphrase error, got %v", err)
+	}
+	if _, err := NewEncryptedStore(path, "").Load(); !errors.Is(err, ErrPassphraseRequired) {
+		t.Fatalf("expected passphrase required error, got %v", err)
+	}
+}
+
+func TestOpenStoreMigratesPlaintext(t *testing.T) {
+	encryptedIterations = 1000
+	t.Cleanup(func() { encryptedIterations = 600_000 })
+	t.Setenv(backendEnv, "")
+	t.Setenv(PassphraseEnv, "")
+	dir := t.TempDir()
+	plainPath := filepath.Join(dir, "auth.json")
+	token := Token{Value: "plain-1", DjangoURL: "https://django.example.com"}
+
+	if err := NewStore(plainPath).Save(token); err != nil {
+		t.Fatalf("seed plaintext: %v", err)
+	}
+	keyring := newMemoryKeyring()
+	store, err := OpenStore(StoreOptions{Backend: BackendKeyring, Path: plainPath, Keyring: keyring})
+	if err != nil {
+		t.Fatalf("open keyring store: %v", err)
+	}
+	if loaded, err := store.Load(); err != nil || loaded.Value != "plain-1" {
+		t.Fatalf("expected migrated token, got %+v (%v)", loaded, err)
+	}
+	if _, err := os.Stat(plainPath); !os.IsNotExist(err) {
+		t.Fatalf("expected plaintext file to be removed, got %v", err)
+	}
+
+	if err := NewStore(plainPath).Save(token); err != nil {
+		t.Fatalf("seed plaintext: %v", err)
+	}
+	if _, err := OpenStore(StoreOptions{Backend: BackendEncrypted, Path: plainPath}); !errors.Is(err, ErrPassphraseRequired) {
+		t.Fatalf("expected passphrase required, got %v", err)
+	}
+	passFile := filepath.Join(dir, "passphrase")
+	if err := os.WriteFile(passFile, []byte("hunter2\n"), 0o600); err != nil {
+		t.Fatalf("write passphrase: %v", err)
+	}
+	store, err = OpenStore(StoreOptions{Backend: BackendEncrypted, Path: plainPath, PassphraseFile: passFile})
+	if err != nil {
+		t.Fatalf("open encrypted store: %v", err)
+	}
+	if got := store.Describe(); got != "encrypted file "+filepath.Join(dir, "auth.enc") {
+		t.Fatalf("unexpected store %q", got)
+	}
+	if loaded, err := store.Load(); err != nil || loaded.Value != "plain-1" {
+		t.Fatalf("expected migrated token, got %+v (%v)", loaded, err)
+	}
+	if _, err := os.Stat(plainPath); !os.IsNotExist(err) {
+		t.Fatalf("expected plaintext file to be removed, got %v", err)
+	}
+
+	store, err = OpenStore(StoreOptions{Path: plainPath})
+	if err != nil {
+		t.Fatalf("open file store: %v", err)
+	}
+	if _, ok := store.(*Store); !ok {
+		t.Fatalf("expected file store by default, got %T", store)
+	}
+	if _, err := OpenStore(StoreOptions{Backend: "vault", Path: plainPath}); err == nil {
+		t.Fatalf("expected unknown backend error")
+	}
+}
+
+func TestOpenStoreProfiles(t *testing.T) {
+	t.Setenv(backendEnv, "")
+	dir := t.TempDir()
+	plainPath := filepath.Join(dir, "auth.json")
+	if err := NewStore(plainPath).WithProfile("prod").Save(Token{Value: "prod-1"}); err != nil {
+		t.Fatalf("seed plaintext: %v", err)
+	}
+	if err := NewStore(plainPath).Save(Token{Value: "default-1"}); err != nil {
+		t.Fatalf("seed plaintext: %v", err)
+	}
+	keyring := newMemoryKeyring()
+	store, err := OpenStore(StoreOptions{Backend: BackendKeyring, Path: plainPath, Profile: "prod", Keyring: keyring})
+	if err != nil {
+		t.Fatalf("open keyring store: %v", err)
+	}
+	if store.Profile() != "prod" || store.Describe() != "keyring reproq-tui/prod" {
+		t.Fatalf("unexpected store %q", store.Describe())
+	}
+	if loaded, err := store.Load(); err != nil || loaded.Value != "prod-1" {
+		t.Fatalf("expected migrated prod token, got %+v (%v)", loaded, err)
+	}
+	if loaded, err := store.WithProfile("").Load(); err != nil || loaded.Value != "default-1" {
+		t.Fatalf("expected migrated default token, got %+v (%v)", loaded, err)
+	}
+	if profiles, _ := store.Profiles(); strings.Join(profiles, ",") != "default,prod" {
+		t.Fatalf("unexpected keyring profiles %v", profiles)
+	}
+	if _, err := OpenStore(StoreOptions{Path: plainPath, Profile: "../prod"}); err == nil {
+		t.Fatalf("expected invalid profile error")
+	}
+}
@@ -10,15 +10,15 @@ import (
 )
 
 type TUIConfig struct {
-	WorkerURL        string
-	WorkerMetricsURL string
-	WorkerHealthURL  string
-	EventsURL        string
-	LowMemoryMode    bool
+	WorkerURL        string `json:"worker_url,omitempty"`
+	WorkerMetricsURL string `json:"worker_metrics_url,omitempty"`
+	WorkerHealthURL  string `json:"worker_health_url,omitempty"`
+	EventsURL        string `json:"events_url,omitempty"`
+	LowMemoryMode    bool   `json:"low_memory_mode,omitempty"`
 }
 
 func FetchConfig(ctx context.Context, httpClient *client.Client, baseURL string) (TUIConfig, error) {
-	configURL, err := joinURL(baseURL, "/reproq/tui/config/")
+	configURL, err := client.JoinURL(baseURL, "/reproq/tui/config/")
 	if err != nil {
 		return TUIConfig{}, err
 	}
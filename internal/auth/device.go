@@ -0,0 +1,246 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/client"
+)
+
+const deviceCodeGrant = "urn:ietf:params:oauth:grant-type:device_code"
+
+// slowDownStep is how much RFC 8628 asks clients to back off on slow_down.
+const slowDownStep = 5 * time.Second
+
+// DeviceConfig describes an OAuth 2.0 identity provider for the device
+// authorization grant (RFC 8628). Endpoints left empty are discovered from
+// the issuer's OpenID configuration.
+type DeviceConfig struct {
+	Issuer        string
+	ClientID      string
+	Scopes        []string
+	DeviceAuthURL string
+	TokenURL      string
+}
+
+func (c DeviceConfig) Enabled() bool {
+	return strings.TrimSpace(c.ClientID) != "" && (strings.TrimSpace(c.Issuer) != "" || (c.DeviceAuthURL != "" && c.TokenURL != ""))
+}
+
+type DeviceAuthorization struct {
+	DeviceCode string
+	UserCode   string
+	VerifyURL  string
+	ExpiresAt  time.Time
+	Interval   time.Duration
+}
+
+// OAuthError is an error response from the token or device endpoint.
+type OAuthError struct {
+	Code        string
+	Description string
+}
+
+func (e OAuthError) Error() string {
+	if e.Description == "" {
+		return "oauth: " + e.Code
+	}
+	return fmt.Sprintf("oauth: %s: %s", e.Code, e.Description)
+}
+
+// IsOAuthError reports whether err is an OAuthError with one of codes.
+func IsOAuthError(err error, codes ...string) bool {
+	var oauthErr OAuthError
+	if !errors.As(err, &oauthErr) {
+		return false
+	}
+	for _, code := range codes {
+		if oauthErr.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// DiscoverDevice fills missing endpoints from the issuer's
+// /.well-known/openid-configuration.
+func DiscoverDevice(ctx context.Context, httpClient *client.Client, cfg DeviceConfig) (DeviceConfig, error) {
+	if cfg.DeviceAuthURL != "" && cfg.TokenURL != "" {
+		return cfg, nil
+	}
+	if strings.TrimSpace(cfg.Issuer) == "" {
+		return cfg, errors.New("oidc issuer is required for endpoint discovery")
+	}
+	discoveryURL, err := client.JoinURL(cfg.Issuer, "/.well-known/openid-configuration")
+	if err != nil {
+		return cfg, err
+	}
+	resp, err := httpClient.Get(ctx, discoveryURL)
+	if err != nil {
+		return cfg, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return cfg, client.StatusError{URL: discoveryURL, Code: resp.StatusCode}
+	}
+	var payload struct {
+		DeviceAuthURL string `json:"device_authorization_endpoint"`
+		TokenURL      string `json:"token_endpoint"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return cfg, err
+	}
+	if cfg.DeviceAuthURL == "" {
+		cfg.DeviceAuthURL = strings.TrimSpace(payload.DeviceAuthURL)
+	}
+	if cfg.TokenURL == "" {
+		cfg.TokenURL = strings.TrimSpace(payload.TokenURL)
+	}
+	if cfg.DeviceAuthURL == "" || cfg.TokenURL == "" {
+		return cfg, fmt.Errorf("issuer %s does not advertise the device authorization grant", cfg.Issuer)
+	}
+	return cfg, nil
+}
+
+// StartDevice requests a device and user code. cfg must already have its
+// endpoints resolved (see DiscoverDevice).
+func StartDevice(ctx context.Context, httpClient *client.Client, cfg DeviceConfig) (DeviceAuthorization, error) {
+	form := url.Values{"client_id": {cfg.ClientID}}
+	if len(cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(cfg.Scopes, " "))
+	}
+	var payload struct {
+		DeviceCode              string `json:"device_code"`
+		UserCode                string `json:"user_code"`
+		VerificationURI         string `json:"verification_uri"`
+		VerificationURIComplete string `json:"verification_uri_complete"`
+		ExpiresIn               int64  `json:"expires_in"`
+		Interval                int64  `json:"interval"`
+	}
+	if err := postForm(ctx, httpClient, cfg.DeviceAuthURL, form, &payload); err != nil {
+		return DeviceAuthorization{}, err
+	}
+	if payload.DeviceCode == "" || payload.UserCode == "" {
+		return DeviceAuthorization{}, errors.New("device authorization response missing codes")
+	}
+	device := DeviceAuthorization{
+		DeviceCode: payload.DeviceCode,
+		UserCode:   payload.UserCode,
+		VerifyURL:  firstNonEmptyString(payload.VerificationURIComplete, payload.VerificationURI),
+		Interval:   5 * time.Second,
+	}
+	if payload.ExpiresIn > 0 {
+		device.ExpiresAt = time.Now().Add(time.Duration(payload.ExpiresIn) * time.Second)
+	}
+	if payload.Interval > 0 {
+		device.Interval = time.Duration(payload.Interval) * time.Second
+	}
+	return device, nil
+}
+
+// PollDevice makes one token request for a pending device authorization.
+// While the user has not approved yet it returns an OAuthError with code
+// authorization_pending or slow_down; see NextDeviceInterval.
+func PollDevice(ctx context.Context, httpClient *client.Client, cfg DeviceConfig, deviceCode string) (Token, error) {
+	return exchangeToken(ctx, httpClient, cfg.TokenURL, url.Values{
+		"grant_type":  {deviceCodeGrant},
+		"device_code": {deviceCode},
+		"client_id":   {cfg.ClientID},
+	})
+}
+
+// NextDeviceInterval returns the polling interval to use after err.
+func NextDeviceInterval(current time.Duration, err error) time.Duration {
+	if IsOAuthError(err, "slow_down") {
+		return current + slowDownStep
+	}
+	return current
+}
+
+// RefreshOAuthToken redeems a refresh token. Providers that do not rotate
+// refresh tokens get the old one carried over.
+func RefreshOAuthToken(ctx context.Context, httpClient *client.Client, cfg DeviceConfig, refreshToken string) (Token, error) {
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+		"client_id":     {cfg.ClientID},
+	}
+	token, err := exchangeToken(ctx, httpClient, cfg.TokenURL, form)
+	if err != nil {
+		return Token{}, err
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, nil
+}
+
+func exchangeToken(ctx context.Context, httpClient *client.Client, tokenURL string, form url.Values) (Token, error) {
+	var payload struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		ExpiresIn    int64  `json:"expires_in"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := postForm(ctx, httpClient, tokenURL, form, &payload); err != nil {
+		return Token{}, err
+	}
+	if payload.AccessToken == "" {
+		return Token{}, errors.New("token response missing access_token")
+	}
+	if payload.TokenType != "" && !strings.EqualFold(payload.TokenType, "bearer") {
+		return Token{}, fmt.Errorf("unsupported token type %q", payload.TokenType)
+	}
+	token := Token{Value: payload.AccessToken, RefreshToken: payload.RefreshToken}
+	if payload.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(payload.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// postForm posts an OAuth form and decodes a JSON success body into out.
+// Error bodies in the RFC 6749 format become OAuthError.
+func postForm(ctx context.Context, httpClient *client.Client, endpoint string, form url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var oauthErr struct {
+			Code        string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		if json.Unmarshal(data, &oauthErr) == nil && oauthErr.Code != "" {
+			return OAuthError{Code: oauthErr.Code, Description: oauthErr.Description}
+		}
+		return client.StatusError{URL: endpoint, Code: resp.StatusCode}
+	}
+	return json.Unmarshal(data, out)
+}
+
+func firstNonEmptyString(values ...string) string {
+	for _, val := range values {
+		if strings.TrimSpace(val) != "" {
+			return val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/client"
+)
+
+// fakeIdP is a minimal RFC 8628 provider: the device code is approved after
+// pendingPolls token requests, and the first poll is answered with slow_down.
+type fakeIdP struct {
+	mu           sync.Mutex
+	server       *httptest.Server
+	pendingPolls int
+	polls        int
+	refreshes    int
+	scope        string
+}
+
+func newFakeIdP(t *testing.T, pendingPolls int) *fakeIdP {
+	t.Helper()
+	idp := &fakeIdP{pendingPolls: pendingPolls}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                        idp.server.URL,
+			"device_authorization_endpoint": idp.server.URL + "/device",
+			"token_endpoint":                idp.server.URL + "/token",
+		})
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		idp.mu.Lock()
+		idp.scope = r.PostForm.Get("scope")
+		idp.mu.Unlock()
+		if r.PostForm.Get("client_id") != "reproq-tui" {
+			writeOAuthError(w, "invalid_client")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"device_code":               "dev-123",
+			"user_code":                 "ABCD-EFGH",
+			"verification_uri":          idp.server.URL + "/activate",
+			"verification_uri_complete": idp.server.URL + "/activate?user_code=ABCD-EFGH",
+			"expires_in":                600,
+			"interval":                  1,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		idp.mu.Lock()
+		defer idp.mu.Unlock()
+		switch r.PostForm.Get("grant_type") {
+		case deviceCodeGrant:
+			if r.PostForm.Get("device_code") != "dev-123" {
+				writeOAuthError(w, "expired_token")
+				return
+			}
+			idp.polls++
+			if idp.polls == 1 {
+				writeOAuthError(w, "slow_down")
+				return
+			}
+			if idp.polls <= idp.pendingPolls {
+				writeOAuthError(w, "authorization_pending")
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token":  "access-1",
+				"token_type":    "Bearer",
+				"expires_in":    300,
+				"refresh_token": "refresh-1",
+			})
+		case "refresh_token":
+			if r.PostForm.Get("refresh_token") != "refresh-1" {
+				writeOAuthError(w, "invalid_grant")
+				return
+			}
+			idp.refreshes++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": "access-2",
+				"token_type":   "bearer",
+				"expires_in":   300,
+			})
+		default:
+			writeOAuthError(w, "unsupported_grant_type")
+		}
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func writeOAuthError(w http.ResponseWriter, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": code})
+}
+
+func TestDeviceFlow(t *testing.T) {
+	idp := newFakeIdP(t, 3)
+	httpClient := client.New(client.Options{Timeout: 2 * time.Second})
+	ctx := context.Background()
+
+	cfg, err := DiscoverDevice(ctx, httpClient, DeviceConfig{Issuer: idp.server.URL, ClientID: "reproq-tui", Scopes: []string{"openid", "offline_access"}})
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	if cfg.TokenURL != idp.server.URL+"/token" || !cfg.Enabled() {
+		t.Fatalf("unexpected discovered config %+v", cfg)
+	}
+	device, err := StartDevice(ctx, httpClient, cfg)
+	if err != nil {
+		t.Fatalf("start device: %v", err)
+	}
+	if device.UserCode != "ABCD-EFGH" || device.Interval != time.Second || device.VerifyURL != idp.server.URL+"/activate?user_code=ABCD-EFGH" {
+		t.Fatalf("unexpected device authorization %+v", device)
+	}
+	if idp.scope != "openid offline_access" {
+		t.Fatalf("expected scopes to be sent, got %q", idp.scope)
+	}
+
+	interval := device.Interval
+	var token Token
+	for i := 0; i < 5; i++ {
+		token, err = PollDevice(ctx, httpClient, cfg, device.DeviceCode)
+		if err == nil {
+			break
+		}
+		if !IsOAuthError(err, "authorization_pending", "slow_down") {
+			t.Fatalf("unexpected poll error: %v", err)
+		}
+		interval = NextDeviceInterval(interval, err)
+	}
+	if err != nil {
+		t.Fatalf("device flow did not complete: %v", err)
+	}
+	if interval != device.Interval+slowDownStep {
+		t.Fatalf("expected slow_down to extend interval once, got %v", interval)
+	}
+	if token.Value != "access-1" || token.RefreshToken != "refresh-1" || token.ExpiresAt.IsZero() {
+		t.Fatalf("unexpected token %+v", token)
+	}
+
+	refreshed, err := RefreshOAuthToken(ctx, httpClient, cfg, token.RefreshToken)
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if refreshed.Value != "access-2" || refreshed.RefreshToken != "refresh-1" {
+		t.Fatalf("unexpected refreshed token %+v", refreshed)
+	}
+	if _, err := RefreshOAuthToken(ctx, httpClient, cfg, "revoked"); !IsOAuthError(err, "invalid_grant") {
+		t.Fatalf("expected invalid_grant, got %v", err)
+	}
+	if _, err := PollDevice(ctx, httpClient, cfg, "unknown"); !IsOAuthError(err, "expired_token") {
+		t.Fatalf("expected expired_token, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	encryptedVersion = 1
+	encryptedKDF     = "pbkdf2-sha256"
+)
+
+// encryptedIterations is a variable so tests can keep key derivation cheap.
+var encryptedIterations = 600_000
+
+var (
+	ErrPassphraseRequired = errors.New("auth passphrase required for the encrypted token store")
+	ErrBadPassphrase      = errors.New("auth token could not be decrypted (wrong passphrase?)")
+)
+
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// EncryptedStore keeps the token in an AES-256-GCM sealed file keyed by a
+// passphrase, for machines without a Secret Service.
+type EncryptedStore struct {
+	base       string
+	profile    string
+	passphrase string
+}
+
+func NewEncryptedStore(path, passphrase string) *EncryptedStore {
+	return &EncryptedStore{base: path, profile: DefaultProfile, passphrase: passphrase}
+}
+
+func (s *EncryptedStore) Path() string {
+	return profilePath(s.base, s.profile)
+}
+
+func (s *EncryptedStore) Describe() string {
+	return "encrypted file " + s.Path()
+}
+
+func (s *EncryptedStore) Profile() string {
+	return s.profile
+}
+
+func (s *EncryptedStore) Profiles() ([]string, error) {
+	return pathProfiles(s.base)
+}
+
+func (s *EncryptedStore) WithProfile(profile string) TokenStore {
+	return &EncryptedStore{base: s.base, profile: normalizeProfile(profile), passphrase: s.passphrase}
+}
+
+func (s *EncryptedStore) Load() (Token, error) {
+	data, err := os.ReadFile(s.Path())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Token{}, ErrNotFound
+		}
+		return Token{}, err
+	}
+	var envelope encryptedFile
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return Token{}, err
+	}
+	if envelope.Version != encryptedVersion || envelope.KDF != encryptedKDF {
+		return Token{}, fmt.Errorf("unsupported encrypted token file (version %d, kdf %q)", envelope.Version, envelope.KDF)
+	}
+	gcm, err := s.cipher(envelope.Salt, envelope.Iterations)
+	if err != nil {
+		return Token{}, err
+	}
+	if len(envelope.Nonce) != gcm.NonceSize() {
+		return Token{}, ErrBadPassphrase
+	}
+	plain, err := gcm.Open(nil, envelope.Nonce, envelope.Ciphertext, nil)
+	if err != nil {
+		return Token{}, ErrBadPassphrase
+	}
+	var token Token
+	if err := json.Unmarshal(plain, &token); err != nil {
+		return Token{}, err
+	}
+	return token, nil
+}
+
+func (s *EncryptedStore) Save(token Token) error {
+	plain, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	envelope := encryptedFile{
+		Version:    encryptedVersion,
+		KDF:        encryptedKDF,
+		Iterations: encryptedIterations,
+		Salt:       make([]byte, 16),
+	}
+	if _, err := rand.Read(envelope.Salt); err != nil {
+		return err
+	}
+	gcm, err := s.cipher(envelope.Salt, envelope.Iterations)
+	if err != nil {
+		return err
+	}
+	envelope.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(envelope.Nonce); err != nil {
+		return err
+	}
+	envelope.Ciphertext = gcm.Seal(nil, envelope.Nonce, plain, nil)
+	data, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.base), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(s.Path(), data, 0o600)
+}
+
+func (s *EncryptedStore) Clear() error {
+	if err := os.Remove(s.Path()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *EncryptedStore) cipher(salt []byte, iterations int) (cipher.AEAD, error) {
+	if s.passphrase == "" {
+		return nil, ErrPassphraseRequired
+	}
+	if iterations <= 0 {
+		return nil, fmt.Errorf("invalid kdf iterations %d", iterations)
+	}
+	key, err := pbkdf2.Key(sha256.New, s.passphrase, salt, iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,181 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+const keyringService = "reproq-tui"
+
+var ErrKeyringUnavailable = errors.New("secret service keyring unavailable")
+
+// Keyring is the minimal secret storage API the keyring backend needs. Get
+// returns ErrNotFound when no secret is stored for the account.
+type Keyring interface {
+	Get(service, account string) (string, error)
+	Set(service, account, label, secret string) error
+	Delete(service, account string) error
+	List(service string) ([]string, error)
+}
+
+type KeyringStore struct {
+	keyring Keyring
+	service string
+	account string
+}
+
+// NewKeyringStore stores each profile's token under its own keyring account.
+func NewKeyringStore(keyring Keyring, profile string) *KeyringStore {
+	return &KeyringStore{keyring: keyring, service: keyringService, account: normalizeProfile(profile)}
+}
+
+func (s *KeyringStore) Describe() string {
+	return fmt.Sprintf("keyring %s/%s", s.service, s.account)
+}
+
+func (s *KeyringStore) Profile() string {
+	return s.account
+}
+
+func (s *KeyringStore) Profiles() ([]string, error) {
+	accounts, err := s.keyring.List(s.service)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(accounts)
+	return accounts, nil
+}
+
+func (s *KeyringStore) WithProfile(profile string) TokenStore {
+	return &KeyringStore{keyring: s.keyring, service: s.service, account: normalizeProfile(profile)}
+}
+
+func (s *KeyringStore) Load() (Token, error) {
+	secret, err := s.keyring.Get(s.service, s.account)
+	if err != nil {
+		return Token{}, err
+	}
+	var token Token
+	if err := json.Unmarshal([]byte(secret), &token); err != nil {
+		return Token{}, err
+	}
+	return token, nil
+}
+
+func (s *KeyringStore) Save(token Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	label := "reproq-tui token"
+	if token.DjangoURL != "" {
+		label += " for " + token.DjangoURL
+	}
+	return s.keyring.Set(s.service, s.account, label, string(data))
+}
+
+func (s *KeyringStore) Clear() error {
+	if err := s.keyring.Delete(s.service, s.account); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+type commandRunner func(ctx context.Context, stdin string, name string, args ...string) (string, error)
+
+// secretTool talks to the freedesktop Secret Service over D-Bus through
+// libsecret's secret-tool, which ships with GNOME Keyring and KWallet setups.
+type secretTool struct {
+	run     commandRunner
+	timeout time.Duration
+}
+
+// SecretServiceKeyring returns the Linux Secret Service keyring.
+func SecretServiceKeyring() (Keyring, error) {
+	if runtime.GOOS != "linux" {
+		return nil, fmt.Errorf("%w on %s", ErrKeyringUnavailable, runtime.GOOS)
+	}
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return nil, fmt.Errorf("%w: secret-tool not found (install libsecret-tools)", ErrKeyringUnavailable)
+	}
+	return &secretTool{run: runCommand, timeout: 5 * time.Second}, nil
+}
+
+func (t *secretTool) Get(service, account string) (string, error) {
+	out, err := t.exec("", "lookup", "service", service, "account", account)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && strings.TrimSpace(out) == "" {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	if out == "" {
+		return "", ErrNotFound
+	}
+	return out, nil
+}
+
+func (t *secretTool) Set(service, account, label, secret string) error {
+	_, err := t.exec(secret, "store", "--label="+label, "service", service, "account", account)
+	return err
+}
+
+func (t *secretTool) Delete(service, account string) error {
+	_, err := t.exec("", "clear", "service", service, "account", account)
+	return err
+}
+
+func (t *secretTool) List(service string) ([]string, error) {
+	out, err := t.exec("", "search", "--all", "service", service)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && strings.TrimSpace(out) == "" {
+			return nil, nil
+		}
+		return nil, err
+	}
+	seen := map[string]bool{}
+	accounts := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		name, value, ok := strings.Cut(line, "=")
+		if !ok || strings.TrimSpace(name) != "attribute.account" {
+			continue
+		}
+		account := strings.TrimSpace(value)
+		if account != "" && !seen[account] {
+			seen[account] = true
+			accounts = append(accounts, account)
+		}
+	}
+	return accounts, nil
+}
+
+func (t *secretTool) exec(stdin string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), t.timeout)
+	defer cancel()
+	return t.run(ctx, stdin, "secret-tool", args...)
+}
+
+func runCommand(ctx context.Context, stdin string, name string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.String(), fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return stdout.String(), fmt.Errorf("%s: %w", name, err)
+	}
+	return stdout.String(), nil
+}
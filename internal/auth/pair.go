@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
 	"strings"
 	"time"
 
@@ -30,7 +29,7 @@ type PairStatus struct {
 }
 
 func StartPair(ctx context.Context, httpClient *client.Client, baseURL string) (Pairing, error) {
-	pairURL, err := joinURL(baseURL, "/reproq/tui/pair/")
+	pairURL, err := client.JoinURL(baseURL, "/reproq/tui/pair/")
 	if err != nil {
 		return Pairing{}, err
 	}
@@ -68,7 +67,7 @@ func StartPair(ctx context.Context, httpClient *client.Client, baseURL string) (
 }
 
 func CheckPair(ctx context.Context, httpClient *client.Client, baseURL, code string) (PairStatus, error) {
-	statusURL, err := joinURL(baseURL, fmt.Sprintf("/reproq/tui/pair/%s/", code))
+	statusURL, err := client.JoinURL(baseURL, fmt.Sprintf("/reproq/tui/pair/%s/", code))
 	if err != nil {
 		return PairStatus{}, err
 	}
@@ -94,24 +93,3 @@ func CheckPair(ctx context.Context, httpClient *client.Client, baseURL, code str
 	}
 	return status, nil
 }
-
-func joinURL(baseURL, suffix string) (string, error) {
-	if strings.TrimSpace(baseURL) == "" {
-		return "", fmt.Errorf("base url is required")
-	}
-	parsed, err := url.Parse(baseURL)
-	if err != nil {
-		return "", err
-	}
-	basePath := strings.TrimSuffix(parsed.Path, "/")
-	if !strings.HasPrefix(suffix, "/") {
-		suffix = "/" + suffix
-	}
-	if strings.HasSuffix(basePath, "/reproq") && strings.HasPrefix(suffix, "/reproq/") {
-		suffix = strings.TrimPrefix(suffix, "/reproq")
-	}
-	parsed.Path = basePath + suffix
-	parsed.RawQuery = ""
-	parsed.Fragment = ""
-	return parsed.String(), nil
-}
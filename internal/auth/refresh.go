@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/client"
+)
+
+// ErrRefreshUnsupported means reproq-django has no refresh endpoint, so an
+// expiring token can only be replaced through pairing.
+var ErrRefreshUnsupported = errors.New("token refresh not supported by server")
+
+// RefreshToken trades the bearer token already set on httpClient for a new
+// one. The returned token keeps baseURL as its Django URL.
+func RefreshToken(ctx context.Context, httpClient *client.Client, baseURL string) (Token, error) {
+	refreshURL, err := client.JoinURL(baseURL, "/reproq/tui/refresh/")
+	if err != nil {
+		return Token{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, refreshURL, nil)
+	if err != nil {
+		return Token{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return Token{}, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return Token{}, ErrRefreshUnsupported
+	default:
+		return Token{}, client.StatusError{URL: refreshURL, Code: resp.StatusCode}
+	}
+	var payload struct {
+		Token     string `json:"token"`
+		ExpiresAt int64  `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return Token{}, err
+	}
+	if payload.Token == "" {
+		return Token{}, errors.New("refresh response missing token")
+	}
+	token := Token{Value: payload.Token, DjangoURL: baseURL}
+	if payload.ExpiresAt > 0 {
+		token.ExpiresAt = time.Unix(payload.ExpiresAt, 0)
+	}
+	return token, nil
+}
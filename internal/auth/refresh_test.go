@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/client"
+)
+
+func TestRefreshToken(t *testing.T) {
+	expires := time.Now().Add(time.Hour).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/reproq/tui/refresh/" || r.Method != http.MethodPost {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer old" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"token": "new", "expires_at": expires})
+	}))
+	defer server.Close()
+
+	httpClient := client.New(client.Options{Timeout: 2 * time.Second, Headers: map[string]string{"Authorization": "Bearer old"}})
+	token, err := RefreshToken(context.Background(), httpClient, server.URL)
+	if err != nil {
+		t.Fatalf("refresh token: %v", err)
+	}
+	if token.Value != "new" || token.ExpiresAt.Unix() != expires || token.DjangoURL != server.URL {
+		t.Fatalf("unexpected token %+v", token)
+	}
+
+	httpClient.SetHeader("Authorization", "Bearer stale")
+	if _, err := RefreshToken(context.Background(), httpClient, server.URL); !client.IsStatus(err, http.StatusUnauthorized) {
+		t.Fatalf("expected 401, got %v", err)
+	}
+
+	missing := httptest.NewServer(http.NotFoundHandler())
+	defer missing.Close()
+	if _, err := RefreshToken(context.Background(), httpClient, missing.URL); !errors.Is(err, ErrRefreshUnsupported) {
+		t.Fatalf("expected unsupported refresh, got %v", err)
+	}
+}
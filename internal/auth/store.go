@@ -3,17 +3,30 @@ package auth
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 )
 
 var ErrNotFound = errors.New("auth token not found")
 
+const DefaultProfile = "default"
+
+var profilePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
 type Token struct {
 	Value     string    `json:"token"`
 	ExpiresAt time.Time `json:"expires_at"`
 	DjangoURL string    `json:"django_url,omitempty"`
+	// RefreshToken is set by the OAuth device flow.
+	RefreshToken string `json:"refresh_token,omitempty"`
+	// Config is the worker endpoint config Django handed out for this
+	// profile, kept so `auth list` can show which cluster a token belongs to.
+	Config *TUIConfig `json:"config,omitempty"`
 }
 
 func (t Token) Valid(now time.Time) bool {
@@ -26,13 +39,87 @@ func (t Token) Valid(now time.Time) bool {
 	return now.Before(t.ExpiresAt)
 }
 
+// TokenStore persists the TUI bearer token for one profile. Store is the
+// plaintext file backend; KeyringStore and EncryptedStore keep the token out
+// of plaintext.
+type TokenStore interface {
+	Load() (Token, error)
+	Save(Token) error
+	Clear() error
+	Describe() string
+	// Profile is the profile this store reads and writes.
+	Profile() string
+	// Profiles lists every profile with a token in the same backend.
+	Profiles() ([]string, error)
+	// WithProfile returns the same backend scoped to another profile.
+	WithProfile(profile string) TokenStore
+}
+
+// ValidProfile reports whether name can be used as a profile; names end up
+// in file names and keyring attributes.
+func ValidProfile(name string) bool {
+	return name == "" || profilePattern.MatchString(name)
+}
+
+func normalizeProfile(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return DefaultProfile
+	}
+	return name
+}
+
+// profilePath keeps the default profile at the legacy path and puts other
+// profiles next to it: auth.json, auth-prod.json, ...
+func profilePath(base, profile string) string {
+	if profile == DefaultProfile {
+		return base
+	}
+	ext := filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "-" + profile + ext
+}
+
+// pathProfiles lists profiles that have a file next to base.
+func pathProfiles(base string) ([]string, error) {
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(filepath.Base(base), ext) + "-"
+	entries, err := os.ReadDir(filepath.Dir(base))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	profiles := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			continue
+		}
+		if name == filepath.Base(base) {
+			profiles = append(profiles, DefaultProfile)
+			continue
+		}
+		if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		profile := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+		if profile != "" && profile != DefaultProfile && profilePattern.MatchString(profile) {
+			profiles = append(profiles, profile)
+		}
+	}
+	sort.Strings(profiles)
+	return profiles, nil
+}
+
 type Store struct {
-	path string
+	base    string
+	profile string
 }
 
 func DefaultStore() *Store {
 	if val := os.Getenv("REPROQ_TUI_AUTH_FILE"); val != "" {
-		return &Store{path: val}
+		return NewStore(val)
 	}
 	root := ""
 	if dir, err := os.UserConfigDir(); err == nil {
@@ -41,19 +128,39 @@ func DefaultStore() *Store {
 	if root == "" {
 		root = os.TempDir()
 	}
-	return &Store{path: filepath.Join(root, "reproq-tui", "auth.json")}
+	return NewStore(filepath.Join(root, "reproq-tui", "auth.json"))
 }
 
 func NewStore(path string) *Store {
-	return &Store{path: path}
+	return &Store{base: path, profile: DefaultProfile}
 }
 
 func (s *Store) Path() string {
-	return s.path
+	return profilePath(s.base, s.profile)
+}
+
+func (s *Store) Describe() string {
+	return "file " + s.Path()
+}
+
+func (s *Store) Profile() string {
+	return s.profile
+}
+
+func (s *Store) Profiles() ([]string, error) {
+	return pathProfiles(s.base)
+}
+
+func (s *Store) WithProfile(profile string) TokenStore {
+	return s.forProfile(profile)
+}
+
+func (s *Store) forProfile(profile string) *Store {
+	return &Store{base: s.base, profile: normalizeProfile(profile)}
 }
 
 func (s *Store) Load() (Token, error) {
-	data, err := os.ReadFile(s.path)
+	data, err := os.ReadFile(s.Path())
 	if err != nil {
 		if os.IsNotExist(err) {
 			return Token{}, ErrNotFound
@@ -68,19 +175,48 @@ func (s *Store) Load() (Token, error) {
 }
 
 func (s *Store) Save(token Token) error {
-	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+	if err := os.MkdirAll(filepath.Dir(s.base), 0o700); err != nil {
 		return err
 	}
 	data, err := json.MarshalIndent(token, "", "  ")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(s.path, data, 0o600)
+	return os.WriteFile(s.Path(), data, 0o600)
 }
 
 func (s *Store) Clear() error {
-	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+	if err := os.Remove(s.Path()); err != nil && !os.IsNotExist(err) {
 		return err
 	}
 	return nil
 }
+
+// FindProfile returns the profile whose token was issued by djangoURL.
+func FindProfile(store TokenStore, djangoURL string) (string, bool) {
+	want := strings.TrimSuffix(strings.TrimSpace(djangoURL), "/")
+	if want == "" {
+		return "", false
+	}
+	profiles, err := store.Profiles()
+	if err != nil {
+		return "", false
+	}
+	for _, profile := range profiles {
+		token, err := store.WithProfile(profile).Load()
+		if err != nil {
+			continue
+		}
+		if strings.TrimSuffix(strings.TrimSpace(token.DjangoURL), "/") == want {
+			return profile, true
+		}
+	}
+	return "", false
+}
+
+func checkProfile(profile string) error {
+	if !ValidProfile(profile) {
+		return fmt.Errorf("invalid profile %q (use letters, digits, '.', '_' or '-')", profile)
+	}
+	return nil
+}
@@ -32,3 +32,36 @@ func TestStoreSaveLoadClear(t *testing.T) {
 		t.Fatalf("expected load error after clear")
 	}
 }
+
+func TestStoreProfiles(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(filepath.Join(dir, "auth.json"))
+	prod := store.WithProfile("prod")
+	if got := prod.(*Store).Path(); got != filepath.Join(dir, "auth-prod.json") {
+		t.Fatalf("unexpected prod path %q", got)
+	}
+	if err := store.Save(Token{Value: "local", DjangoURL: "http://localhost:8000"}); err != nil {
+		t.Fatalf("save default: %v", err)
+	}
+	cfg := &TUIConfig{WorkerMetricsURL: "https://worker.prod/metrics"}
+	if err := prod.Save(Token{Value: "prod", DjangoURL: "https://django.prod/", Config: cfg}); err != nil {
+		t.Fatalf("save prod: %v", err)
+	}
+	profiles, err := store.Profiles()
+	if err != nil || len(profiles) != 2 || profiles[0] != "default" || profiles[1] != "prod" {
+		t.Fatalf("unexpected profiles %v (%v)", profiles, err)
+	}
+	loaded, err := prod.Load()
+	if err != nil || loaded.Value != "prod" || loaded.Config == nil || loaded.Config.WorkerMetricsURL != cfg.WorkerMetricsURL {
+		t.Fatalf("unexpected prod token %+v (%v)", loaded, err)
+	}
+	if profile, ok := FindProfile(store, "https://django.prod"); !ok || profile != "prod" {
+		t.Fatalf("expected prod profile for django url, got %q %v", profile, ok)
+	}
+	if _, ok := FindProfile(store, "https://elsewhere"); ok {
+		t.Fatalf("expected no profile for unknown django url")
+	}
+	if ValidProfile("../etc") || !ValidProfile("prod-eu.1") {
+		t.Fatalf("unexpected profile name validation")
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/adpena/reproq-tui/internal/events"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
@@ -28,36 +30,135 @@ type Config struct {
 	Interval           time.Duration
 	HealthInterval     time.Duration
 	StatsInterval      time.Duration
+	StatsDelta         bool
+	Database           string
 	Window             time.Duration
 	Theme              string
 	AutoLogin          bool
 	Headers            map[string]string
 	AuthToken          string
+	AuthStore          string
+	AuthPassphraseFile string
+	Profile            string
+	AuthRefreshBefore  time.Duration
+	OIDCIssuer         string
+	OIDCClientID       string
+	OIDCScopes         []string
 	Timeout            time.Duration
+	Retries            int
+	RetryBackoff       time.Duration
+	BreakerThreshold   int
+	BreakerMaxBackoff  time.Duration
 	InsecureSkipVerify bool
+	CAFile             string
+	ClientCertFile     string
+	ClientKeyFile      string
+	TLSServerName      string
+	Endpoints          map[string]EndpointAuth
 	Metrics            map[string]string
+	EventFields        map[string]string
+	EventLevelAliases  map[string]string
+	EventHistory       bool
+	EventHistoryFile   string
+	EventHistoryMaxAge time.Duration
+	HealthFlapCount    int
+	HealthFlapWindow   time.Duration
+	SLOTarget          float64
+	SLOWindow          time.Duration
+	CronTimezone       string
+	WorkerStaleAfter   time.Duration
 	LogFile            string
+	AuditLogFile       string
 }
 
 type fileConfig struct {
-	WorkerURL          string            `yaml:"worker_url" toml:"worker_url"`
-	WorkerMetricsURL   string            `yaml:"worker_metrics_url" toml:"worker_metrics_url"`
-	WorkerHealthURL    string            `yaml:"worker_health_url" toml:"worker_health_url"`
-	EventsURL          string            `yaml:"events_url" toml:"events_url"`
-	DjangoURL          string            `yaml:"django_url" toml:"django_url"`
-	DjangoStatsURL     string            `yaml:"django_stats_url" toml:"django_stats_url"`
-	Interval           string            `yaml:"interval" toml:"interval"`
-	HealthInterval     string            `yaml:"health_interval" toml:"health_interval"`
-	StatsInterval      string            `yaml:"stats_interval" toml:"stats_interval"`
-	Window             string            `yaml:"window" toml:"window"`
-	Theme              string            `yaml:"theme" toml:"theme"`
-	AutoLogin          *bool             `yaml:"auto_login" toml:"auto_login"`
-	Headers            []string          `yaml:"headers" toml:"headers"`
-	AuthToken          string            `yaml:"auth_token" toml:"auth_token"`
-	Timeout            string            `yaml:"timeout" toml:"timeout"`
-	InsecureSkipVerify bool              `yaml:"insecure_skip_verify" toml:"insecure_skip_verify"`
-	Metrics            map[string]string `yaml:"metrics" toml:"metrics"`
-	LogFile            string            `yaml:"log_file" toml:"log_file"`
+	WorkerURL          string                        `yaml:"worker_url" toml:"worker_url"`
+	WorkerMetricsURL   string                        `yaml:"worker_metrics_url" toml:"worker_metrics_url"`
+	WorkerHealthURL    string                        `yaml:"worker_health_url" toml:"worker_health_url"`
+	EventsURL          string                        `yaml:"events_url" toml:"events_url"`
+	DjangoURL          string                        `yaml:"django_url" toml:"django_url"`
+	DjangoStatsURL     string                        `yaml:"django_stats_url" toml:"django_stats_url"`
+	Interval           string                        `yaml:"interval" toml:"interval"`
+	HealthInterval     string                        `yaml:"health_interval" toml:"health_interval"`
+	StatsInterval      string                        `yaml:"stats_interval" toml:"stats_interval"`
+	StatsDelta         *bool                         `yaml:"stats_delta" toml:"stats_delta"`
+	Database           string                        `yaml:"database" toml:"database"`
+	Window             string                        `yaml:"window" toml:"window"`
+	Theme              string                        `yaml:"theme" toml:"theme"`
+	AutoLogin          *bool                         `yaml:"auto_login" toml:"auto_login"`
+	Headers            []string                      `yaml:"headers" toml:"headers"`
+	AuthToken          string                        `yaml:"auth_token" toml:"auth_token"`
+	AuthStore          string                        `yaml:"auth_store" toml:"auth_store"`
+	AuthPassphraseFile string                        `yaml:"auth_passphrase_file" toml:"auth_passphrase_file"`
+	Profile            string                        `yaml:"profile" toml:"profile"`
+	AuthRefreshBefore  string                        `yaml:"auth_refresh_before" toml:"auth_refresh_before"`
+	OIDCIssuer         string                        `yaml:"oidc_issuer" toml:"oidc_issuer"`
+	OIDCClientID       string                        `yaml:"oidc_client_id" toml:"oidc_client_id"`
+	OIDCScopes         []string                      `yaml:"oidc_scopes" toml:"oidc_scopes"`
+	Timeout            string                        `yaml:"timeout" toml:"timeout"`
+	Retries            *int                          `yaml:"retries" toml:"retries"`
+	RetryBackoff       string                        `yaml:"retry_backoff" toml:"retry_backoff"`
+	BreakerThreshold   *int                          `yaml:"breaker_threshold" toml:"breaker_threshold"`
+	BreakerMaxBackoff  string                        `yaml:"breaker_max_backoff" toml:"breaker_max_backoff"`
+	InsecureSkipVerify bool                          `yaml:"insecure_skip_verify" toml:"insecure_skip_verify"`
+	CAFile             string                        `yaml:"ca_file" toml:"ca_file"`
+	ClientCertFile     string                        `yaml:"client_cert" toml:"client_cert"`
+	ClientKeyFile      string                        `yaml:"client_key" toml:"client_key"`
+	TLSServerName      string                        `yaml:"tls_server_name" toml:"tls_server_name"`
+	Endpoints          map[string]endpointFileConfig `yaml:"endpoints" toml:"endpoints"`
+	Metrics            map[string]string             `yaml:"metrics" toml:"metrics"`
+	EventFields        map[string]string             `yaml:"event_fields" toml:"event_fields"`
+	EventLevelAliases  map[string]string             `yaml:"event_level_aliases" toml:"event_level_aliases"`
+	EventHistory       *bool                         `yaml:"event_history" toml:"event_history"`
+	EventHistoryFile   string                        `yaml:"event_history_file" toml:"event_history_file"`
+	EventHistoryMaxAge string                        `yaml:"event_history_retention" toml:"event_history_retention"`
+	HealthFlapCount    int                           `yaml:"health_flap_transitions" toml:"health_flap_transitions"`
+	HealthFlapWindow   string                        `yaml:"health_flap_window" toml:"health_flap_window"`
+	SLOTarget          string                        `yaml:"slo_target" toml:"slo_target"`
+	SLOWindow          string                        `yaml:"slo_window" toml:"slo_window"`
+	CronTimezone       string                        `yaml:"cron_timezone" toml:"cron_timezone"`
+	WorkerStaleAfter   string                        `yaml:"worker_stale_after" toml:"worker_stale_after"`
+	LogFile            string                        `yaml:"log_file" toml:"log_file"`
+	AuditLogFile       string                        `yaml:"audit_log_file" toml:"audit_log_file"`
+}
+
+// Endpoint names accepted under endpoints: in the config file. Django covers
+// stats, control actions and the login endpoints.
+const (
+	EndpointMetrics = "metrics"
+	EndpointHealth  = "health"
+	EndpointEvents  = "events"
+	EndpointDjango  = "django"
+)
+
+var endpointNames = []string{EndpointMetrics, EndpointHealth, EndpointEvents, EndpointDjango}
+
+// EndpointAuth overrides the shared headers for one endpoint. Secrets are
+// only ever read from a file or printed by a command, never kept inline.
+type EndpointAuth struct {
+	TokenFile       string
+	TokenCommand    string
+	Username        string
+	PasswordFile    string
+	PasswordCommand string
+	Headers         map[string]string
+}
+
+// SetsAuthorization reports whether the endpoint sends its own Authorization
+// header.
+func (e EndpointAuth) SetsAuthorization() bool {
+	return e.TokenFile != "" || e.TokenCommand != "" || e.Username != "" || hasAuthorizationHeader(e.Headers)
+}
+
+type endpointFileConfig struct {
+	Token           string   `yaml:"token" toml:"token"`
+	TokenFile       string   `yaml:"token_file" toml:"token_file"`
+	TokenCommand    string   `yaml:"token_command" toml:"token_command"`
+	Username        string   `yaml:"username" toml:"username"`
+	Password        string   `yaml:"password" toml:"password"`
+	PasswordFile    string   `yaml:"password_file" toml:"password_file"`
+	PasswordCommand string   `yaml:"password_command" toml:"password_command"`
+	Headers         []string `yaml:"headers" toml:"headers"`
 }
 
 type minimalFileConfig struct {
@@ -80,35 +181,92 @@ type flagValues struct {
 	Interval           time.Duration
 	HealthInterval     time.Duration
 	StatsInterval      time.Duration
+	StatsDelta         bool
+	Database           string
 	Window             time.Duration
 	Theme              string
 	AutoLogin          bool
 	Headers            []string
 	AuthToken          string
+	AuthStore          string
+	AuthPassphraseFile string
+	Profile            string
+	AuthRefreshBefore  time.Duration
+	OIDCIssuer         string
+	OIDCClientID       string
+	OIDCScopes         string
 	Timeout            time.Duration
+	Retries            int
+	RetryBackoff       time.Duration
+	BreakerThreshold   int
+	BreakerMaxBackoff  time.Duration
 	InsecureSkipVerify bool
+	CAFile             string
+	ClientCertFile     string
+	ClientKeyFile      string
+	TLSServerName      string
 	Metrics            []string
+	EventFields        []string
+	EventLevelAliases  []string
+	EventHistory       bool
+	EventHistoryFile   string
+	EventHistoryMaxAge time.Duration
+	HealthFlapCount    int
+	HealthFlapWindow   time.Duration
+	SLOTarget          string
+	SLOWindow          time.Duration
+	CronTimezone       string
+	WorkerStaleAfter   time.Duration
 	LogFile            string
+	AuditLogFile       string
 	IntervalSet        bool
 	HealthIntervalSet  bool
 	StatsIntervalSet   bool
+	StatsDeltaSet      bool
 	WindowSet          bool
 	ThemeSet           bool
 	AutoLoginSet       bool
 	TimeoutSet         bool
+	RetriesSet         bool
+	RetryBackoffSet    bool
+	BreakerSet         bool
+	BreakerBackoffSet  bool
+	EventHistorySet    bool
+	EventHistoryAgeSet bool
+	HealthFlapCountSet bool
+	HealthFlapWinSet   bool
+	SLOWindowSet       bool
+	WorkerStaleSet     bool
+	AuthRefreshSet     bool
 }
 
 func DefaultConfig() Config {
 	return Config{
-		Interval:       time.Second,
-		HealthInterval: 500 * time.Millisecond,
-		StatsInterval:  5 * time.Second,
-		Window:         5 * time.Minute,
-		Theme:          "auto",
-		AutoLogin:      true,
-		Headers:        map[string]string{},
-		Timeout:        2 * time.Second,
-		Metrics:        map[string]string{},
+		Interval:           time.Second,
+		HealthInterval:     500 * time.Millisecond,
+		StatsInterval:      5 * time.Second,
+		Window:             5 * time.Minute,
+		Theme:              "auto",
+		AutoLogin:          true,
+		Headers:            map[string]string{},
+		Endpoints:          map[string]EndpointAuth{},
+		AuthRefreshBefore:  10 * time.Minute,
+		OIDCScopes:         []string{"openid", "offline_access"},
+		Timeout:            2 * time.Second,
+		Retries:            2,
+		RetryBackoff:       100 * time.Millisecond,
+		BreakerThreshold:   3,
+		BreakerMaxBackoff:  time.Minute,
+		Metrics:            map[string]string{},
+		EventFields:        map[string]string{},
+		EventLevelAliases:  map[string]string{},
+		EventHistory:       true,
+		EventHistoryMaxAge: 72 * time.Hour,
+		HealthFlapCount:    4,
+		HealthFlapWindow:   5 * time.Minute,
+		SLOTarget:          0.999,
+		SLOWindow:          24 * time.Hour,
+		CronTimezone:       "UTC",
 	}
 }
 
@@ -117,21 +275,50 @@ func RegisterFlags(cmd *cobra.Command) {
 	cmd.Flags().String("worker-url", "", "Base worker URL (derives /metrics and /healthz)")
 	cmd.Flags().String("worker-metrics-url", "", "Worker Prometheus/OpenMetrics URL")
 	cmd.Flags().String("worker-health-url", "", "Worker health URL (default derived from metrics host)")
-	cmd.Flags().String("events-url", "", "Events SSE URL")
+	cmd.Flags().String("events-url", "", "Events stream URL (SSE, NDJSON, ws://), JSON-lines file path, or - for stdin")
 	cmd.Flags().String("django-url", "", "Base Django URL (derives /reproq/stats/ and auth endpoints)")
 	cmd.Flags().String("django-stats-url", "", "Django stats API URL (optional)")
 	cmd.Flags().Duration("interval", time.Second, "Metrics poll interval")
 	cmd.Flags().Duration("health-interval", 500*time.Millisecond, "Health poll interval")
 	cmd.Flags().Duration("stats-interval", 5*time.Second, "Django stats poll interval")
+	cmd.Flags().Bool("stats-delta", false, "Request incremental Django stats with ?since= after the first full payload")
+	cmd.Flags().String("database", "", "Scope Django stats panels to one database alias")
 	cmd.Flags().Duration("window", 5*time.Minute, "Default timeseries window")
 	cmd.Flags().String("theme", "auto", "Theme: auto, dark, or light")
 	cmd.Flags().Bool("auto-login", true, "Auto-start login flow when auth is required")
 	cmd.Flags().StringArray("header", []string{}, "Request header in 'Key: Value' form (repeatable)")
 	cmd.Flags().String("auth-token", "", "Bearer token for metrics/health/events (adds Authorization header)")
+	cmd.Flags().String("auth-store", "", "Where the TUI login token is kept: file, keyring, encrypted, or auto (default file)")
+	cmd.Flags().String("auth-passphrase-file", "", "Passphrase file for --auth-store encrypted (or set REPROQ_TUI_AUTH_PASSPHRASE)")
+	cmd.Flags().String("profile", "", "Auth profile whose stored token to use (default: matched by Django URL)")
+	cmd.Flags().Duration("auth-refresh-before", 10*time.Minute, "Refresh the login token (or warn) this long before it expires")
+	cmd.Flags().String("oidc-issuer", "", "OIDC issuer URL; sign in with the OAuth device flow instead of Django pairing")
+	cmd.Flags().String("oidc-client-id", "", "OAuth client ID for the device flow")
+	cmd.Flags().String("oidc-scopes", "", "Comma-separated OAuth scopes (default openid,offline_access)")
 	cmd.Flags().Duration("timeout", 2*time.Second, "HTTP request timeout")
+	cmd.Flags().Int("retries", 2, "Retries for GET requests that fail in transport or with 502/504 (0 disables)")
+	cmd.Flags().Duration("retry-backoff", 100*time.Millisecond, "Base delay between retries; doubles per attempt with full jitter")
+	cmd.Flags().Int("breaker-threshold", 3, "Consecutive failures before polling an endpoint backs off (0 disables)")
+	cmd.Flags().Duration("breaker-max-backoff", time.Minute, "Longest wait between probes of an endpoint that is down")
 	cmd.Flags().Bool("insecure-skip-verify", false, "Skip TLS verification (dev only)")
+	cmd.Flags().String("ca-file", "", "PEM CA bundle used instead of the system roots")
+	cmd.Flags().String("client-cert", "", "PEM client certificate for mutual TLS (reloaded when the file changes)")
+	cmd.Flags().String("client-key", "", "PEM client key for mutual TLS (default: --client-cert)")
+	cmd.Flags().String("tls-server-name", "", "Override the TLS server name (SNI and certificate check)")
 	cmd.Flags().StringArray("metric", []string{}, "Metric mapping in 'canonical=actual' form (repeatable)")
+	cmd.Flags().StringArray("event-field", []string{}, "Event field mapping in 'field=path' form, e.g. task_id=task.id (repeatable)")
+	cmd.Flags().StringArray("event-level-alias", []string{}, "Event level alias in 'raw=level' form, e.g. 50=error (repeatable)")
+	cmd.Flags().Bool("event-history", true, "Persist events to an on-disk history log")
+	cmd.Flags().String("event-history-file", "", "Event history file (default in the user cache dir)")
+	cmd.Flags().Duration("event-history-retention", 72*time.Hour, "How long to keep events in the history log")
+	cmd.Flags().Int("health-flap-transitions", 4, "Health state changes within --health-flap-window that mark a component as flapping")
+	cmd.Flags().Duration("health-flap-window", 5*time.Minute, "Window used for health flap detection")
+	cmd.Flags().String("slo-target", "", "Availability SLO target as a ratio or percent (default 99.9)")
+	cmd.Flags().Duration("slo-window", 24*time.Hour, "Window the availability SLO and error budget cover")
+	cmd.Flags().String("cron-timezone", "", "Time zone used to evaluate periodic task cron expressions (default UTC)")
+	cmd.Flags().Duration("worker-stale-after", 0, "Mark workers stale after this long without a heartbeat (default max(90s, 6x stats interval))")
 	cmd.Flags().String("log-file", "", "Write debug logs to file")
+	cmd.Flags().String("audit-log-file", "", "Audit log for queue and task actions (default in the user config dir)")
 }
 
 func Load(cmd *cobra.Command) (Config, error) {
@@ -149,18 +336,9 @@ func load(cmd *cobra.Command, requireMetrics bool) (Config, error) {
 	}
 
 	cfg := DefaultConfig()
-	filePath := flags.ConfigFile
-	if filePath == "" {
-		filePath = strings.TrimSpace(os.Getenv(envPrefix + "CONFIG"))
-	}
-	if filePath == "" {
-		if defaultPath, err := defaultConfigPath(); err == nil {
-			if _, statErr := os.Stat(defaultPath); statErr == nil {
-				filePath = defaultPath
-			} else if !os.IsNotExist(statErr) {
-				return Config{}, statErr
-			}
-		}
+	filePath, err := configFilePath(flags.ConfigFile)
+	if err != nil {
+		return Config{}, err
 	}
 	if filePath != "" {
 		if err := applyFile(&cfg, filePath); err != nil {
@@ -182,15 +360,83 @@ func load(cmd *cobra.Command, requireMetrics bool) (Config, error) {
 	if cfg.WorkerHealthURL == "" {
 		cfg.WorkerHealthURL = deriveHealthURL(cfg.WorkerMetricsURL)
 	}
+	if cfg.EventHistory && cfg.EventHistoryFile == "" {
+		cfg.EventHistoryFile = defaultEventHistoryPath()
+	}
+	if cfg.AuditLogFile == "" {
+		cfg.AuditLogFile = defaultAuditLogPath()
+	}
 	if requireMetrics && cfg.WorkerMetricsURL == "" {
 		return Config{}, errors.New("worker metrics URL is required (--worker-metrics-url or --worker-url)")
 	}
 	if err := validateURLs(cfg); err != nil {
 		return Config{}, err
 	}
+	if err := validateEventFields(cfg.EventFields); err != nil {
+		return Config{}, err
+	}
+	if err := validateTLSFiles(cfg); err != nil {
+		return Config{}, err
+	}
+	if err := validateEndpoints(cfg.Endpoints); err != nil {
+		return Config{}, err
+	}
 	return cfg, nil
 }
 
+// LoadAuthStore resolves the token store settings (auth_store,
+// auth_passphrase_file and profile) for commands that only manage the stored
+// login. It reads the same config file and environment as Load, then the
+// --config, --auth-store, --auth-passphrase-file and --profile flags cmd
+// defines, but requires and validates no endpoints.
+func LoadAuthStore(cmd *cobra.Command) (Config, error) {
+	flag := func(name string) string {
+		if f := cmd.Flags().Lookup(name); f != nil {
+			return strings.TrimSpace(f.Value.String())
+		}
+		return ""
+	}
+	cfg := DefaultConfig()
+	filePath, err := configFilePath(flag("config"))
+	if err != nil {
+		return Config{}, err
+	}
+	if filePath != "" {
+		if err := applyFile(&cfg, filePath); err != nil {
+			return Config{}, err
+		}
+	}
+	applyEnv(&cfg)
+	if store := flag("auth-store"); validAuthStore(store) {
+		cfg.AuthStore = strings.ToLower(store)
+	}
+	cfg.AuthPassphraseFile = firstNonEmpty(cfg.AuthPassphraseFile, flag("auth-passphrase-file"))
+	cfg.Profile = firstNonEmpty(cfg.Profile, flag("profile"))
+	return cfg, nil
+}
+
+// configFilePath picks the config file: the --config value, then
+// REPROQ_TUI_CONFIG, then the default path when that file exists.
+func configFilePath(flagPath string) (string, error) {
+	if flagPath != "" {
+		return flagPath, nil
+	}
+	if envPath := strings.TrimSpace(os.Getenv(envPrefix + "CONFIG")); envPath != "" {
+		return envPath, nil
+	}
+	defaultPath, err := defaultConfigPath()
+	if err != nil {
+		return "", nil
+	}
+	if _, statErr := os.Stat(defaultPath); statErr != nil {
+		if os.IsNotExist(statErr) {
+			return "", nil
+		}
+		return "", statErr
+	}
+	return defaultPath, nil
+}
+
 func defaultConfigPath() (string, error) {
 	dir, err := os.UserConfigDir()
 	if err != nil || dir == "" {
@@ -199,6 +445,22 @@ func defaultConfigPath() (string, error) {
 	return filepath.Join(dir, "reproq-tui", "config.yaml"), nil
 }
 
+func defaultEventHistoryPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil || dir == "" {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "reproq-tui", "events.jsonl")
+}
+
+func defaultAuditLogPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil || dir == "" {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "reproq-tui", "audit.jsonl")
+}
+
 func DefaultConfigPath() (string, error) {
 	return defaultConfigPath()
 }
@@ -256,6 +518,15 @@ func readFlags(cmd *cobra.Command) (flagValues, error) {
 		return flags, err
 	}
 	flags.StatsIntervalSet = cmd.Flags().Changed("stats-interval")
+	flags.StatsDelta, err = cmd.Flags().GetBool("stats-delta")
+	if err != nil {
+		return flags, err
+	}
+	flags.StatsDeltaSet = cmd.Flags().Changed("stats-delta")
+	flags.Database, err = cmd.Flags().GetString("database")
+	if err != nil {
+		return flags, err
+	}
 	flags.Window, err = cmd.Flags().GetDuration("window")
 	if err != nil {
 		return flags, err
@@ -279,23 +550,142 @@ func readFlags(cmd *cobra.Command) (flagValues, error) {
 	if err != nil {
 		return flags, err
 	}
+	flags.AuthStore, err = cmd.Flags().GetString("auth-store")
+	if err != nil {
+		return flags, err
+	}
+	flags.AuthPassphraseFile, err = cmd.Flags().GetString("auth-passphrase-file")
+	if err != nil {
+		return flags, err
+	}
+	flags.Profile, err = cmd.Flags().GetString("profile")
+	if err != nil {
+		return flags, err
+	}
+	flags.AuthRefreshBefore, err = cmd.Flags().GetDuration("auth-refresh-before")
+	if err != nil {
+		return flags, err
+	}
+	flags.AuthRefreshSet = cmd.Flags().Changed("auth-refresh-before")
+	flags.OIDCIssuer, err = cmd.Flags().GetString("oidc-issuer")
+	if err != nil {
+		return flags, err
+	}
+	flags.OIDCClientID, err = cmd.Flags().GetString("oidc-client-id")
+	if err != nil {
+		return flags, err
+	}
+	flags.OIDCScopes, err = cmd.Flags().GetString("oidc-scopes")
+	if err != nil {
+		return flags, err
+	}
 	flags.Timeout, err = cmd.Flags().GetDuration("timeout")
 	if err != nil {
 		return flags, err
 	}
 	flags.TimeoutSet = cmd.Flags().Changed("timeout")
+	flags.Retries, err = cmd.Flags().GetInt("retries")
+	if err != nil {
+		return flags, err
+	}
+	flags.RetriesSet = cmd.Flags().Changed("retries")
+	flags.RetryBackoff, err = cmd.Flags().GetDuration("retry-backoff")
+	if err != nil {
+		return flags, err
+	}
+	flags.RetryBackoffSet = cmd.Flags().Changed("retry-backoff")
+	flags.BreakerThreshold, err = cmd.Flags().GetInt("breaker-threshold")
+	if err != nil {
+		return flags, err
+	}
+	flags.BreakerSet = cmd.Flags().Changed("breaker-threshold")
+	flags.BreakerMaxBackoff, err = cmd.Flags().GetDuration("breaker-max-backoff")
+	if err != nil {
+		return flags, err
+	}
+	flags.BreakerBackoffSet = cmd.Flags().Changed("breaker-max-backoff")
 	flags.InsecureSkipVerify, err = cmd.Flags().GetBool("insecure-skip-verify")
 	if err != nil {
 		return flags, err
 	}
+	flags.CAFile, err = cmd.Flags().GetString("ca-file")
+	if err != nil {
+		return flags, err
+	}
+	flags.ClientCertFile, err = cmd.Flags().GetString("client-cert")
+	if err != nil {
+		return flags, err
+	}
+	flags.ClientKeyFile, err = cmd.Flags().GetString("client-key")
+	if err != nil {
+		return flags, err
+	}
+	flags.TLSServerName, err = cmd.Flags().GetString("tls-server-name")
+	if err != nil {
+		return flags, err
+	}
 	flags.Metrics, err = cmd.Flags().GetStringArray("metric")
 	if err != nil {
 		return flags, err
 	}
+	flags.EventFields, err = cmd.Flags().GetStringArray("event-field")
+	if err != nil {
+		return flags, err
+	}
+	flags.EventLevelAliases, err = cmd.Flags().GetStringArray("event-level-alias")
+	if err != nil {
+		return flags, err
+	}
+	flags.EventHistory, err = cmd.Flags().GetBool("event-history")
+	if err != nil {
+		return flags, err
+	}
+	flags.EventHistorySet = cmd.Flags().Changed("event-history")
+	flags.EventHistoryFile, err = cmd.Flags().GetString("event-history-file")
+	if err != nil {
+		return flags, err
+	}
+	flags.EventHistoryMaxAge, err = cmd.Flags().GetDuration("event-history-retention")
+	if err != nil {
+		return flags, err
+	}
+	flags.EventHistoryAgeSet = cmd.Flags().Changed("event-history-retention")
+	flags.HealthFlapCount, err = cmd.Flags().GetInt("health-flap-transitions")
+	if err != nil {
+		return flags, err
+	}
+	flags.HealthFlapCountSet = cmd.Flags().Changed("health-flap-transitions")
+	flags.HealthFlapWindow, err = cmd.Flags().GetDuration("health-flap-window")
+	if err != nil {
+		return flags, err
+	}
+	flags.HealthFlapWinSet = cmd.Flags().Changed("health-flap-window")
+	flags.SLOTarget, err = cmd.Flags().GetString("slo-target")
+	if err != nil {
+		return flags, err
+	}
+	flags.SLOWindow, err = cmd.Flags().GetDuration("slo-window")
+	if err != nil {
+		return flags, err
+	}
+	flags.SLOWindowSet = cmd.Flags().Changed("slo-window")
+	flags.CronTimezone, err = cmd.Flags().GetString("cron-timezone")
+	if err != nil {
+		return flags, err
+	}
+	flags.WorkerStaleAfter, err = cmd.Flags().GetDuration("worker-stale-after")
+	if err != nil {
+		return flags, err
+	}
+	flags.WorkerStaleSet = cmd.Flags().Changed("worker-stale-after")
 	flags.LogFile, err = cmd.Flags().GetString("log-file")
 	if err != nil {
 		return flags, err
 	}
+	flags.AuditLogFile, err = cmd.Flags().GetString("audit-log-file")
+	if err != nil {
+		return flags, err
+	}
 	return flags, nil
 }
 
@@ -317,6 +707,11 @@ func applyFile(cfg *Config, path string) error {
 	default:
 		return fmt.Errorf("unsupported config extension: %s", filepath.Ext(path))
 	}
+	for name, endpoint := range fc.Endpoints {
+		if endpoint.Token != "" || endpoint.Password != "" {
+			return fmt.Errorf("endpoints.%s: inline secrets are not supported; use token_file/token_command or password_file/password_command", name)
+		}
+	}
 	applyFileConfig(cfg, fc)
 	return nil
 }
@@ -337,6 +732,10 @@ func applyFileConfig(cfg *Config, fc fileConfig) {
 	if d := parseDuration(fc.StatsInterval); d > 0 {
 		cfg.StatsInterval = d
 	}
+	if fc.StatsDelta != nil {
+		cfg.StatsDelta = *fc.StatsDelta
+	}
+	cfg.Database = firstNonEmpty(cfg.Database, strings.TrimSpace(fc.Database))
 	if d := parseDuration(fc.Window); d > 0 {
 		cfg.Window = d
 	}
@@ -348,18 +747,89 @@ func applyFileConfig(cfg *Config, fc fileConfig) {
 		cfg.Headers = mergeHeaders(cfg.Headers, fc.Headers)
 	}
 	cfg.AuthToken = firstNonEmpty(cfg.AuthToken, fc.AuthToken)
+	if validAuthStore(fc.AuthStore) {
+		cfg.AuthStore = strings.ToLower(strings.TrimSpace(fc.AuthStore))
+	}
+	cfg.AuthPassphraseFile = firstNonEmpty(cfg.AuthPassphraseFile, fc.AuthPassphraseFile)
+	cfg.Profile = firstNonEmpty(cfg.Profile, strings.TrimSpace(fc.Profile))
+	if d := parseDuration(fc.AuthRefreshBefore); d > 0 {
+		cfg.AuthRefreshBefore = d
+	}
+	cfg.OIDCIssuer = firstNonEmpty(cfg.OIDCIssuer, strings.TrimSpace(fc.OIDCIssuer))
+	cfg.OIDCClientID = firstNonEmpty(cfg.OIDCClientID, strings.TrimSpace(fc.OIDCClientID))
+	if scopes := splitComma(strings.Join(fc.OIDCScopes, ",")); len(scopes) > 0 {
+		cfg.OIDCScopes = scopes
+	}
 	if d := parseDuration(fc.Timeout); d > 0 {
 		cfg.Timeout = d
 	}
+	if fc.Retries != nil && *fc.Retries >= 0 {
+		cfg.Retries = *fc.Retries
+	}
+	if d := parseDuration(fc.RetryBackoff); d > 0 {
+		cfg.RetryBackoff = d
+	}
+	if fc.BreakerThreshold != nil && *fc.BreakerThreshold >= 0 {
+		cfg.BreakerThreshold = *fc.BreakerThreshold
+	}
+	if d := parseDuration(fc.BreakerMaxBackoff); d > 0 {
+		cfg.BreakerMaxBackoff = d
+	}
 	if fc.InsecureSkipVerify {
 		cfg.InsecureSkipVerify = true
 	}
+	cfg.CAFile = firstNonEmpty(cfg.CAFile, fc.CAFile)
+	cfg.ClientCertFile = firstNonEmpty(cfg.ClientCertFile, fc.ClientCertFile)
+	cfg.ClientKeyFile = firstNonEmpty(cfg.ClientKeyFile, fc.ClientKeyFile)
+	cfg.TLSServerName = firstNonEmpty(cfg.TLSServerName, strings.TrimSpace(fc.TLSServerName))
+	for name, endpoint := range fc.Endpoints {
+		cfg.Endpoints[strings.ToLower(strings.TrimSpace(name))] = EndpointAuth{
+			TokenFile:       strings.TrimSpace(endpoint.TokenFile),
+			TokenCommand:    strings.TrimSpace(endpoint.TokenCommand),
+			Username:        strings.TrimSpace(endpoint.Username),
+			PasswordFile:    strings.TrimSpace(endpoint.PasswordFile),
+			PasswordCommand: strings.TrimSpace(endpoint.PasswordCommand),
+			Headers:         parseHeaderList(endpoint.Headers),
+		}
+	}
 	if len(fc.Metrics) > 0 {
 		for k, v := range fc.Metrics {
 			cfg.Metrics[k] = v
 		}
 	}
+	for k, v := range fc.EventFields {
+		cfg.EventFields[k] = v
+	}
+	for k, v := range fc.EventLevelAliases {
+		cfg.EventLevelAliases[k] = v
+	}
+	if fc.EventHistory != nil {
+		cfg.EventHistory = *fc.EventHistory
+	}
+	cfg.EventHistoryFile = firstNonEmpty(cfg.EventHistoryFile, fc.EventHistoryFile)
+	if d := parseDuration(fc.EventHistoryMaxAge); d > 0 {
+		cfg.EventHistoryMaxAge = d
+	}
+	if fc.HealthFlapCount > 0 {
+		cfg.HealthFlapCount = fc.HealthFlapCount
+	}
+	if d := parseDuration(fc.HealthFlapWindow); d > 0 {
+		cfg.HealthFlapWindow = d
+	}
+	if target, ok := parseSLOTarget(fc.SLOTarget); ok {
+		cfg.SLOTarget = target
+	}
+	if d := parseDuration(fc.SLOWindow); d > 0 {
+		cfg.SLOWindow = d
+	}
+	if validTimezone(fc.CronTimezone) {
+		cfg.CronTimezone = fc.CronTimezone
+	}
+	if d := parseDuration(fc.WorkerStaleAfter); d > 0 {
+		cfg.WorkerStaleAfter = d
+	}
 	cfg.LogFile = firstNonEmpty(cfg.LogFile, fc.LogFile)
+	cfg.AuditLogFile = firstNonEmpty(cfg.AuditLogFile, fc.AuditLogFile)
 }
 
 func applyEnv(cfg *Config) {
@@ -396,6 +866,12 @@ func applyEnv(cfg *Config) {
 			cfg.StatsInterval = d
 		}
 	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "STATS_DELTA")); val != "" {
+		cfg.StatsDelta = parseBool(val)
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "DATABASE")); val != "" {
+		cfg.Database = val
+	}
 	if val := strings.TrimSpace(os.Getenv(envPrefix + "WINDOW")); val != "" {
 		if d := parseDuration(val); d > 0 {
 			cfg.Window = d
@@ -415,22 +891,127 @@ func applyEnv(cfg *Config) {
 	} else if val := strings.TrimSpace(os.Getenv("METRICS_AUTH_TOKEN")); val != "" {
 		cfg.AuthToken = val
 	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "AUTH_STORE")); validAuthStore(val) {
+		cfg.AuthStore = strings.ToLower(val)
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "AUTH_PASSPHRASE_FILE")); val != "" {
+		cfg.AuthPassphraseFile = val
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "PROFILE")); val != "" {
+		cfg.Profile = val
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "AUTH_REFRESH_BEFORE")); val != "" {
+		if d := parseDuration(val); d > 0 {
+			cfg.AuthRefreshBefore = d
+		}
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "OIDC_ISSUER")); val != "" {
+		cfg.OIDCIssuer = val
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "OIDC_CLIENT_ID")); val != "" {
+		cfg.OIDCClientID = val
+	}
+	if scopes := splitComma(os.Getenv(envPrefix + "OIDC_SCOPES")); len(scopes) > 0 {
+		cfg.OIDCScopes = scopes
+	}
 	if val := strings.TrimSpace(os.Getenv(envPrefix + "TIMEOUT")); val != "" {
 		if d := parseDuration(val); d > 0 {
 			cfg.Timeout = d
 		}
 	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "RETRIES")); val != "" {
+		if n, err := strconv.Atoi(val); err == nil && n >= 0 {
+			cfg.Retries = n
+		}
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "RETRY_BACKOFF")); val != "" {
+		if d := parseDuration(val); d > 0 {
+			cfg.RetryBackoff = d
+		}
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "BREAKER_THRESHOLD")); val != "" {
+		if n, err := strconv.Atoi(val); err == nil && n >= 0 {
+			cfg.BreakerThreshold = n
+		}
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "BREAKER_MAX_BACKOFF")); val != "" {
+		if d := parseDuration(val); d > 0 {
+			cfg.BreakerMaxBackoff = d
+		}
+	}
 	if val := strings.TrimSpace(os.Getenv(envPrefix + "INSECURE_SKIP_VERIFY")); val != "" {
 		cfg.InsecureSkipVerify = parseBool(val)
 	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "CA_FILE")); val != "" {
+		cfg.CAFile = val
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "CLIENT_CERT")); val != "" {
+		cfg.ClientCertFile = val
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "CLIENT_KEY")); val != "" {
+		cfg.ClientKeyFile = val
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "TLS_SERVER_NAME")); val != "" {
+		cfg.TLSServerName = val
+	}
 	if val := strings.TrimSpace(os.Getenv(envPrefix + "METRICS")); val != "" {
 		for k, v := range parseKeyValueList(splitComma(val)) {
 			cfg.Metrics[k] = v
 		}
 	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "EVENT_FIELDS")); val != "" {
+		for k, v := range parseKeyValueList(splitComma(val)) {
+			cfg.EventFields[k] = v
+		}
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "EVENT_LEVEL_ALIASES")); val != "" {
+		for k, v := range parseKeyValueList(splitComma(val)) {
+			cfg.EventLevelAliases[k] = v
+		}
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "EVENT_HISTORY")); val != "" {
+		cfg.EventHistory = parseBool(val)
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "EVENT_HISTORY_FILE")); val != "" {
+		cfg.EventHistoryFile = val
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "EVENT_HISTORY_RETENTION")); val != "" {
+		if d := parseDuration(val); d > 0 {
+			cfg.EventHistoryMaxAge = d
+		}
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "HEALTH_FLAP_TRANSITIONS")); val != "" {
+		if n, err := strconv.Atoi(val); err == nil && n > 0 {
+			cfg.HealthFlapCount = n
+		}
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "HEALTH_FLAP_WINDOW")); val != "" {
+		if d := parseDuration(val); d > 0 {
+			cfg.HealthFlapWindow = d
+		}
+	}
+	if target, ok := parseSLOTarget(os.Getenv(envPrefix + "SLO_TARGET")); ok {
+		cfg.SLOTarget = target
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "SLO_WINDOW")); val != "" {
+		if d := parseDuration(val); d > 0 {
+			cfg.SLOWindow = d
+		}
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "CRON_TIMEZONE")); validTimezone(val) {
+		cfg.CronTimezone = val
+	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "WORKER_STALE_AFTER")); val != "" {
+		if d := parseDuration(val); d > 0 {
+			cfg.WorkerStaleAfter = d
+		}
+	}
 	if val := strings.TrimSpace(os.Getenv(envPrefix + "LOG_FILE")); val != "" {
 		cfg.LogFile = val
 	}
+	if val := strings.TrimSpace(os.Getenv(envPrefix + "AUDIT_LOG_FILE")); val != "" {
+		cfg.AuditLogFile = val
+	}
 }
 
 func applyFlags(cfg *Config, flags flagValues) {
@@ -449,6 +1030,10 @@ func applyFlags(cfg *Config, flags flagValues) {
 	if flags.StatsIntervalSet && flags.StatsInterval > 0 {
 		cfg.StatsInterval = flags.StatsInterval
 	}
+	if flags.StatsDeltaSet {
+		cfg.StatsDelta = flags.StatsDelta
+	}
+	cfg.Database = firstNonEmpty(cfg.Database, strings.TrimSpace(flags.Database))
 	if flags.WindowSet && flags.Window > 0 {
 		cfg.Window = flags.Window
 	}
@@ -462,18 +1047,79 @@ func applyFlags(cfg *Config, flags flagValues) {
 		cfg.Headers = mergeHeaders(cfg.Headers, flags.Headers)
 	}
 	cfg.AuthToken = firstNonEmpty(cfg.AuthToken, flags.AuthToken)
+	if validAuthStore(flags.AuthStore) {
+		cfg.AuthStore = strings.ToLower(strings.TrimSpace(flags.AuthStore))
+	}
+	cfg.AuthPassphraseFile = firstNonEmpty(cfg.AuthPassphraseFile, flags.AuthPassphraseFile)
+	cfg.Profile = firstNonEmpty(cfg.Profile, strings.TrimSpace(flags.Profile))
+	if flags.AuthRefreshSet && flags.AuthRefreshBefore > 0 {
+		cfg.AuthRefreshBefore = flags.AuthRefreshBefore
+	}
+	cfg.OIDCIssuer = firstNonEmpty(cfg.OIDCIssuer, strings.TrimSpace(flags.OIDCIssuer))
+	cfg.OIDCClientID = firstNonEmpty(cfg.OIDCClientID, strings.TrimSpace(flags.OIDCClientID))
+	if scopes := splitComma(flags.OIDCScopes); len(scopes) > 0 {
+		cfg.OIDCScopes = scopes
+	}
 	if flags.TimeoutSet && flags.Timeout > 0 {
 		cfg.Timeout = flags.Timeout
 	}
+	if flags.RetriesSet && flags.Retries >= 0 {
+		cfg.Retries = flags.Retries
+	}
+	if flags.RetryBackoffSet && flags.RetryBackoff > 0 {
+		cfg.RetryBackoff = flags.RetryBackoff
+	}
+	if flags.BreakerSet && flags.BreakerThreshold >= 0 {
+		cfg.BreakerThreshold = flags.BreakerThreshold
+	}
+	if flags.BreakerBackoffSet && flags.BreakerMaxBackoff > 0 {
+		cfg.BreakerMaxBackoff = flags.BreakerMaxBackoff
+	}
 	if flags.InsecureSkipVerify {
 		cfg.InsecureSkipVerify = true
 	}
+	cfg.CAFile = firstNonEmpty(cfg.CAFile, flags.CAFile)
+	cfg.ClientCertFile = firstNonEmpty(cfg.ClientCertFile, flags.ClientCertFile)
+	cfg.ClientKeyFile = firstNonEmpty(cfg.ClientKeyFile, flags.ClientKeyFile)
+	cfg.TLSServerName = firstNonEmpty(cfg.TLSServerName, strings.TrimSpace(flags.TLSServerName))
 	if len(flags.Metrics) > 0 {
 		for k, v := range parseKeyValueList(flags.Metrics) {
 			cfg.Metrics[k] = v
 		}
 	}
+	for k, v := range parseKeyValueList(flags.EventFields) {
+		cfg.EventFields[k] = v
+	}
+	for k, v := range parseKeyValueList(flags.EventLevelAliases) {
+		cfg.EventLevelAliases[k] = v
+	}
+	if flags.EventHistorySet {
+		cfg.EventHistory = flags.EventHistory
+	}
+	cfg.EventHistoryFile = firstNonEmpty(cfg.EventHistoryFile, flags.EventHistoryFile)
+	if flags.EventHistoryAgeSet && flags.EventHistoryMaxAge > 0 {
+		cfg.EventHistoryMaxAge = flags.EventHistoryMaxAge
+	}
+	if flags.HealthFlapCountSet && flags.HealthFlapCount > 0 {
+		cfg.HealthFlapCount = flags.HealthFlapCount
+	}
+	if flags.HealthFlapWinSet && flags.HealthFlapWindow > 0 {
+		cfg.HealthFlapWindow = flags.HealthFlapWindow
+	}
+	if target, ok := parseSLOTarget(flags.SLOTarget); ok {
+		cfg.SLOTarget = target
+	}
+	if flags.SLOWindowSet && flags.SLOWindow > 0 {
+		cfg.SLOWindow = flags.SLOWindow
+	}
+	if validTimezone(flags.CronTimezone) {
+		cfg.CronTimezone = flags.CronTimezone
+	}
+	if flags.WorkerStaleSet && flags.WorkerStaleAfter > 0 {
+		cfg.WorkerStaleAfter = flags.WorkerStaleAfter
+	}
 	cfg.LogFile = firstNonEmpty(cfg.LogFile, flags.LogFile)
+	cfg.AuditLogFile = firstNonEmpty(cfg.AuditLogFile, flags.AuditLogFile)
 }
 
 func deriveMetricsURL(workerURL string) string {
@@ -586,17 +1232,102 @@ func validateURLs(cfg Config) error {
 		"events url":         cfg.EventsURL,
 		"django url":         cfg.DjangoURL,
 		"django stats url":   cfg.DjangoStatsURL,
+		"oidc issuer":        cfg.OIDCIssuer,
 	} {
 		if val == "" {
 			continue
 		}
-		if _, err := url.ParseRequestURI(val); err != nil {
+		if name == "events url" && events.IsLocalSource(val) {
+			continue
+		}
+		parsed, err := url.ParseRequestURI(val)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+		if name == "events url" {
+			switch strings.ToLower(parsed.Scheme) {
+			case "http", "https", "ws", "wss":
+			default:
+				return fmt.Errorf("invalid %s %q: use an http(s) or ws(s) URL, an absolute path, file://, or -", name, val)
+			}
+		}
+	}
+	return nil
+}
+
+var eventFieldKeys = []string{"ts", "level", "type", "msg", "queue", "task_id", "worker_id", "metadata"}
+
+// validateTLSFiles only checks that the files exist; their contents are loaded
+// (and reloaded on rotation) by the HTTP client.
+func validateTLSFiles(cfg Config) error {
+	if cfg.ClientKeyFile != "" && cfg.ClientCertFile == "" {
+		return errors.New("client key requires a client cert (--client-cert)")
+	}
+	for name, path := range map[string]string{
+		"ca file":     cfg.CAFile,
+		"client cert": cfg.ClientCertFile,
+		"client key":  cfg.ClientKeyFile,
+	} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
 			return fmt.Errorf("invalid %s: %w", name, err)
 		}
 	}
 	return nil
 }
 
+func validateEndpoints(endpoints map[string]EndpointAuth) error {
+	for name, endpoint := range endpoints {
+		known := false
+		for _, candidate := range endpointNames {
+			if name == candidate {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown endpoint %q (expected one of %s)", name, strings.Join(endpointNames, ", "))
+		}
+		switch {
+		case endpoint.TokenFile != "" && endpoint.TokenCommand != "":
+			return fmt.Errorf("endpoints.%s: set token_file or token_command, not both", name)
+		case endpoint.PasswordFile != "" && endpoint.PasswordCommand != "":
+			return fmt.Errorf("endpoints.%s: set password_file or password_command, not both", name)
+		case (endpoint.PasswordFile != "" || endpoint.PasswordCommand != "") && endpoint.Username == "":
+			return fmt.Errorf("endpoints.%s: password requires a username", name)
+		case endpoint.Username != "" && (endpoint.TokenFile != "" || endpoint.TokenCommand != ""):
+			return fmt.Errorf("endpoints.%s: use either a token or basic auth, not both", name)
+		}
+		for _, path := range []string{endpoint.TokenFile, endpoint.PasswordFile} {
+			if path == "" {
+				continue
+			}
+			if _, err := os.Stat(path); err != nil {
+				return fmt.Errorf("endpoints.%s: invalid secret file: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateEventFields(fields map[string]string) error {
+	for key := range fields {
+		known := false
+		for _, candidate := range eventFieldKeys {
+			if strings.EqualFold(strings.TrimSpace(key), candidate) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown event field %q (expected one of %s)", key, strings.Join(eventFieldKeys, ", "))
+		}
+	}
+	return nil
+}
+
 func joinPath(basePath, suffix string) string {
 	if suffix == "" {
 		return basePath
@@ -622,6 +1353,24 @@ func parseDuration(val string) time.Duration {
 	return d
 }
 
+func parseSLOTarget(val string) (float64, bool) {
+	val = strings.TrimSuffix(strings.TrimSpace(val), "%")
+	if val == "" {
+		return 0, false
+	}
+	target, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return 0, false
+	}
+	if target > 1 {
+		target /= 100
+	}
+	if target <= 0 || target >= 1 {
+		return 0, false
+	}
+	return target, true
+}
+
 func parseBool(val string) bool {
 	switch strings.ToLower(strings.TrimSpace(val)) {
 	case "1", "true", "yes", "y", "on":
@@ -713,6 +1462,22 @@ func splitComma(val string) []string {
 	return out
 }
 
+func validAuthStore(name string) bool {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "file", "keyring", "encrypted", "auto":
+		return true
+	}
+	return false
+}
+
+func validTimezone(name string) bool {
+	if strings.TrimSpace(name) == "" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
 func firstNonEmpty(current, next string) string {
 	if strings.TrimSpace(next) == "" {
 		return current
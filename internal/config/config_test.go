@@ -375,3 +375,500 @@ func TestLoadDefaultConfigPath(t *testing.T) {
 		t.Fatalf("expected config from default path, got %s", cfg.WorkerMetricsURL)
 	}
 }
+
+func TestLoadEventSchemaMapping(t *testing.T) {
+	setTestConfigHome(t)
+	cmd := &cobra.Command{Use: "test"}
+	RegisterFlags(cmd)
+
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+	content := "worker_metrics_url: http://config\nevent_fields:\n  ts: timestamp\n  task_id: task.id\nevent_level_aliases:\n  \"50\": error\n"
+	if err := os.WriteFile(cfgPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := cmd.Flags().Set("config", cfgPath); err != nil {
+		t.Fatalf("set config flag: %v", err)
+	}
+	if err := cmd.Flags().Set("event-field", "level=severity"); err != nil {
+		t.Fatalf("set event-field flag: %v", err)
+	}
+
+	cfg, err := Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.EventFields["ts"] != "timestamp" || cfg.EventFields["task_id"] != "task.id" || cfg.EventFields["level"] != "severity" {
+		t.Fatalf("unexpected event fields: %+v", cfg.EventFields)
+	}
+	if cfg.EventLevelAliases["50"] != "error" {
+		t.Fatalf("unexpected level aliases: %+v", cfg.EventLevelAliases)
+	}
+
+	if err := cmd.Flags().Set("event-field", "bogus=path"); err != nil {
+		t.Fatalf("set event-field flag: %v", err)
+	}
+	if _, err := Load(cmd); err == nil || !strings.Contains(err.Error(), "unknown event field") {
+		t.Fatalf("expected unknown event field error, got %v", err)
+	}
+}
+
+func TestLoadAllowsLocalEventsSources(t *testing.T) {
+	setTestConfigHome(t)
+	for _, source := range []string{"-", "/var/log/reproq/events.jsonl", "file:///tmp/events.jsonl"} {
+		cmd := &cobra.Command{Use: "test"}
+		RegisterFlags(cmd)
+		if err := cmd.Flags().Set("worker-metrics-url", "http://metrics"); err != nil {
+			t.Fatalf("set metrics flag: %v", err)
+		}
+		if err := cmd.Flags().Set("events-url", source); err != nil {
+			t.Fatalf("set events flag: %v", err)
+		}
+		cfg, err := Load(cmd)
+		if err != nil {
+			t.Fatalf("load config with %q: %v", source, err)
+		}
+		if cfg.EventsURL != source {
+			t.Fatalf("expected events url %q, got %q", source, cfg.EventsURL)
+		}
+	}
+	cmd := &cobra.Command{Use: "test"}
+	RegisterFlags(cmd)
+	if err := cmd.Flags().Set("worker-metrics-url", "http://metrics"); err != nil {
+		t.Fatalf("set metrics flag: %v", err)
+	}
+	if err := cmd.Flags().Set("events-url", "localhost:9100/events"); err != nil {
+		t.Fatalf("set events flag: %v", err)
+	}
+	if _, err := Load(cmd); err == nil || !strings.Contains(err.Error(), "events url") {
+		t.Fatalf("expected a schemeless events url to be rejected, got %v", err)
+	}
+}
+
+func TestLoadEventHistoryDefaultsAndDisable(t *testing.T) {
+	home := setTestConfigHome(t)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	cmd := &cobra.Command{Use: "test"}
+	RegisterFlags(cmd)
+	if err := cmd.Flags().Set("worker-metrics-url", "http://metrics"); err != nil {
+		t.Fatalf("set metrics flag: %v", err)
+	}
+
+	cfg, err := Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if !cfg.EventHistory || !strings.HasSuffix(cfg.EventHistoryFile, filepath.Join("reproq-tui", "events.jsonl")) {
+		t.Fatalf("expected default history file, got %v %q", cfg.EventHistory, cfg.EventHistoryFile)
+	}
+	if cfg.EventHistoryMaxAge != 72*time.Hour {
+		t.Fatalf("expected default retention, got %s", cfg.EventHistoryMaxAge)
+	}
+
+	t.Setenv(envPrefix+"EVENT_HISTORY_RETENTION", "6h")
+	if err := cmd.Flags().Set("event-history", "false"); err != nil {
+		t.Fatalf("set event-history flag: %v", err)
+	}
+	cfg, err = Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.EventHistory || cfg.EventHistoryFile != "" {
+		t.Fatalf("expected history disabled, got %v %q", cfg.EventHistory, cfg.EventHistoryFile)
+	}
+	if cfg.EventHistoryMaxAge != 6*time.Hour {
+		t.Fatalf("expected env retention, got %s", cfg.EventHistoryMaxAge)
+	}
+}
+
+func TestLoadSLOTargetAndWindow(t *testing.T) {
+	setTestConfigHome(t)
+	cmd := &cobra.Command{Use: "test"}
+	RegisterFlags(cmd)
+	if err := cmd.Flags().Set("worker-metrics-url", "http://metrics"); err != nil {
+		t.Fatalf("set metrics flag: %v", err)
+	}
+
+	cfg, err := Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.SLOTarget != 0.999 || cfg.SLOWindow != 24*time.Hour {
+		t.Fatalf("expected default SLO, got %v over %s", cfg.SLOTarget, cfg.SLOWindow)
+	}
+
+	t.Setenv(envPrefix+"SLO_TARGET", "99.5%")
+	t.Setenv(envPrefix+"SLO_WINDOW", "6h")
+	cfg, err = Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.SLOTarget != 0.995 || cfg.SLOWindow != 6*time.Hour {
+		t.Fatalf("expected env SLO, got %v over %s", cfg.SLOTarget, cfg.SLOWindow)
+	}
+
+	if err := cmd.Flags().Set("slo-target", "0.99"); err != nil {
+		t.Fatalf("set slo-target flag: %v", err)
+	}
+	cfg, err = Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.SLOTarget != 0.99 {
+		t.Fatalf("expected flag SLO target, got %v", cfg.SLOTarget)
+	}
+
+	if err := cmd.Flags().Set("slo-target", "150"); err != nil {
+		t.Fatalf("set slo-target flag: %v", err)
+	}
+	cfg, err = Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.SLOTarget != 0.995 {
+		t.Fatalf("expected invalid flag target to be ignored, got %v", cfg.SLOTarget)
+	}
+}
+
+func TestLoadCronTimezone(t *testing.T) {
+	setTestConfigHome(t)
+	cmd := &cobra.Command{Use: "test"}
+	RegisterFlags(cmd)
+	if err := cmd.Flags().Set("worker-metrics-url", "http://metrics"); err != nil {
+		t.Fatalf("set metrics flag: %v", err)
+	}
+
+	t.Setenv(envPrefix+"CRON_TIMEZONE", "Not/AZone")
+	cfg, err := Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.CronTimezone != "UTC" {
+		t.Fatalf("expected invalid zone to fall back to UTC, got %q", cfg.CronTimezone)
+	}
+
+	if err := cmd.Flags().Set("cron-timezone", "America/Chicago"); err != nil {
+		t.Fatalf("set cron-timezone flag: %v", err)
+	}
+	cfg, err = Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.CronTimezone != "America/Chicago" {
+		t.Fatalf("expected flag zone, got %q", cfg.CronTimezone)
+	}
+}
+
+func TestLoadWorkerStaleAfter(t *testing.T) {
+	setTestConfigHome(t)
+	cmd := &cobra.Command{Use: "test"}
+	RegisterFlags(cmd)
+	if err := cmd.Flags().Set("worker-metrics-url", "http://metrics"); err != nil {
+		t.Fatalf("set metrics flag: %v", err)
+	}
+
+	cfg, err := Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.WorkerStaleAfter != 0 {
+		t.Fatalf("expected automatic stale threshold, got %s", cfg.WorkerStaleAfter)
+	}
+
+	t.Setenv(envPrefix+"WORKER_STALE_AFTER", "2m")
+	cfg, err = Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.WorkerStaleAfter != 2*time.Minute {
+		t.Fatalf("expected env threshold, got %s", cfg.WorkerStaleAfter)
+	}
+
+	if err := cmd.Flags().Set("worker-stale-after", "45s"); err != nil {
+		t.Fatalf("set worker-stale-after flag: %v", err)
+	}
+	cfg, err = Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.WorkerStaleAfter != 45*time.Second {
+		t.Fatalf("expected flag threshold, got %s", cfg.WorkerStaleAfter)
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	setTestConfigHome(t)
+	cmd := &cobra.Command{Use: "test"}
+	RegisterFlags(cmd)
+	if err := cmd.Flags().Set("worker-metrics-url", "http://metrics"); err != nil {
+		t.Fatalf("set metrics flag: %v", err)
+	}
+
+	t.Setenv(envPrefix+"PROFILE", " staging ")
+	cfg, err := Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.Profile != "staging" {
+		t.Fatalf("expected env profile, got %q", cfg.Profile)
+	}
+
+	if err := cmd.Flags().Set("profile", "prod"); err != nil {
+		t.Fatalf("set profile flag: %v", err)
+	}
+	cfg, err = Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.Profile != "prod" {
+		t.Fatalf("expected flag profile, got %q", cfg.Profile)
+	}
+	if cfg.AuthRefreshBefore != 10*time.Minute {
+		t.Fatalf("expected default refresh lead, got %v", cfg.AuthRefreshBefore)
+	}
+
+	t.Setenv(envPrefix+"AUTH_REFRESH_BEFORE", "2m")
+	cfg, err = Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.AuthRefreshBefore != 2*time.Minute {
+		t.Fatalf("expected env refresh lead, got %v", cfg.AuthRefreshBefore)
+	}
+}
+
+func TestLoadOIDC(t *testing.T) {
+	setTestConfigHome(t)
+	cmd := &cobra.Command{Use: "test"}
+	RegisterFlags(cmd)
+	if err := cmd.Flags().Set("worker-metrics-url", "http://metrics"); err != nil {
+		t.Fatalf("set metrics flag: %v", err)
+	}
+	cfg, err := Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if strings.Join(cfg.OIDCScopes, " ") != "openid offline_access" {
+		t.Fatalf("unexpected default scopes %v", cfg.OIDCScopes)
+	}
+
+	t.Setenv(envPrefix+"OIDC_ISSUER", "https://sso.example.com/realms/ops")
+	t.Setenv(envPrefix+"OIDC_CLIENT_ID", "reproq-tui")
+	if err := cmd.Flags().Set("oidc-scopes", "openid, profile"); err != nil {
+		t.Fatalf("set oidc-scopes flag: %v", err)
+	}
+	cfg, err = Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.OIDCIssuer != "https://sso.example.com/realms/ops" || cfg.OIDCClientID != "reproq-tui" {
+		t.Fatalf("unexpected oidc config %q %q", cfg.OIDCIssuer, cfg.OIDCClientID)
+	}
+	if strings.Join(cfg.OIDCScopes, " ") != "openid profile" {
+		t.Fatalf("expected flag scopes, got %v", cfg.OIDCScopes)
+	}
+
+	t.Setenv(envPrefix+"OIDC_ISSUER", "not a url")
+	if _, err := Load(cmd); err == nil {
+		t.Fatalf("expected invalid issuer to fail validation")
+	}
+}
+
+func TestLoadTLSFiles(t *testing.T) {
+	setTestConfigHome(t)
+	cmd := &cobra.Command{Use: "test"}
+	RegisterFlags(cmd)
+	if err := cmd.Flags().Set("worker-metrics-url", "https://metrics"); err != nil {
+		t.Fatalf("set metrics flag: %v", err)
+	}
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "client.pem")
+	if err := os.WriteFile(certPath, []byte("pem"), 0o600); err != nil {
+		t.Fatalf("write cert: %v", err)
+	}
+
+	t.Setenv(envPrefix+"CLIENT_CERT", certPath)
+	t.Setenv(envPrefix+"TLS_SERVER_NAME", "worker.internal")
+	cfg, err := Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.ClientCertFile != certPath || cfg.TLSServerName != "worker.internal" {
+		t.Fatalf("unexpected tls config %q %q", cfg.ClientCertFile, cfg.TLSServerName)
+	}
+
+	if err := cmd.Flags().Set("ca-file", filepath.Join(dir, "missing.pem")); err != nil {
+		t.Fatalf("set ca-file flag: %v", err)
+	}
+	if _, err := Load(cmd); err == nil || !strings.Contains(err.Error(), "ca file") {
+		t.Fatalf("expected missing ca file error, got %v", err)
+	}
+}
+
+func TestLoadResilience(t *testing.T) {
+	setTestConfigHome(t)
+	cmd := &cobra.Command{Use: "test"}
+	RegisterFlags(cmd)
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(cfgPath, []byte("worker_metrics_url = \"http://metrics\"\nretries = 4\nretry_backoff = \"250ms\"\nbreaker_max_backoff = \"2m\"\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := cmd.Flags().Set("config", cfgPath); err != nil {
+		t.Fatalf("set config flag: %v", err)
+	}
+
+	cfg, err := Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.Retries != 4 || cfg.RetryBackoff != 250*time.Millisecond || cfg.BreakerThreshold != 3 || cfg.BreakerMaxBackoff != 2*time.Minute {
+		t.Fatalf("unexpected resilience config %d %v %d %v", cfg.Retries, cfg.RetryBackoff, cfg.BreakerThreshold, cfg.BreakerMaxBackoff)
+	}
+
+	t.Setenv(envPrefix+"RETRIES", "0")
+	t.Setenv(envPrefix+"BREAKER_THRESHOLD", "-1")
+	if err := cmd.Flags().Set("breaker-max-backoff", "30s"); err != nil {
+		t.Fatalf("set breaker flag: %v", err)
+	}
+	cfg, err = Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.Retries != 0 || cfg.BreakerThreshold != 3 || cfg.BreakerMaxBackoff != 30*time.Second {
+		t.Fatalf("expected env to disable retries and ignore a negative threshold, got %d %d %v", cfg.Retries, cfg.BreakerThreshold, cfg.BreakerMaxBackoff)
+	}
+}
+
+func TestLoadEndpoints(t *testing.T) {
+	setTestConfigHome(t)
+	cmd := &cobra.Command{Use: "test"}
+	RegisterFlags(cmd)
+
+	dir := t.TempDir()
+	tokenPath := filepath.Join(dir, "worker-token")
+	if err := os.WriteFile(tokenPath, []byte("worker-secret\n"), 0o600); err != nil {
+		t.Fatalf("write token: %v", err)
+	}
+	cfgPath := filepath.Join(dir, "config.yaml")
+	write := func(body string) {
+		t.Helper()
+		if err := os.WriteFile(cfgPath, []byte("worker_metrics_url: http://metrics\n"+body), 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+	if err := cmd.Flags().Set("config", cfgPath); err != nil {
+		t.Fatalf("set config flag: %v", err)
+	}
+
+	write("endpoints:\n  metrics:\n    token_file: " + tokenPath + "\n  Django:\n    username: ops\n    password_command: vault read -field=password secret/django\n    headers: [\"X-Tenant: blue\"]\n")
+	cfg, err := Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.Endpoints[EndpointMetrics].TokenFile != tokenPath {
+		t.Fatalf("unexpected metrics endpoint %+v", cfg.Endpoints[EndpointMetrics])
+	}
+	django := cfg.Endpoints[EndpointDjango]
+	if django.Username != "ops" || django.PasswordCommand != "vault read -field=password secret/django" || django.Headers["X-Tenant"] != "blue" {
+		t.Fatalf("unexpected django endpoint %+v", django)
+	}
+	if _, ok := cfg.Endpoints[EndpointHealth]; ok {
+		t.Fatalf("expected health to keep the shared headers")
+	}
+
+	for body, want := range map[string]string{
+		"endpoints:\n  health:\n    token: plain\n":                                          "inline secrets",
+		"endpoints:\n  stats:\n    token_file: " + tokenPath + "\n":                          "unknown endpoint",
+		"endpoints:\n  events:\n    token_file: " + tokenPath + "\n    token_command: cat\n": "not both",
+		"endpoints:\n  events:\n    password_file: " + tokenPath + "\n":                      "requires a username",
+		"endpoints:\n  events:\n    token_file: " + filepath.Join(dir, "missing") + "\n":     "invalid secret file",
+	} {
+		write(body)
+		if _, err := Load(cmd); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q error for %q, got %v", want, body, err)
+		}
+	}
+}
+
+func TestLoadAuthStore(t *testing.T) {
+	setTestConfigHome(t)
+	cmd := &cobra.Command{Use: "test"}
+	RegisterFlags(cmd)
+	if err := cmd.Flags().Set("worker-metrics-url", "http://metrics"); err != nil {
+		t.Fatalf("set metrics flag: %v", err)
+	}
+
+	t.Setenv(envPrefix+"AUTH_STORE", "vault")
+	cfg, err := Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.AuthStore != "" {
+		t.Fatalf("expected unknown backend to be ignored, got %q", cfg.AuthStore)
+	}
+
+	t.Setenv(envPrefix+"AUTH_STORE", "Encrypted")
+	t.Setenv(envPrefix+"AUTH_PASSPHRASE_FILE", "/run/secrets/reproq")
+	cfg, err = Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.AuthStore != "encrypted" || cfg.AuthPassphraseFile != "/run/secrets/reproq" {
+		t.Fatalf("unexpected auth store config %q %q", cfg.AuthStore, cfg.AuthPassphraseFile)
+	}
+
+	if err := cmd.Flags().Set("auth-store", "keyring"); err != nil {
+		t.Fatalf("set auth-store flag: %v", err)
+	}
+	cfg, err = Load(cmd)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.AuthStore != "keyring" {
+		t.Fatalf("expected flag backend, got %q", cfg.AuthStore)
+	}
+}
+
+func TestLoadAuthStoreWithoutDashboardFlags(t *testing.T) {
+	home := setTestConfigHome(t)
+	t.Setenv(envPrefix+"AUTH_STORE", "")
+	t.Setenv(envPrefix+"PROFILE", "")
+	passphrase := filepath.Join(home, "passphrase")
+	t.Setenv(envPrefix+"AUTH_PASSPHRASE_FILE", passphrase)
+
+	defaultPath, err := DefaultConfigPath()
+	if err != nil {
+		t.Fatalf("default config path: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(defaultPath), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(defaultPath, []byte("auth_store: encrypted\nauth_passphrase_file: /etc/reproq/passphrase\nprofile: staging\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	// Commands like logout only define the auth store flags.
+	cmd := &cobra.Command{Use: "logout"}
+	cmd.Flags().String("auth-store", "", "")
+	cmd.Flags().String("auth-passphrase-file", "", "")
+	cmd.Flags().String("profile", "", "")
+	cfg, err := LoadAuthStore(cmd)
+	if err != nil {
+		t.Fatalf("load auth store: %v", err)
+	}
+	if cfg.AuthStore != "encrypted" || cfg.AuthPassphraseFile != passphrase || cfg.Profile != "staging" {
+		t.Fatalf("expected config file and env to apply, got store=%q passphrase=%q profile=%q", cfg.AuthStore, cfg.AuthPassphraseFile, cfg.Profile)
+	}
+
+	if err := cmd.Flags().Set("auth-store", "keyring"); err != nil {
+		t.Fatalf("set flag: %v", err)
+	}
+	if err := cmd.Flags().Set("profile", "prod"); err != nil {
+		t.Fatalf("set flag: %v", err)
+	}
+	cfg, err = LoadAuthStore(cmd)
+	if err != nil || cfg.AuthStore != "keyring" || cfg.Profile != "prod" {
+		t.Fatalf("expected flags to win, got store=%q profile=%q (%v)", cfg.AuthStore, cfg.Profile, err)
+	}
+}
@@ -0,0 +1,65 @@
+package control
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type AuditEntry struct {
+	Timestamp time.Time `json:"ts"`
+	Action    string    `json:"action"`
+	Target    string    `json:"target"`
+	Database  string    `json:"database,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	Endpoint  string    `json:"endpoint,omitempty"`
+	Result    string    `json:"result"`
+	Affected  int       `json:"affected,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+type AuditLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewAuditLog(path string) *AuditLog {
+	return &AuditLog{path: path}
+}
+
+func (a *AuditLog) Path() string {
+	return a.path
+}
+
+func (a *AuditLog) Record(entry AuditEntry) error {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	if entry.Result == "" {
+		entry.Result = "ok"
+		if entry.Error != "" {
+			entry.Result = "error"
+		}
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(a.path), 0o700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(a.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
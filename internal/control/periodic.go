@@ -0,0 +1,52 @@
+package control
+
+import (
+	"context"
+
+	"github.com/adpena/reproq-tui/pkg/client"
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+const (
+	ActionEnable  = "enable"
+	ActionDisable = "disable"
+	ActionRunNow  = "run"
+)
+
+type PeriodicRequest struct {
+	Name     string `json:"name"`
+	Database string `json:"database,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+type PeriodicRunResult struct {
+	ResultID int64 `json:"result_id"`
+}
+
+func SetPeriodicEnabled(ctx context.Context, httpClient *client.Client, baseURL string, req PeriodicRequest, enabled bool) (models.PeriodicTask, error) {
+	action := ActionDisable
+	if enabled {
+		action = ActionEnable
+	}
+	endpoint, err := client.JoinURL(baseURL, "/reproq/periodic/"+action+"/")
+	if err != nil {
+		return models.PeriodicTask{}, err
+	}
+	task := models.PeriodicTask{Name: req.Name, Enabled: enabled}
+	if err := postJSON(ctx, httpClient, endpoint, req, &task); err != nil {
+		return models.PeriodicTask{}, err
+	}
+	return task, nil
+}
+
+func RunPeriodicNow(ctx context.Context, httpClient *client.Client, baseURL string, req PeriodicRequest) (PeriodicRunResult, error) {
+	endpoint, err := client.JoinURL(baseURL, "/reproq/periodic/"+ActionRunNow+"/")
+	if err != nil {
+		return PeriodicRunResult{}, err
+	}
+	var result PeriodicRunResult
+	if err := postJSON(ctx, httpClient, endpoint, req, &result); err != nil {
+		return PeriodicRunResult{}, err
+	}
+	return result, nil
+}
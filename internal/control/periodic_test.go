@@ -0,0 +1,51 @@
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/client"
+)
+
+func TestPeriodicActions(t *testing.T) {
+	paths := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		var req PeriodicRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch r.URL.Path {
+		case "/reproq/periodic/enable/":
+			_, _ = w.Write([]byte(`{"name":"` + req.Name + `","enabled":true}`))
+		case "/reproq/periodic/run/":
+			_, _ = w.Write([]byte(`{"result_id":42}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	httpClient := client.New(client.Options{Timeout: time.Second})
+	ctx := context.Background()
+	task, err := SetPeriodicEnabled(ctx, httpClient, server.URL, PeriodicRequest{Name: "cleanup"}, true)
+	if err != nil || !task.Enabled || task.Name != "cleanup" {
+		t.Fatalf("enable: %+v %v", task, err)
+	}
+	task, err = SetPeriodicEnabled(ctx, httpClient, server.URL, PeriodicRequest{Name: "cleanup"}, false)
+	if err != nil || task.Enabled {
+		t.Fatalf("disable with empty body: %+v %v", task, err)
+	}
+	result, err := RunPeriodicNow(ctx, httpClient, server.URL, PeriodicRequest{Name: "cleanup"})
+	if err != nil || result.ResultID != 42 {
+		t.Fatalf("run now: %+v %v", result, err)
+	}
+	want := []string{"/reproq/periodic/enable/", "/reproq/periodic/disable/", "/reproq/periodic/run/"}
+	for i, path := range want {
+		if paths[i] != path {
+			t.Fatalf("expected %s, got %v", path, paths)
+		}
+	}
+}
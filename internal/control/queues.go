@@ -0,0 +1,82 @@
+package control
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/client"
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+type QueueRequest struct {
+	Queue    string `json:"queue_name"`
+	Database string `json:"database,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+func PauseQueue(ctx context.Context, httpClient *client.Client, baseURL string, req QueueRequest) (models.QueueControl, error) {
+	return setQueuePaused(ctx, httpClient, baseURL, req, true)
+}
+
+func ResumeQueue(ctx context.Context, httpClient *client.Client, baseURL string, req QueueRequest) (models.QueueControl, error) {
+	return setQueuePaused(ctx, httpClient, baseURL, req, false)
+}
+
+func setQueuePaused(ctx context.Context, httpClient *client.Client, baseURL string, req QueueRequest, paused bool) (models.QueueControl, error) {
+	action := "resume"
+	if paused {
+		action = "pause"
+	}
+	endpoint, err := client.JoinURL(baseURL, "/reproq/queues/"+action+"/")
+	if err != nil {
+		return models.QueueControl{}, err
+	}
+	control := models.QueueControl{
+		QueueName: req.Queue,
+		Paused:    paused,
+		Reason:    strings.TrimSpace(req.Reason),
+		UpdatedAt: time.Now(),
+		Database:  req.Database,
+	}
+	if paused {
+		control.PausedAt = control.UpdatedAt
+	}
+	if err := postJSON(ctx, httpClient, endpoint, req, &control); err != nil {
+		return models.QueueControl{}, err
+	}
+	return control, nil
+}
+
+func postJSON(ctx context.Context, httpClient *client.Client, endpoint string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return client.StatusError{URL: endpoint, Code: resp.StatusCode}
+	}
+	if out == nil {
+		return nil
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil || len(bytes.TrimSpace(data)) == 0 {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,59 @@
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/client"
+)
+
+func TestPauseQueuePostsRequest(t *testing.T) {
+	var got QueueRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/reproq/queues/pause/" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"queue_name": got.Queue,
+			"paused":     true,
+			"reason":     got.Reason,
+			"database":   "default",
+		})
+	}))
+	defer server.Close()
+
+	httpClient := client.New(client.Options{Timeout: time.Second, Headers: map[string]string{"Authorization": "Bearer token"}})
+	control, err := PauseQueue(context.Background(), httpClient, server.URL+"/reproq", QueueRequest{Queue: "bulk", Reason: "deploy"})
+	if err != nil {
+		t.Fatalf("pause queue: %v", err)
+	}
+	if got.Queue != "bulk" || got.Reason != "deploy" {
+		t.Fatalf("unexpected request %+v", got)
+	}
+	if !control.Paused || control.Database != "default" || control.Reason != "deploy" {
+		t.Fatalf("unexpected control %+v", control)
+	}
+}
+
+func TestResumeQueueReportsStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	httpClient := client.New(client.Options{Timeout: time.Second})
+	_, err := ResumeQueue(context.Background(), httpClient, server.URL, QueueRequest{Queue: "bulk"})
+	if !client.IsStatus(err, http.StatusForbidden) {
+		t.Fatalf("expected 403 status error, got %v", err)
+	}
+}
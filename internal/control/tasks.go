@@ -0,0 +1,114 @@
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/adpena/reproq-tui/pkg/client"
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+const (
+	ActionRetry  = "retry"
+	ActionCancel = "cancel"
+)
+
+type TaskQuery struct {
+	TaskPath string
+	Status   string
+	Database string
+	Limit    int
+}
+
+type TaskActionRequest struct {
+	ResultIDs []int64 `json:"result_ids,omitempty"`
+	TaskPath  string  `json:"task_path,omitempty"`
+	Database  string  `json:"database,omitempty"`
+	Reason    string  `json:"reason,omitempty"`
+}
+
+type TaskActionResult struct {
+	Affected int `json:"affected"`
+}
+
+func ListTasks(ctx context.Context, httpClient *client.Client, baseURL string, query TaskQuery) ([]models.TaskRun, error) {
+	endpoint, err := client.JoinURL(baseURL, "/reproq/tasks/")
+	if err != nil {
+		return nil, err
+	}
+	values := url.Values{}
+	if query.TaskPath != "" {
+		values.Set("task_path", query.TaskPath)
+	}
+	if query.Status != "" {
+		values.Set("status", query.Status)
+	}
+	if query.Database != "" {
+		values.Set("database", query.Database)
+	}
+	if query.Limit > 0 {
+		values.Set("limit", strconv.Itoa(query.Limit))
+	}
+	if encoded := values.Encode(); encoded != "" {
+		endpoint += "?" + encoded
+	}
+	var payload struct {
+		Tasks []models.TaskRun `json:"tasks"`
+	}
+	if err := getJSON(ctx, httpClient, endpoint, &payload); err != nil {
+		return nil, err
+	}
+	return payload.Tasks, nil
+}
+
+func GetTask(ctx context.Context, httpClient *client.Client, baseURL string, resultID int64) (models.TaskRun, error) {
+	endpoint, err := client.JoinURL(baseURL, "/reproq/tasks/"+strconv.FormatInt(resultID, 10)+"/")
+	if err != nil {
+		return models.TaskRun{}, err
+	}
+	var task models.TaskRun
+	if err := getJSON(ctx, httpClient, endpoint, &task); err != nil {
+		return models.TaskRun{}, err
+	}
+	return task, nil
+}
+
+func RetryTasks(ctx context.Context, httpClient *client.Client, baseURL string, req TaskActionRequest) (TaskActionResult, error) {
+	return taskAction(ctx, httpClient, baseURL, ActionRetry, req)
+}
+
+func CancelTasks(ctx context.Context, httpClient *client.Client, baseURL string, req TaskActionRequest) (TaskActionResult, error) {
+	return taskAction(ctx, httpClient, baseURL, ActionCancel, req)
+}
+
+func taskAction(ctx context.Context, httpClient *client.Client, baseURL, action string, req TaskActionRequest) (TaskActionResult, error) {
+	endpoint, err := client.JoinURL(baseURL, "/reproq/tasks/"+action+"/")
+	if err != nil {
+		return TaskActionResult{}, err
+	}
+	var result TaskActionResult
+	if err := postJSON(ctx, httpClient, endpoint, req, &result); err != nil {
+		return TaskActionResult{}, err
+	}
+	return result, nil
+}
+
+func getJSON(ctx context.Context, httpClient *client.Client, endpoint string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return client.StatusError{URL: endpoint, Code: resp.StatusCode}
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
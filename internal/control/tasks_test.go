@@ -0,0 +1,87 @@
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/client"
+)
+
+func TestTaskListInspectAndRetry(t *testing.T) {
+	var retried TaskActionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/reproq/tasks/":
+			query := r.URL.Query()
+			if query.Get("task_path") != "app.tasks.send" || query.Get("status") != "FAILED" || query.Get("limit") != "5" {
+				t.Errorf("unexpected query %q", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"tasks":[{"result_id":7,"task_path":"app.tasks.send","status":"FAILED","exception":"ValueError: boom"}]}`))
+		case "/reproq/tasks/7/":
+			_, _ = w.Write([]byte(`{"result_id":7,"args":[1,"a"],"traceback":"Traceback..."}`))
+		case "/reproq/tasks/retry/":
+			_ = json.NewDecoder(r.Body).Decode(&retried)
+			_, _ = w.Write([]byte(`{"affected":1}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	httpClient := client.New(client.Options{Timeout: time.Second})
+	ctx := context.Background()
+	tasks, err := ListTasks(ctx, httpClient, server.URL, TaskQuery{TaskPath: "app.tasks.send", Status: "FAILED", Limit: 5})
+	if err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ResultID != 7 || tasks[0].Exception != "ValueError: boom" {
+		t.Fatalf("unexpected tasks %+v", tasks)
+	}
+	task, err := GetTask(ctx, httpClient, server.URL, 7)
+	if err != nil {
+		t.Fatalf("get task: %v", err)
+	}
+	if string(task.Args) != `[1,"a"]` || task.Traceback == "" {
+		t.Fatalf("unexpected task detail %+v", task)
+	}
+	result, err := RetryTasks(ctx, httpClient, server.URL, TaskActionRequest{ResultIDs: []int64{7}, Reason: "flaky"})
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if result.Affected != 1 || len(retried.ResultIDs) != 1 || retried.Reason != "flaky" {
+		t.Fatalf("unexpected retry %+v %+v", result, retried)
+	}
+}
+
+func TestAuditLogAppendsEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "audit.jsonl")
+	audit := NewAuditLog(path)
+	if err := audit.Record(AuditEntry{Action: "pause", Target: "bulk", Reason: "deploy"}); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if err := audit.Record(AuditEntry{Action: "task.retry", Target: "#7", Error: "http status 500"}); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read audit log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(lines))
+	}
+	var entry AuditEntry
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatalf("decode entry: %v", err)
+	}
+	if entry.Result != "error" || entry.Timestamp.IsZero() {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+}
@@ -0,0 +1,252 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchLimit bounds Next so impossible schedules (for example Feb 30) end
+// instead of looping forever.
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+type Schedule struct {
+	expr     string
+	minute   uint64
+	hour     uint64
+	dom      uint64
+	month    uint64
+	dow      uint64
+	domStar  bool
+	dowStar  bool
+	fields   []string
+	location *time.Location
+}
+
+type fieldSpec struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteSpec = fieldSpec{name: "minute", min: 0, max: 59}
+	hourSpec   = fieldSpec{name: "hour", min: 0, max: 23}
+	domSpec    = fieldSpec{name: "day of month", min: 1, max: 31}
+	monthSpec  = fieldSpec{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	dowSpec = fieldSpec{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+)
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+func Parse(expr string, loc *time.Location) (*Schedule, error) {
+	trimmed := strings.TrimSpace(expr)
+	if expanded, ok := macros[strings.ToLower(trimmed)]; ok {
+		trimmed = expanded
+	}
+	fields := strings.Fields(trimmed)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron %q: expected 5 fields, got %d", expr, len(fields))
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	s := &Schedule{expr: expr, fields: fields, location: loc}
+	var err error
+	if s.minute, err = parseField(fields[0], minuteSpec); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseField(fields[1], hourSpec); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseField(fields[2], domSpec); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseField(fields[3], monthSpec); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseField(fields[4], dowSpec); err != nil {
+		return nil, err
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = strings.HasPrefix(fields[2], "*")
+	s.dowStar = strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+func parseField(field string, spec fieldSpec) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		if part == "" {
+			return 0, fmt.Errorf("cron %s: empty list item in %q", spec.name, field)
+		}
+		rangePart, step := part, 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			rangePart = part[:idx]
+			parsed, err := strconv.Atoi(part[idx+1:])
+			if err != nil || parsed <= 0 {
+				return 0, fmt.Errorf("cron %s: invalid step in %q", spec.name, part)
+			}
+			step = parsed
+		}
+		lo, hi := spec.min, spec.max
+		switch {
+		case rangePart == "*":
+			if spec.name == dowSpec.name {
+				hi = 6
+			}
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = parseValue(bounds[0], spec); err != nil {
+				return 0, err
+			}
+			if hi, err = parseValue(bounds[1], spec); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("cron %s: range %q is reversed", spec.name, rangePart)
+			}
+		default:
+			value, err := parseValue(rangePart, spec)
+			if err != nil {
+				return 0, err
+			}
+			lo = value
+			if step == 1 {
+				hi = value
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(raw string, spec fieldSpec) (int, error) {
+	if value, ok := spec.names[strings.ToUpper(raw)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < spec.min || value > spec.max {
+		return 0, fmt.Errorf("cron %s: invalid value %q", spec.name, raw)
+	}
+	return value, nil
+}
+
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+func (s *Schedule) Location() *time.Location {
+	return s.location
+}
+
+// Next returns the first fire time strictly after t, or the zero time when
+// the schedule never fires.
+//
+// Across DST changes the search steps in absolute time, so it always moves
+// forward. Wall times skipped when clocks spring forward never fire. Wall
+// times repeated when clocks fall back fire once, in the first occurrence,
+// unless the schedule runs every hour, in which case both occurrences fire.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(searchLimit)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = forward(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location))
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location))
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 || (!s.everyHour() && repeatedWallTime(t)) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// forward returns next, or an hour past t when a wall time in a DST gap
+// normalized next to a time that is not ahead of t.
+func forward(t, next time.Time) time.Time {
+	if next.After(t) {
+		return next
+	}
+	return t.Add(time.Hour)
+}
+
+func (s *Schedule) everyHour() bool {
+	return s.hour == 1<<24-1
+}
+
+// repeatedWallTime reports whether t's wall clock already occurred earlier
+// because clocks were set back.
+func repeatedWallTime(t time.Time) bool {
+	_, offset := t.Zone()
+	_, before := t.Add(-time.Hour).Zone()
+	if before <= offset {
+		return false
+	}
+	earlier := t.Add(-time.Duration(before-offset) * time.Second)
+	return earlier.Day() == t.Day() && earlier.Hour() == t.Hour() && earlier.Minute() == t.Minute()
+}
+
+func (s *Schedule) NextN(t time.Time, n int) []time.Time {
+	out := make([]time.Time, 0, n)
+	for len(out) < n {
+		t = s.Next(t)
+		if t.IsZero() {
+			break
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// Between counts fire times in (from, to], stopping at max.
+func (s *Schedule) Between(from, to time.Time, max int) int {
+	count := 0
+	for count < max {
+		from = s.Next(from)
+		if from.IsZero() || from.After(to) {
+			break
+		}
+		count++
+	}
+	return count
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
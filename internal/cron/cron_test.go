@@ -0,0 +1,167 @@
+package cron
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestNextFireTimes(t *testing.T) {
+	base := time.Date(2025, 3, 14, 10, 7, 30, 0, time.UTC)
+	cases := []struct {
+		expr string
+		want []time.Time
+	}{
+		{"*/15 * * * *", []time.Time{
+			time.Date(2025, 3, 14, 10, 15, 0, 0, time.UTC),
+			time.Date(2025, 3, 14, 10, 30, 0, 0, time.UTC),
+		}},
+		{"0 9 * * MON-FRI", []time.Time{
+			time.Date(2025, 3, 17, 9, 0, 0, 0, time.UTC),
+			time.Date(2025, 3, 18, 9, 0, 0, 0, time.UTC),
+		}},
+		{"30 2 1 * *", []time.Time{
+			time.Date(2025, 4, 1, 2, 30, 0, 0, time.UTC),
+			time.Date(2025, 5, 1, 2, 30, 0, 0, time.UTC),
+		}},
+		{"0 0 13 * 5", []time.Time{
+			time.Date(2025, 3, 21, 0, 0, 0, 0, time.UTC),
+			time.Date(2025, 3, 28, 0, 0, 0, 0, time.UTC),
+		}},
+		{"@hourly", []time.Time{
+			time.Date(2025, 3, 14, 11, 0, 0, 0, time.UTC),
+			time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC),
+		}},
+		{"0 0 29 2 *", []time.Time{
+			time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		}},
+	}
+	for _, tc := range cases {
+		schedule, err := Parse(tc.expr, time.UTC)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tc.expr, err)
+		}
+		got := schedule.NextN(base, len(tc.want))
+		if len(got) != len(tc.want) {
+			t.Fatalf("%q: expected %d fires, got %v", tc.expr, len(tc.want), got)
+		}
+		for i := range got {
+			if !got[i].Equal(tc.want[i]) {
+				t.Fatalf("%q fire %d: expected %s, got %s", tc.expr, i, tc.want[i], got[i])
+			}
+		}
+	}
+}
+
+func TestNextUsesLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	schedule, err := Parse("0 9 * * *", loc)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	next := schedule.Next(time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC))
+	if want := time.Date(2025, 1, 2, 7, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Fatalf("expected %s, got %s", want, next.UTC())
+	}
+}
+
+func TestNextAcrossDSTTransitions(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	edt := time.FixedZone("EDT", -4*60*60)
+	est := time.FixedZone("EST", -5*60*60)
+	cases := []struct {
+		name string
+		expr string
+		from time.Time
+		want []time.Time
+	}{
+		{"spring forward skips the missing wall time", "30 2 * * *", time.Date(2026, 3, 8, 0, 0, 0, 0, loc), []time.Time{
+			time.Date(2026, 3, 9, 2, 30, 0, 0, edt),
+			time.Date(2026, 3, 10, 2, 30, 0, 0, edt),
+		}},
+		{"spring forward keeps hourly runs", "0 * * * *", time.Date(2026, 3, 8, 0, 30, 0, 0, loc), []time.Time{
+			time.Date(2026, 3, 8, 1, 0, 0, 0, est),
+			time.Date(2026, 3, 8, 3, 0, 0, 0, edt),
+		}},
+		{"fall back fires a repeated wall time once", "30 1 * * *", time.Date(2026, 10, 31, 12, 0, 0, 0, loc), []time.Time{
+			time.Date(2026, 11, 1, 1, 30, 0, 0, edt),
+			time.Date(2026, 11, 2, 1, 30, 0, 0, est),
+		}},
+		{"fall back repeats every-hour schedules", "*/30 * * * *", time.Date(2026, 11, 1, 0, 50, 0, 0, loc), []time.Time{
+			time.Date(2026, 11, 1, 1, 0, 0, 0, edt),
+			time.Date(2026, 11, 1, 1, 30, 0, 0, edt),
+			time.Date(2026, 11, 1, 1, 0, 0, 0, est),
+			time.Date(2026, 11, 1, 1, 30, 0, 0, est),
+			time.Date(2026, 11, 1, 2, 0, 0, 0, est),
+		}},
+	}
+	for _, tc := range cases {
+		schedule, err := Parse(tc.expr, loc)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tc.expr, err)
+		}
+		got := schedule.NextN(tc.from, len(tc.want))
+		if len(got) != len(tc.want) {
+			t.Fatalf("%s: expected %d fires, got %v", tc.name, len(tc.want), got)
+		}
+		for i := range got {
+			if !got[i].Equal(tc.want[i]) {
+				t.Fatalf("%s fire %d: expected %s, got %s", tc.name, i, tc.want[i], got[i])
+			}
+		}
+	}
+
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	schedule, err := Parse("0 9 * * *", kolkata)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if next := schedule.Next(time.Date(2026, 1, 1, 0, 0, 0, 0, kolkata)); !next.Equal(time.Date(2026, 1, 1, 9, 0, 0, 0, kolkata)) {
+		t.Fatalf("expected 09:00 in a half-hour offset zone, got %s", next)
+	}
+}
+
+func TestParseRejectsInvalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "0 0 * FOO *"} {
+		if _, err := Parse(expr, time.UTC); err == nil {
+			t.Fatalf("expected %q to be rejected", expr)
+		}
+	}
+	schedule, err := Parse("0 0 30 2 *", time.UTC)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if next := schedule.Next(time.Now()); !next.IsZero() {
+		t.Fatalf("expected impossible schedule to never fire, got %s", next)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	cases := map[string]string{
+		"* * * * *":       "every minute",
+		"*/5 * * * *":     "every 5 minutes",
+		"15 * * * *":      "hourly at :15",
+		"0 */6 * * *":     "every 6 hours at :00",
+		"@daily":          "daily at 00:00",
+		"30 9 * * 1-5":    "weekdays at 09:30",
+		"0 18 * * 1,3":    "on Mon, Wed at 18:00",
+		"0 3 1 * *":       "monthly on day 1 at 03:00",
+		"0 0 25 DEC *":    "yearly on Dec 25 at 00:00",
+		"0,30 9-17 * * *": "minute 0,30, hour 9-17",
+	}
+	for expr, want := range cases {
+		schedule, err := Parse(expr, time.UTC)
+		if err != nil {
+			t.Fatalf("parse %q: %v", expr, err)
+		}
+		if got := schedule.Describe(); got != want {
+			t.Fatalf("%q: expected %q, got %q", expr, want, got)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var weekdayNames = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+var monthNames = []string{"", "Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+
+// Describe renders the common cron shapes in plain English and falls back to
+// a per-field summary for anything else.
+func (s *Schedule) Describe() string {
+	minute, hour, dom, month, dow := s.fields[0], s.fields[1], s.fields[2], s.fields[3], s.fields[4]
+	minuteVal, minuteSingle := single(minute)
+	hourVal, hourSingle := single(hour)
+	allDays := dom == "*" && month == "*" && dow == "*"
+
+	switch {
+	case minute == "*" && hour == "*" && allDays:
+		return "every minute"
+	case strings.HasPrefix(minute, "*/") && hour == "*" && allDays:
+		return "every " + plural(minute[2:], "minute")
+	case minuteSingle && hour == "*" && allDays:
+		return fmt.Sprintf("hourly at :%02d", minuteVal)
+	case minuteSingle && strings.HasPrefix(hour, "*/") && allDays:
+		return fmt.Sprintf("every %s at :%02d", plural(hour[2:], "hour"), minuteVal)
+	}
+	if !minuteSingle || !hourSingle {
+		return s.describeFields()
+	}
+	at := fmt.Sprintf("at %02d:%02d", hourVal, minuteVal)
+	switch {
+	case allDays:
+		return "daily " + at
+	case dom == "*" && month == "*":
+		return fmt.Sprintf("%s %s", describeWeekdays(dow), at)
+	case month == "*" && dow == "*":
+		if day, ok := single(dom); ok {
+			return fmt.Sprintf("monthly on day %d %s", day, at)
+		}
+	case dow == "*":
+		day, dayOK := single(dom)
+		mon, monOK := s.singleMonth()
+		if dayOK && monOK {
+			return fmt.Sprintf("yearly on %s %d %s", monthNames[mon], day, at)
+		}
+	}
+	return s.describeFields()
+}
+
+func (s *Schedule) describeFields() string {
+	labels := []string{"minute", "hour", "day", "month", "weekday"}
+	parts := []string{}
+	for i, field := range s.fields {
+		if field == "*" {
+			continue
+		}
+		parts = append(parts, labels[i]+" "+field)
+	}
+	if len(parts) == 0 {
+		return "every minute"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (s *Schedule) singleMonth() (int, bool) {
+	if value, ok := single(s.fields[3]); ok {
+		return value, true
+	}
+	if value, ok := monthSpec.names[strings.ToUpper(s.fields[3])]; ok {
+		return value, true
+	}
+	return 0, false
+}
+
+func describeWeekdays(field string) string {
+	switch strings.ToUpper(field) {
+	case "1-5", "MON-FRI":
+		return "weekdays"
+	case "0,6", "6,0", "SAT,SUN", "SUN,SAT":
+		return "weekends"
+	}
+	days := []string{}
+	for _, part := range strings.Split(field, ",") {
+		bounds := strings.SplitN(part, "-", 2)
+		names := make([]string, 0, len(bounds))
+		for _, bound := range bounds {
+			value, err := parseValue(bound, dowSpec)
+			if err != nil {
+				return "weekday " + field
+			}
+			names = append(names, weekdayNames[value])
+		}
+		days = append(days, strings.Join(names, "-"))
+	}
+	return "on " + strings.Join(days, ", ")
+}
+
+func single(field string) (int, bool) {
+	value, err := strconv.Atoi(field)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+func plural(count, unit string) string {
+	if count == "1" {
+		return unit
+	}
+	return count + " " + unit + "s"
+}
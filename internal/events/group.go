@@ -0,0 +1,165 @@
+package events
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+const groupSampleSize = 5
+
+var (
+	uuidPattern   = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	hexIDPattern  = regexp.MustCompile(`(?i)\b(?:0x)?[0-9a-f]*[0-9][0-9a-f]*\b`)
+	numberPattern = regexp.MustCompile(`\d+(?:\.\d+)?`)
+)
+
+type Group struct {
+	Fingerprint string
+	Type        string
+	Queue       string
+	// Level is the most severe level seen, so a group stays visible as an
+	// error after quieter events with the same fingerprint.
+	Level     string
+	Message   string
+	Count     int
+	FirstSeen time.Time
+	LastSeen  time.Time
+	Workers   []string
+	Samples   []models.Event
+}
+
+func (g Group) Latest() models.Event {
+	if len(g.Samples) == 0 {
+		return models.Event{}
+	}
+	return g.Samples[len(g.Samples)-1]
+}
+
+func NormalizeMessage(message string) string {
+	normalized := uuidPattern.ReplaceAllString(message, "<id>")
+	normalized = hexIDPattern.ReplaceAllStringFunc(normalized, func(match string) string {
+		if len(match) >= 8 && strings.ContainsAny(strings.ToLower(match), "abcdef") {
+			return "<id>"
+		}
+		return match
+	})
+	normalized = numberPattern.ReplaceAllString(normalized, "<n>")
+	return strings.Join(strings.Fields(normalized), " ")
+}
+
+func Fingerprint(event models.Event) string {
+	return strings.Join([]string{
+		strings.ToLower(strings.TrimSpace(event.Type)),
+		strings.TrimSpace(event.Queue),
+		NormalizeMessage(event.Message),
+	}, "|")
+}
+
+type GroupBuffer struct {
+	groups map[string]*Group
+	size   int
+}
+
+func NewGroupBuffer(size int) *GroupBuffer {
+	if size < 1 {
+		size = 1
+	}
+	return &GroupBuffer{
+		groups: make(map[string]*Group, size),
+		size:   size,
+	}
+}
+
+func (b *GroupBuffer) Add(event models.Event) {
+	key := Fingerprint(event)
+	seen := event.Timestamp
+	if seen.IsZero() {
+		seen = time.Now()
+	}
+	group, ok := b.groups[key]
+	if !ok {
+		if len(b.groups) >= b.size {
+			b.evictOldest()
+		}
+		group = &Group{
+			Fingerprint: key,
+			Type:        event.Type,
+			Queue:       event.Queue,
+			Message:     NormalizeMessage(event.Message),
+			FirstSeen:   seen,
+		}
+		b.groups[key] = group
+	}
+	group.Count++
+	if group.Level == "" || LevelRank(event.Level) > LevelRank(group.Level) {
+		group.Level = event.Level
+	}
+	if seen.After(group.LastSeen) {
+		group.LastSeen = seen
+	}
+	if seen.Before(group.FirstSeen) {
+		group.FirstSeen = seen
+	}
+	if worker := strings.TrimSpace(event.WorkerID); worker != "" && !containsString(group.Workers, worker) {
+		group.Workers = append(group.Workers, worker)
+		sort.Strings(group.Workers)
+	}
+	if len(group.Samples) >= groupSampleSize {
+		copy(group.Samples, group.Samples[1:])
+		group.Samples[len(group.Samples)-1] = event
+	} else {
+		group.Samples = append(group.Samples, event)
+	}
+}
+
+func (b *GroupBuffer) Groups() []Group {
+	out := make([]Group, 0, len(b.groups))
+	for _, group := range b.groups {
+		copied := *group
+		copied.Workers = append([]string(nil), group.Workers...)
+		copied.Samples = append([]models.Event(nil), group.Samples...)
+		out = append(out, copied)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].LastSeen.Equal(out[j].LastSeen) {
+			return out[i].Fingerprint < out[j].Fingerprint
+		}
+		return out[i].LastSeen.Before(out[j].LastSeen)
+	})
+	return out
+}
+
+func (b *GroupBuffer) Len() int {
+	return len(b.groups)
+}
+
+func (b *GroupBuffer) Clear() {
+	b.groups = make(map[string]*Group, b.size)
+}
+
+func (b *GroupBuffer) evictOldest() {
+	oldestKey := ""
+	var oldest time.Time
+	for key, group := range b.groups {
+		if oldestKey == "" || group.LastSeen.Before(oldest) {
+			oldestKey = key
+			oldest = group.LastSeen
+		}
+	}
+	if oldestKey != "" {
+		delete(b.groups, oldestKey)
+	}
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
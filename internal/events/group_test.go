@@ -0,0 +1,74 @@
+package events
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+func TestNormalizeMessageStripsIDs(t *testing.T) {
+	got := NormalizeMessage("task 4211 failed: job 3f2a9c1e-1b2c-4d5e-8f90-0123456789ab timed out after 30.5s (ref deadbeef42)")
+	want := "task <n> failed: job <id> timed out after <n>s (ref <id>)"
+	if got != want {
+		t.Fatalf("unexpected normalized message:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestGroupBufferCollapsesDuplicates(t *testing.T) {
+	buf := NewGroupBuffer(3)
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 50; i++ {
+		buf.Add(models.Event{
+			Timestamp: base.Add(time.Duration(i) * time.Second),
+			Level:     "error",
+			Type:      "task_failed",
+			Queue:     "default",
+			Message:   fmt.Sprintf("task %d failed", i),
+			WorkerID:  fmt.Sprintf("w%d", i%2),
+		})
+	}
+	buf.Add(models.Event{Timestamp: base, Type: "task_completed", Message: "ok"})
+
+	groups := buf.Groups()
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(groups))
+	}
+	flood := groups[1]
+	if flood.Count != 50 {
+		t.Fatalf("expected 50 events in group, got %d", flood.Count)
+	}
+	if !flood.FirstSeen.Equal(base) || !flood.LastSeen.Equal(base.Add(49*time.Second)) {
+		t.Fatalf("unexpected first/last seen: %v %v", flood.FirstSeen, flood.LastSeen)
+	}
+	if len(flood.Workers) != 2 || flood.Workers[0] != "w0" || flood.Workers[1] != "w1" {
+		t.Fatalf("unexpected workers: %v", flood.Workers)
+	}
+	if len(flood.Samples) != groupSampleSize || flood.Latest().Message != "task 49 failed" {
+		t.Fatalf("unexpected samples: %+v", flood.Samples)
+	}
+
+	buf.Add(models.Event{Timestamp: base.Add(time.Minute), Level: "info", Type: "task_failed", Queue: "default", Message: "task 50 failed"})
+	if flood := buf.Groups()[1]; flood.Level != "error" {
+		t.Fatalf("expected the group to keep its worst level, got %q", flood.Level)
+	}
+}
+
+func TestGroupBufferEvictsLeastRecentlySeen(t *testing.T) {
+	buf := NewGroupBuffer(2)
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	buf.Add(models.Event{Timestamp: base, Type: "a"})
+	buf.Add(models.Event{Timestamp: base.Add(time.Second), Type: "b"})
+	buf.Add(models.Event{Timestamp: base.Add(2 * time.Second), Type: "a"})
+	buf.Add(models.Event{Timestamp: base.Add(3 * time.Second), Type: "c"})
+
+	groups := buf.Groups()
+	if len(groups) != 2 || groups[0].Type != "a" || groups[1].Type != "c" {
+		t.Fatalf("expected groups a and c, got %+v", groups)
+	}
+	buf.Clear()
+	if buf.Len() != 0 {
+		t.Fatalf("expected empty buffer after clear")
+	}
+}
@@ -0,0 +1,303 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+const (
+	historyMaxBytes     = 32 << 20
+	historyDefaultLimit = 200
+	historyQueueSize    = 1024
+)
+
+// ErrHistoryBehind is returned by Append when the writer has fallen too far
+// behind and the event was dropped rather than blocking the caller.
+var ErrHistoryBehind = errors.New("event history writer is behind; event dropped")
+
+// History is an append-only JSONL log of events. Appends are handed to a
+// writer goroutine that owns the file and compacts it when it grows past
+// historyMaxBytes; Search reads the file through its own descriptor and
+// never waits on the writer. Compaction replaces the file with a rename, so
+// a search in progress keeps reading the old copy.
+type History struct {
+	path   string
+	maxAge time.Duration
+	now    func() time.Time
+
+	// file and size belong to the writer goroutine once it has started.
+	file *os.File
+	size int64
+
+	queue chan []byte
+	flush chan chan struct{}
+	done  chan struct{}
+
+	// mu guards closed and sends on queue; errMu guards err.
+	mu     sync.Mutex
+	closed bool
+	errMu  sync.Mutex
+	err    error
+}
+
+func OpenHistory(path string, maxAge time.Duration) (*History, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	h := &History{path: path, maxAge: maxAge, now: time.Now}
+	if err := h.prune(); err != nil {
+		return nil, err
+	}
+	if err := h.open(); err != nil {
+		return nil, err
+	}
+	h.queue = make(chan []byte, historyQueueSize)
+	h.flush = make(chan chan struct{})
+	h.done = make(chan struct{})
+	go h.run()
+	return h, nil
+}
+
+func (h *History) Path() string {
+	return h.path
+}
+
+// Append queues event for the writer and never blocks. It reports the last
+// write error, if any, so callers can tell the history has stopped growing.
+func (h *History) Append(event models.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return os.ErrClosed
+	}
+	select {
+	case h.queue <- data:
+	default:
+		return ErrHistoryBehind
+	}
+	return h.lastErr()
+}
+
+// Flush waits until every event queued before the call has been written.
+// It goes through its own channel and never holds mu, so Append does not
+// wait behind a flush while the queue is full.
+func (h *History) Flush() {
+	flushed := make(chan struct{})
+	select {
+	case h.flush <- flushed:
+		<-flushed
+	case <-h.done:
+	}
+}
+
+func (h *History) run() {
+	defer close(h.done)
+	for {
+		select {
+		case data, ok := <-h.queue:
+			if !ok {
+				h.closeFile()
+				return
+			}
+			h.setErr(h.write(data))
+		case flushed := <-h.flush:
+			// Everything queued before the flush is already buffered.
+			for pending := len(h.queue); pending > 0; pending-- {
+				data, ok := <-h.queue
+				if !ok {
+					break
+				}
+				h.setErr(h.write(data))
+			}
+			close(flushed)
+		}
+	}
+}
+
+func (h *History) closeFile() {
+	if h.file != nil {
+		if err := h.file.Close(); err != nil {
+			h.setErr(err)
+		}
+		h.file = nil
+	}
+}
+
+func (h *History) write(data []byte) error {
+	if h.file == nil {
+		if err := h.open(); err != nil {
+			return err
+		}
+	}
+	n, err := h.file.Write(data)
+	h.size += int64(n)
+	if err != nil {
+		return err
+	}
+	if h.size > historyMaxBytes {
+		h.file.Close()
+		h.file = nil
+		if err := h.prune(); err != nil {
+			return err
+		}
+		return h.open()
+	}
+	return nil
+}
+
+func (h *History) setErr(err error) {
+	h.errMu.Lock()
+	h.err = err
+	h.errMu.Unlock()
+}
+
+func (h *History) lastErr() error {
+	h.errMu.Lock()
+	defer h.errMu.Unlock()
+	return h.err
+}
+
+func (h *History) Search(match func(models.Event) bool, limit int) ([]models.Event, error) {
+	if limit <= 0 {
+		limit = historyDefaultLimit
+	}
+	file, err := os.Open(h.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+	cutoff := h.cutoff()
+	out := make([]models.Event, 0, limit)
+	err = scanHistory(file, func(event models.Event) {
+		if !cutoff.IsZero() && event.Timestamp.Before(cutoff) {
+			return
+		}
+		if match != nil && !match(event) {
+			return
+		}
+		if len(out) == limit {
+			copy(out, out[1:])
+			out[len(out)-1] = event
+			return
+		}
+		out = append(out, event)
+	})
+	return out, err
+}
+
+// Close stops accepting events, waits for queued ones to be written and
+// closes the file.
+func (h *History) Close() error {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return nil
+	}
+	h.closed = true
+	close(h.queue)
+	h.mu.Unlock()
+	<-h.done
+	return h.lastErr()
+}
+
+func (h *History) open() error {
+	file, err := os.OpenFile(h.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	h.file = file
+	h.size = info.Size()
+	return nil
+}
+
+func (h *History) cutoff() time.Time {
+	if h.maxAge <= 0 {
+		return time.Time{}
+	}
+	return h.now().Add(-h.maxAge)
+}
+
+func (h *History) prune() error {
+	data, err := os.ReadFile(h.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	cutoff := h.cutoff()
+	var kept [][]byte
+	var keptBytes int64
+	err = scanHistoryLines(bytes.NewReader(data), func(line []byte, event models.Event) {
+		if !cutoff.IsZero() && event.Timestamp.Before(cutoff) {
+			return
+		}
+		kept = append(kept, line)
+		keptBytes += int64(len(line)) + 1
+	})
+	if err != nil {
+		return err
+	}
+	for len(kept) > 0 && keptBytes > historyMaxBytes/2 {
+		keptBytes -= int64(len(kept[0])) + 1
+		kept = kept[1:]
+	}
+	if keptBytes == int64(len(data)) {
+		return nil
+	}
+	var buf bytes.Buffer
+	for _, line := range kept {
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	tmp := h.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, h.path)
+}
+
+func scanHistory(reader io.Reader, fn func(models.Event)) error {
+	return scanHistoryLines(reader, func(_ []byte, event models.Event) {
+		fn(event)
+	})
+}
+
+func scanHistoryLines(reader io.Reader, fn func([]byte, models.Event)) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var event models.Event
+		if err := json.Unmarshal(line, &event); err != nil {
+			continue
+		}
+		fn(append([]byte(nil), line...), event)
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,120 @@
+package events
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+func TestHistoryAppendSearchAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history", "events.jsonl")
+	history, err := OpenHistory(path, time.Hour)
+	if err != nil {
+		t.Fatalf("open history: %v", err)
+	}
+	now := time.Now()
+	for i, queue := range []string{"default", "emails", "default", "default"} {
+		event := models.Event{Timestamp: now.Add(time.Duration(i) * time.Second), Level: "info", Queue: queue, Message: "event"}
+		if err := history.Append(event); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+	if err := history.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	reopened, err := OpenHistory(path, time.Hour)
+	if err != nil {
+		t.Fatalf("reopen history: %v", err)
+	}
+	defer reopened.Close()
+	found, err := reopened.Search(func(event models.Event) bool { return event.Queue == "default" }, 2)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(found) != 2 {
+		t.Fatalf("expected 2 newest matches, got %d", len(found))
+	}
+	if !found[0].Timestamp.Equal(now.Add(2*time.Second)) || !found[1].Timestamp.Equal(now.Add(3*time.Second)) {
+		t.Fatalf("expected newest matches in order, got %+v", found)
+	}
+}
+
+func TestHistoryRetentionPrunesOnOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	old := `{"ts":"2020-01-01T00:00:00Z","level":"info","type":"old","msg":"stale"}`
+	fresh := `{"ts":"` + time.Now().UTC().Format(time.RFC3339) + `","level":"info","type":"fresh","msg":"recent"}`
+	if err := os.WriteFile(path, []byte(old+"\nnot json\n"+fresh+"\n"), 0o600); err != nil {
+		t.Fatalf("write history: %v", err)
+	}
+	history, err := OpenHistory(path, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("open history: %v", err)
+	}
+	defer history.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read history: %v", err)
+	}
+	if strings.Contains(string(data), "stale") || strings.Contains(string(data), "not json") {
+		t.Fatalf("expected expired and invalid lines to be pruned, got %q", data)
+	}
+	found, err := history.Search(nil, 0)
+	if err != nil || len(found) != 1 || found[0].Type != "fresh" {
+		t.Fatalf("expected only fresh event, got %+v (%v)", found, err)
+	}
+}
+
+func TestHistorySearchDoesNotWaitOnWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	history, err := OpenHistory(path, time.Hour)
+	if err != nil {
+		t.Fatalf("open history: %v", err)
+	}
+	now := time.Now()
+	if err := history.Append(models.Event{Timestamp: now, Level: "info", Message: "first"}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	history.Flush()
+	if err := history.Append(models.Event{Timestamp: now.Add(time.Second), Level: "info", Message: "second"}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	// Flushing and searching must not need the lock appends take.
+	history.mu.Lock()
+	done := make(chan []models.Event, 1)
+	go func() {
+		history.Flush()
+		found, _ := history.Search(nil, 0)
+		done <- found
+	}()
+	select {
+	case found := <-done:
+		if len(found) != 2 || found[0].Message != "first" || found[1].Message != "second" {
+			t.Fatalf("expected written event, got %+v", found)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("search blocked on the writer")
+	}
+	history.mu.Unlock()
+
+	if err := history.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read history: %v", err)
+	}
+	if !strings.Contains(string(data), "second") {
+		t.Fatalf("expected close to flush queued events, got %q", data)
+	}
+	if err := history.Append(models.Event{Timestamp: now, Message: "late"}); err == nil {
+		t.Fatalf("expected append after close to fail")
+	}
+	history.Flush()
+}
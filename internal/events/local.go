@@ -0,0 +1,161 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+const (
+	StdinSource = "-"
+
+	tailPollInterval = 250 * time.Millisecond
+	tailBacklog      = 64 * 1024
+)
+
+var stdinReader io.Reader = os.Stdin
+
+func IsStdinSource(raw string) bool {
+	return strings.TrimSpace(raw) == StdinSource
+}
+
+// IsLocalSource reports whether raw is read locally rather than over the
+// network: stdin ("-"), a file:// URL, or an absolute path. Anything else is
+// treated as a URL, so a mistyped host is reported instead of tailed as a
+// file.
+func IsLocalSource(raw string) bool {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return false
+	}
+	if raw == StdinSource || strings.HasPrefix(strings.ToLower(raw), "file://") {
+		return true
+	}
+	return filepath.IsAbs(raw)
+}
+
+func localPath(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if !strings.HasPrefix(strings.ToLower(raw), "file:") {
+		return raw
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return strings.TrimPrefix(raw[len("file:"):], "//")
+	}
+	if parsed.Path != "" {
+		return parsed.Path
+	}
+	return parsed.Opaque
+}
+
+func connectLocal(ctx context.Context, raw string, schema Schema, out chan<- models.Event) error {
+	if IsStdinSource(raw) {
+		return readStdin(ctx, stdinReader, schema, out)
+	}
+	return tailFile(ctx, localPath(raw), schema, out, tailPollInterval)
+}
+
+func readStdin(ctx context.Context, in io.Reader, schema Schema, out chan<- models.Event) error {
+	err := readNDJSON(ctx, in, schema, out)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func tailFile(ctx context.Context, path string, schema Schema, out chan<- models.Event, poll time.Duration) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { file.Close() }()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	offset := int64(0)
+	skipPartial := false
+	if info.Size() > tailBacklog {
+		offset = info.Size() - tailBacklog
+		skipPartial = true
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	reader := bufio.NewReader(file)
+	var pending string
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+	readAvailable := func() {
+		for {
+			chunk, err := reader.ReadString('\n')
+			offset += int64(len(chunk))
+			pending += chunk
+			if err != nil {
+				return
+			}
+			line := pending
+			pending = ""
+			if skipPartial {
+				skipPartial = false
+				continue
+			}
+			emitLines(line, schema, out)
+		}
+	}
+	for {
+		readAvailable()
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		current, err := os.Stat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		opened, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		if !os.SameFile(current, opened) {
+			next, err := os.Open(path)
+			if err != nil {
+				continue
+			}
+			readAvailable()
+			if pending != "" {
+				emitLines(pending, schema, out)
+			}
+			file.Close()
+			file = next
+			offset = 0
+			pending = ""
+			skipPartial = false
+			reader.Reset(file)
+			continue
+		}
+		if current.Size() < offset {
+			if _, err := file.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			offset = 0
+			pending = ""
+			skipPartial = false
+			reader.Reset(file)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package events
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+func TestIsLocalSource(t *testing.T) {
+	cases := map[string]bool{
+		"-":                            true,
+		"/var/log/reproq.jsonl":        true,
+		"logs/events.jsonl":            false,
+		"localhost:9100/events":        false,
+		"file:///tmp/events.jsonl":     true,
+		"http://localhost:9100/events": false,
+		"wss://example.com/events":     false,
+		"":                             false,
+	}
+	for input, want := range cases {
+		if got := IsLocalSource(input); got != want {
+			t.Fatalf("IsLocalSource(%q) = %v, want %v", input, got, want)
+		}
+	}
+	if got := localPath("file:///tmp/events.jsonl"); got != "/tmp/events.jsonl" {
+		t.Fatalf("unexpected file path: %q", got)
+	}
+}
+
+func TestReadStdinParsesLinesAndWaits(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	out := make(chan models.Event, 4)
+	done := make(chan error, 1)
+	input := strings.NewReader("{\"msg\":\"one\"}\nnot json\n{\"msg\":\"two\"}")
+	go func() { done <- readStdin(ctx, input, DefaultSchema(), out) }()
+
+	expectMessages(t, out, "one", "two")
+	select {
+	case <-done:
+		t.Fatal("stdin reader returned before cancellation")
+	case <-time.After(20 * time.Millisecond):
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTailFileFollowsTruncationAndRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.jsonl")
+	writeFile(t, path, "{\"msg\":\"old\"}\n", os.O_CREATE|os.O_WRONLY|os.O_TRUNC)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := make(chan models.Event, 8)
+	done := make(chan error, 1)
+	go func() { done <- tailFile(ctx, path, DefaultSchema(), out, 5*time.Millisecond) }()
+	expectMessages(t, out, "old")
+
+	writeFile(t, path, "{\"msg\":\"appended\"}\n{\"msg\":", os.O_APPEND|os.O_WRONLY)
+	expectMessages(t, out, "appended")
+	writeFile(t, path, "\"split\"}\n", os.O_APPEND|os.O_WRONLY)
+	expectMessages(t, out, "split")
+
+	writeFile(t, path, "{\"msg\":\"truncated\"}\n", os.O_WRONLY|os.O_TRUNC)
+	expectMessages(t, out, "truncated")
+
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	writeFile(t, path, "{\"msg\":\"rotated\"}\n", os.O_CREATE|os.O_WRONLY|os.O_TRUNC)
+	expectMessages(t, out, "rotated")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTailFileMissing(t *testing.T) {
+	err := tailFile(context.Background(), filepath.Join(t.TempDir(), "missing.jsonl"), DefaultSchema(), make(chan models.Event), time.Millisecond)
+	if !os.IsNotExist(err) {
+		t.Fatalf("expected not-exist error, got %v", err)
+	}
+}
+
+func writeFile(t *testing.T, path, content string, flag int) {
+	t.Helper()
+	file, err := os.OpenFile(path, flag, 0o644)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(content); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+func expectMessages(t *testing.T, out <-chan models.Event, messages ...string) {
+	t.Helper()
+	for _, want := range messages {
+		select {
+		case event := <-out:
+			if event.Message != want {
+				t.Fatalf("expected %q, got %q", want, event.Message)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for %q", want)
+		}
+	}
+}
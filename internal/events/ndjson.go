@@ -0,0 +1,52 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+const maxLineSize = 1 << 20
+
+func isNDJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+	switch mediaType {
+	case "application/x-ndjson", "application/ndjson", "application/jsonl", "application/x-jsonlines", "application/json-seq":
+		return true
+	}
+	return false
+}
+
+func readNDJSON(ctx context.Context, body io.Reader, schema Schema, out chan<- models.Event) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return nil
+		}
+		emitLines(scanner.Text(), schema, out)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return io.EOF
+}
+
+func emitLines(payload string, schema Schema, out chan<- models.Event) {
+	for _, line := range strings.Split(payload, "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(line, "\x1e"))
+		if line == "" {
+			continue
+		}
+		if event, ok := schema.Parse(line); ok {
+			out <- event
+		}
+	}
+}
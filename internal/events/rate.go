@@ -0,0 +1,117 @@
+package events
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/metrics"
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+const (
+	RateAll = "all"
+
+	maxRateKeys = 64
+)
+
+func RateKeyType(eventType string) string {
+	return "type:" + strings.ToLower(strings.TrimSpace(eventType))
+}
+
+func RateKeyLevel(level string) string {
+	return "level:" + strings.ToLower(strings.TrimSpace(level))
+}
+
+type RateTracker struct {
+	capacity  int
+	counts    map[string]int
+	series    map[string]*metrics.RingBuffer
+	lastFlush time.Time
+}
+
+func NewRateTracker(capacity int) *RateTracker {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &RateTracker{
+		capacity: capacity,
+		counts:   map[string]int{},
+		series:   seedRateSeries(capacity),
+	}
+}
+
+func seedRateSeries(capacity int) map[string]*metrics.RingBuffer {
+	return map[string]*metrics.RingBuffer{
+		RateAll:                    metrics.NewRingBuffer(capacity),
+		RateKeyType("task_failed"): metrics.NewRingBuffer(capacity),
+		RateKeyLevel("error"):      metrics.NewRingBuffer(capacity),
+	}
+}
+
+func (r *RateTracker) Observe(event models.Event) {
+	r.counts[RateAll]++
+	if strings.TrimSpace(event.Type) != "" {
+		r.count(RateKeyType(event.Type))
+	}
+	if strings.TrimSpace(event.Level) != "" {
+		r.count(RateKeyLevel(event.Level))
+	}
+}
+
+func (r *RateTracker) count(key string) {
+	if _, ok := r.series[key]; !ok {
+		if len(r.series) >= maxRateKeys {
+			return
+		}
+		r.series[key] = metrics.NewRingBuffer(r.capacity)
+	}
+	r.counts[key]++
+}
+
+func (r *RateTracker) Flush(now time.Time) {
+	if r.lastFlush.IsZero() || !now.After(r.lastFlush) {
+		r.lastFlush = now
+		return
+	}
+	elapsed := now.Sub(r.lastFlush).Seconds()
+	r.lastFlush = now
+	for key, buf := range r.series {
+		buf.Add(models.Sample{Timestamp: now, Value: float64(r.counts[key]) / elapsed})
+	}
+	r.counts = map[string]int{}
+}
+
+func (r *RateTracker) Series(key string, cutoff time.Time) []models.Sample {
+	buf, ok := r.series[key]
+	if !ok {
+		return nil
+	}
+	return buf.ValuesSince(cutoff)
+}
+
+func (r *RateTracker) Latest(key string) (float64, bool) {
+	buf, ok := r.series[key]
+	if !ok {
+		return 0, false
+	}
+	sample, ok := buf.Latest()
+	return sample.Value, ok
+}
+
+func (r *RateTracker) Keys(prefix string) []string {
+	keys := make([]string, 0, len(r.series))
+	for key := range r.series {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (r *RateTracker) Clear() {
+	r.counts = map[string]int{}
+	r.series = seedRateSeries(r.capacity)
+	r.lastFlush = time.Time{}
+}
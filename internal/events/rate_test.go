@@ -0,0 +1,54 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+func TestRateTrackerFlushComputesPerSecondRates(t *testing.T) {
+	tracker := NewRateTracker(10)
+	base := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	tracker.Flush(base)
+
+	for i := 0; i < 4; i++ {
+		tracker.Observe(models.Event{Type: "task_failed", Level: "ERROR"})
+	}
+	tracker.Observe(models.Event{Type: "task_completed", Level: "info"})
+	tracker.Flush(base.Add(2 * time.Second))
+
+	if got, _ := tracker.Latest(RateAll); got != 2.5 {
+		t.Fatalf("expected 2.5 events/sec, got %v", got)
+	}
+	if got, _ := tracker.Latest(RateKeyType("task_failed")); got != 2 {
+		t.Fatalf("expected 2 failures/sec, got %v", got)
+	}
+	if got, _ := tracker.Latest(RateKeyLevel("error")); got != 2 {
+		t.Fatalf("expected level keys to be case-insensitive, got %v", got)
+	}
+
+	tracker.Flush(base.Add(3 * time.Second))
+	samples := tracker.Series(RateKeyType("task_completed"), time.Time{})
+	if len(samples) != 2 || samples[0].Value != 0.5 || samples[1].Value != 0 {
+		t.Fatalf("expected idle interval to record zero, got %+v", samples)
+	}
+	if keys := tracker.Keys("type:"); len(keys) != 2 || keys[0] != "type:task_completed" {
+		t.Fatalf("unexpected type keys: %v", keys)
+	}
+
+	tracker.Clear()
+	if _, ok := tracker.Latest(RateAll); ok {
+		t.Fatalf("expected cleared tracker to have no samples")
+	}
+}
+
+func TestRateTrackerCapsKeys(t *testing.T) {
+	tracker := NewRateTracker(2)
+	for i := 0; i < maxRateKeys*2; i++ {
+		tracker.Observe(models.Event{Type: time.Duration(i).String()})
+	}
+	if got := len(tracker.Keys("")); got > maxRateKeys {
+		t.Fatalf("expected at most %d keys, got %d", maxRateKeys, got)
+	}
+}
@@ -0,0 +1,137 @@
+package events
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+const (
+	FieldTimestamp = "ts"
+	FieldLevel     = "level"
+	FieldType      = "type"
+	FieldMessage   = "msg"
+	FieldQueue     = "queue"
+	FieldTaskID    = "task_id"
+	FieldWorkerID  = "worker_id"
+	FieldMetadata  = "metadata"
+)
+
+type Schema struct {
+	Fields       map[string]string
+	LevelAliases map[string]string
+}
+
+func DefaultSchema() Schema {
+	return Schema{
+		Fields: map[string]string{
+			FieldTimestamp: "ts",
+			FieldLevel:     "level",
+			FieldType:      "type",
+			FieldMessage:   "msg",
+			FieldQueue:     "queue",
+			FieldTaskID:    "task_id",
+			FieldWorkerID:  "worker_id",
+			FieldMetadata:  "metadata",
+		},
+		LevelAliases: map[string]string{},
+	}
+}
+
+func NewSchema(fields map[string]string, levelAliases map[string]string) Schema {
+	schema := DefaultSchema()
+	for key, path := range fields {
+		key = strings.ToLower(strings.TrimSpace(key))
+		path = strings.TrimSpace(path)
+		if _, ok := schema.Fields[key]; ok && path != "" {
+			schema.Fields[key] = path
+		}
+	}
+	for raw, level := range levelAliases {
+		raw = strings.ToLower(strings.TrimSpace(raw))
+		level = strings.TrimSpace(level)
+		if raw != "" && level != "" {
+			schema.LevelAliases[raw] = level
+		}
+	}
+	return schema
+}
+
+func (s Schema) Parse(payload string) (models.Event, bool) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(payload), &raw); err != nil {
+		return models.Event{}, false
+	}
+	event := models.Event{
+		Timestamp: time.Now(),
+		Level:     s.level(toString(s.lookup(raw, FieldLevel))),
+		Type:      toString(s.lookup(raw, FieldType)),
+		Message:   toString(s.lookup(raw, FieldMessage)),
+		Queue:     toString(s.lookup(raw, FieldQueue)),
+		TaskID:    toString(s.lookup(raw, FieldTaskID)),
+		WorkerID:  toString(s.lookup(raw, FieldWorkerID)),
+		Metadata:  map[string]string{},
+	}
+	if ts := s.lookup(raw, FieldTimestamp); ts != nil {
+		if parsed, ok := parseTime(ts); ok {
+			event.Timestamp = parsed
+		}
+	}
+	if meta, ok := s.lookup(raw, FieldMetadata).(map[string]interface{}); ok {
+		for key, val := range meta {
+			event.Metadata[key] = toString(val)
+		}
+	}
+	return event, true
+}
+
+func (s Schema) lookup(raw map[string]interface{}, field string) interface{} {
+	path := s.Fields[field]
+	if path == "" {
+		return nil
+	}
+	return lookupPath(raw, path)
+}
+
+func (s Schema) level(raw string) string {
+	if alias, ok := s.LevelAliases[strings.ToLower(strings.TrimSpace(raw))]; ok {
+		return alias
+	}
+	return raw
+}
+
+// LevelRank orders severities from trace and debug (0) up to critical (4);
+// an unknown level ranks -1.
+func LevelRank(level string) int {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "trace", "debug":
+		return 0
+	case "info", "notice":
+		return 1
+	case "warn", "warning":
+		return 2
+	case "error", "err":
+		return 3
+	case "critical", "fatal", "panic":
+		return 4
+	default:
+		return -1
+	}
+}
+
+func lookupPath(raw map[string]interface{}, path string) interface{} {
+	if val, ok := raw[path]; ok {
+		return val
+	}
+	head, rest, found := strings.Cut(path, ".")
+	if !found {
+		return nil
+	}
+	nested, ok := raw[head].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return lookupPath(nested, rest)
+}
@@ -0,0 +1,51 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchemaParseNestedFieldsAndAliases(t *testing.T) {
+	schema := NewSchema(map[string]string{
+		"ts":        "timestamp",
+		"level":     "severity",
+		"msg":       "message",
+		"task_id":   "task.id",
+		"worker_id": "worker.id",
+		"metadata":  "extra",
+	}, map[string]string{"50": "error", "WARNING": "warn"})
+
+	payload := `{"timestamp":1700000000000,"severity":50,"type":"task_failed","message":"boom","queue":"default","task":{"id":"42"},"worker":{"id":"w1"},"extra":{"attempt":"2"}}`
+	event, ok := schema.Parse(payload)
+	if !ok {
+		t.Fatalf("expected payload to parse")
+	}
+	if event.Level != "error" || event.Message != "boom" || event.Type != "task_failed" {
+		t.Fatalf("unexpected event fields: %+v", event)
+	}
+	if event.TaskID != "42" || event.WorkerID != "w1" || event.Queue != "default" {
+		t.Fatalf("unexpected event ids: %+v", event)
+	}
+	if event.Metadata["attempt"] != "2" {
+		t.Fatalf("unexpected metadata: %+v", event.Metadata)
+	}
+	if !event.Timestamp.Equal(time.Unix(1700000000, 0)) {
+		t.Fatalf("expected millisecond timestamp, got %v", event.Timestamp)
+	}
+
+	event, _ = schema.Parse(`{"severity":"warning","message":"slow"}`)
+	if event.Level != "warn" {
+		t.Fatalf("expected case-insensitive alias, got %q", event.Level)
+	}
+}
+
+func TestSchemaPrefersLiteralDottedKey(t *testing.T) {
+	schema := NewSchema(map[string]string{"task_id": "task.id"}, nil)
+	event, ok := schema.Parse(`{"task.id":"flat","task":{"id":"nested"}}`)
+	if !ok {
+		t.Fatalf("expected payload to parse")
+	}
+	if event.TaskID != "flat" {
+		t.Fatalf("expected literal key to win, got %q", event.TaskID)
+	}
+}
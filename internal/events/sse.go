@@ -3,8 +3,8 @@ package events
 import (
 	"bufio"
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"math/rand"
 	"net/http"
 	"strings"
@@ -25,10 +25,11 @@ type listenOptions struct {
 	jitter  func(time.Duration) time.Duration
 	sleep   func(context.Context, time.Duration) bool
 	connect func(context.Context, *client.Client, string, chan<- models.Event) error
+	schema  Schema
 }
 
-func Listen(ctx context.Context, httpClient *client.Client, url string, out chan<- models.Event) {
-	listenWithOptions(ctx, httpClient, url, out, listenOptions{})
+func Listen(ctx context.Context, httpClient *client.Client, url string, schema Schema, out chan<- models.Event) {
+	listenWithOptions(ctx, httpClient, url, out, listenOptions{schema: schema})
 }
 
 func listenWithOptions(ctx context.Context, httpClient *client.Client, url string, out chan<- models.Event, opts listenOptions) {
@@ -53,9 +54,15 @@ func listenWithOptions(ctx context.Context, httpClient *client.Client, url strin
 	if sleep == nil {
 		sleep = defaultSleep
 	}
+	schema := opts.schema
+	if schema.Fields == nil {
+		schema = DefaultSchema()
+	}
 	connectFn := opts.connect
 	if connectFn == nil {
-		connectFn = connect
+		connectFn = func(ctx context.Context, httpClient *client.Client, url string, out chan<- models.Event) error {
+			return connect(ctx, httpClient, url, schema, out)
+		}
 	}
 
 	backoff := min
@@ -78,13 +85,19 @@ func listenWithOptions(ctx context.Context, httpClient *client.Client, url strin
 	}
 }
 
-func connect(ctx context.Context, httpClient *client.Client, url string, out chan<- models.Event) error {
+func connect(ctx context.Context, httpClient *client.Client, url string, schema Schema, out chan<- models.Event) error {
+	if IsLocalSource(url) {
+		return connectLocal(ctx, url, schema, out)
+	}
+	if isWebSocketURL(url) {
+		return connectWebSocket(ctx, httpClient, url, schema, out)
+	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Accept", "text/event-stream")
-	resp, err := httpClient.Do(req)
+	req.Header.Set("Accept", "text/event-stream, application/x-ndjson;q=0.9")
+	resp, err := httpClient.DoStream(req)
 	if err != nil {
 		return err
 	}
@@ -92,8 +105,14 @@ func connect(ctx context.Context, httpClient *client.Client, url string, out cha
 	if resp.StatusCode != http.StatusOK {
 		return client.StatusError{URL: url, Code: resp.StatusCode}
 	}
+	if isNDJSONContentType(resp.Header.Get("Content-Type")) {
+		return readNDJSON(ctx, resp.Body, schema, out)
+	}
+	return readSSE(ctx, resp.Body, schema, out)
+}
 
-	reader := bufio.NewReader(resp.Body)
+func readSSE(ctx context.Context, body io.Reader, schema Schema, out chan<- models.Event) error {
+	reader := bufio.NewReader(body)
 	var dataLines []string
 	for {
 		if ctx.Err() != nil {
@@ -107,7 +126,7 @@ func connect(ctx context.Context, httpClient *client.Client, url string, out cha
 			}
 			if len(dataLines) > 0 {
 				payload := strings.Join(dataLines, "\n")
-				if event, ok := parseEvent(payload); ok {
+				if event, ok := schema.Parse(payload); ok {
 					out <- event
 				}
 			}
@@ -117,7 +136,7 @@ func connect(ctx context.Context, httpClient *client.Client, url string, out cha
 		if line == "" {
 			if len(dataLines) > 0 {
 				payload := strings.Join(dataLines, "\n")
-				if event, ok := parseEvent(payload); ok {
+				if event, ok := schema.Parse(payload); ok {
 					out <- event
 				}
 			}
@@ -148,31 +167,7 @@ func defaultSleep(ctx context.Context, d time.Duration) bool {
 }
 
 func parseEvent(payload string) (models.Event, bool) {
-	var raw map[string]interface{}
-	if err := json.Unmarshal([]byte(payload), &raw); err != nil {
-		return models.Event{}, false
-	}
-	event := models.Event{
-		Timestamp: time.Now(),
-		Level:     toString(raw["level"]),
-		Type:      toString(raw["type"]),
-		Message:   toString(raw["msg"]),
-		Queue:     toString(raw["queue"]),
-		TaskID:    toString(raw["task_id"]),
-		WorkerID:  toString(raw["worker_id"]),
-		Metadata:  map[string]string{},
-	}
-	if ts, ok := raw["ts"]; ok {
-		if parsed, ok := parseTime(ts); ok {
-			event.Timestamp = parsed
-		}
-	}
-	if meta, ok := raw["metadata"].(map[string]interface{}); ok {
-		for key, val := range meta {
-			event.Metadata[key] = toString(val)
-		}
-	}
-	return event, true
+	return DefaultSchema().Parse(payload)
 }
 
 func toString(val interface{}) string {
@@ -198,6 +193,9 @@ func parseTime(val interface{}) (time.Time, bool) {
 			return parsed, true
 		}
 	case float64:
+		if t > 1e12 {
+			t /= 1000
+		}
 		sec := int64(t)
 		nsec := int64((t - float64(sec)) * float64(time.Second))
 		return time.Unix(sec, nsec), true
@@ -60,7 +60,7 @@ func TestConnectStatusError(t *testing.T) {
 	defer server.Close()
 
 	httpClient := client.New(client.Options{Timeout: 2 * time.Second})
-	err := connect(context.Background(), httpClient, server.URL, make(chan models.Event, 1))
+	err := connect(context.Background(), httpClient, server.URL, DefaultSchema(), make(chan models.Event, 1))
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -0,0 +1,155 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/client"
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+func collectEvents(t *testing.T, rawURL string, want int) []models.Event {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := make(chan models.Event, want)
+	go listenWithOptions(ctx, client.New(client.Options{Timeout: time.Second}), rawURL, out, listenOptions{
+		min:    10 * time.Millisecond,
+		max:    10 * time.Millisecond,
+		jitter: func(time.Duration) time.Duration { return 0 },
+	})
+	var got []models.Event
+	timeout := time.After(2 * time.Second)
+	for len(got) < want {
+		select {
+		case event := <-out:
+			got = append(got, event)
+		case <-timeout:
+			t.Fatalf("expected %d events, got %d", want, len(got))
+		}
+	}
+	return got
+}
+
+func TestListenSSETransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keepalive\ndata: {\"msg\":\"one\"}\n\ndata: {\"msg\":\"two\"}\n\n")
+	}))
+	defer server.Close()
+
+	got := collectEvents(t, server.URL, 2)
+	if got[0].Message != "one" || got[1].Message != "two" {
+		t.Fatalf("unexpected events: %+v", got)
+	}
+}
+
+func TestListenNDJSONTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept"), "application/x-ndjson") {
+			t.Errorf("expected ndjson in Accept header, got %q", r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson; charset=utf-8")
+		flusher := w.(http.Flusher)
+		fmt.Fprint(w, "{\"msg\":\"one\",\"level\":\"info\"}\n")
+		flusher.Flush()
+		fmt.Fprint(w, "\n{invalid}\n{\"msg\":\"two\",\"level\":\"error\"}\n")
+		flusher.Flush()
+	}))
+	defer server.Close()
+
+	got := collectEvents(t, server.URL, 2)
+	if got[0].Message != "one" || got[1].Message != "two" || got[1].Level != "error" {
+		t.Fatalf("unexpected events: %+v", got)
+	}
+}
+
+func TestListenWebSocketTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		key := r.Header.Get("Sec-WebSocket-Key")
+		if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") || key == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("hijack: %v", err)
+			return
+		}
+		defer conn.Close()
+		fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n", websocketAccept(key))
+		writeServerFrame(rw, true, wsOpPing, []byte("hi"))
+		writeServerFrame(rw, true, wsOpText, []byte(`{"msg":"one"}`))
+		writeServerFrame(rw, false, wsOpText, []byte(`{"msg":`))
+		writeServerFrame(rw, true, wsOpContinuation, []byte(`"two"}`))
+		rw.Flush()
+
+		reader := bufio.NewReader(rw)
+		var header [2]byte
+		if _, err := reader.Read(header[:]); err != nil {
+			t.Errorf("read pong: %v", err)
+			return
+		}
+		if header[0]&0x0F != wsOpPong || header[1]&0x80 == 0 {
+			t.Errorf("expected masked pong frame, got %x", header)
+		}
+		writeServerFrame(rw, true, wsOpClose, nil)
+		rw.Flush()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	httpClient := client.New(client.Options{Headers: map[string]string{"Authorization": "Bearer token"}})
+	out := make(chan models.Event, 4)
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	err := connect(ctx, httpClient, wsURL, DefaultSchema(), out)
+	if err != errWebSocketClosed {
+		t.Fatalf("expected close error, got %v", err)
+	}
+	if len(out) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(out))
+	}
+	if first, second := <-out, <-out; first.Message != "one" || second.Message != "two" {
+		t.Fatalf("unexpected events: %q %q", first.Message, second.Message)
+	}
+}
+
+func TestWebSocketStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	err := connect(context.Background(), client.New(client.Options{}), wsURL, DefaultSchema(), make(chan models.Event, 1))
+	if !client.IsStatus(err, http.StatusForbidden) {
+		t.Fatalf("expected status error, got %v", err)
+	}
+}
+
+func writeServerFrame(w *bufio.ReadWriter, fin bool, opcode byte, payload []byte) {
+	first := opcode
+	if fin {
+		first |= 0x80
+	}
+	if len(payload) < 126 {
+		w.Write([]byte{first, byte(len(payload))})
+	} else {
+		var ext [2]byte
+		binary.BigEndian.PutUint16(ext[:], uint16(len(payload)))
+		w.Write([]byte{first, 126, ext[0], ext[1]})
+	}
+	w.Write(payload)
+}
@@ -13,11 +13,20 @@ import (
 )
 
 type payload struct {
-	Status  string `json:"status"`
-	Version string `json:"version"`
-	Build   string `json:"build"`
-	Commit  string `json:"commit"`
-	Message string `json:"message"`
+	Status  string                     `json:"status"`
+	Version string                     `json:"version"`
+	Build   string                     `json:"build"`
+	Commit  string                     `json:"commit"`
+	Message string                     `json:"message"`
+	Checks  map[string]json.RawMessage `json:"checks"`
+}
+
+type checkPayload struct {
+	Status    string   `json:"status"`
+	Healthy   *bool    `json:"healthy"`
+	Message   string   `json:"message"`
+	Error     string   `json:"error"`
+	LatencyMS *float64 `json:"latency_ms"`
 }
 
 func Fetch(ctx context.Context, httpClient *client.Client, url string) (models.HealthStatus, error) {
@@ -43,7 +52,8 @@ func Fetch(ctx context.Context, httpClient *client.Client, url string) (models.H
 			status.Build = parsed.Build
 			status.Commit = parsed.Commit
 			status.Message = parsed.Message
-			if status.Status == "ok" || status.Status == "healthy" {
+			status.Checks = parseChecks(parsed.Checks)
+			if healthyStatus(status.Status) {
 				status.Healthy = true
 			}
 		}
@@ -53,3 +63,64 @@ func Fetch(ctx context.Context, httpClient *client.Client, url string) (models.H
 	}
 	return status, nil
 }
+
+func parseChecks(raw map[string]json.RawMessage) map[string]models.HealthCheck {
+	if len(raw) == 0 {
+		return nil
+	}
+	checks := make(map[string]models.HealthCheck, len(raw))
+	for name, data := range raw {
+		var check models.HealthCheck
+		var text string
+		var flag bool
+		var detail checkPayload
+		switch {
+		case json.Unmarshal(data, &text) == nil:
+			check.Status = strings.ToLower(strings.TrimSpace(text))
+			check.Healthy = healthyStatus(check.Status)
+		case json.Unmarshal(data, &flag) == nil:
+			check.Healthy = flag
+			check.Status = "ok"
+			if !flag {
+				check.Status = "fail"
+			}
+		case json.Unmarshal(data, &detail) == nil:
+			check.Status = strings.ToLower(strings.TrimSpace(detail.Status))
+			check.Healthy = healthyStatus(check.Status)
+			if detail.Healthy != nil {
+				check.Healthy = *detail.Healthy
+			}
+			if check.Status == "" {
+				check.Status = "ok"
+				if !check.Healthy {
+					check.Status = "fail"
+				}
+			}
+			check.Message = firstNonEmpty(detail.Message, detail.Error)
+			if detail.LatencyMS != nil {
+				check.Latency = time.Duration(*detail.LatencyMS * float64(time.Millisecond))
+			}
+		default:
+			continue
+		}
+		checks[name] = check
+	}
+	return checks
+}
+
+func healthyStatus(status string) bool {
+	switch status {
+	case "ok", "healthy", "pass", "passing", "up", "true":
+		return true
+	}
+	return false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	return ""
+}
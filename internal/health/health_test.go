@@ -83,3 +83,31 @@ func TestFetchHealthError(t *testing.T) {
 		t.Fatalf("expected status text to reflect http status, got %q", status.Status)
 	}
 }
+
+func TestFetchHealthChecks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"status":"degraded","checks":{"db":"ok","broker":{"status":"fail","error":"connection refused","latency_ms":12.5},"scheduler":false,"cache":{"healthy":true}}}`))
+	}))
+	defer server.Close()
+
+	httpClient := client.New(client.Options{Timeout: 2 * time.Second})
+	status, err := Fetch(context.Background(), httpClient, server.URL)
+	if err == nil {
+		t.Fatalf("expected status error")
+	}
+	if len(status.Checks) != 4 {
+		t.Fatalf("expected 4 checks, got %+v", status.Checks)
+	}
+	if db := status.Checks["db"]; !db.Healthy || db.Status != "ok" {
+		t.Fatalf("unexpected db check: %+v", db)
+	}
+	broker := status.Checks["broker"]
+	if broker.Healthy || broker.Message != "connection refused" || broker.Latency != 12500*time.Microsecond {
+		t.Fatalf("unexpected broker check: %+v", broker)
+	}
+	if status.Checks["scheduler"].Healthy || !status.Checks["cache"].Healthy {
+		t.Fatalf("unexpected bool checks: %+v", status.Checks)
+	}
+}
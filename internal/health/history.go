@@ -0,0 +1,177 @@
+package health
+
+import (
+	"sort"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+const Overall = "overall"
+
+type Sample struct {
+	At      time.Time
+	Healthy bool
+	Status  string
+}
+
+type BucketState int
+
+const (
+	BucketUnknown BucketState = iota
+	BucketUp
+	BucketDown
+	BucketMixed
+)
+
+type History struct {
+	capacity   int
+	components map[string][]Sample
+}
+
+func NewHistory(capacity int) *History {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &History{
+		capacity:   capacity,
+		components: map[string][]Sample{},
+	}
+}
+
+func (h *History) Record(at time.Time, status models.HealthStatus, err error) {
+	overall := Sample{At: at, Healthy: err == nil && status.Healthy, Status: status.Status}
+	if err != nil && overall.Status == "" {
+		overall.Status = "unreachable"
+	}
+	h.add(Overall, overall)
+	for name, check := range status.Checks {
+		h.add(name, Sample{At: at, Healthy: check.Healthy, Status: check.Status})
+	}
+}
+
+// RecordSkipped marks a poll skipped while the endpoint was unreachable, so
+// the strip shows the outage instead of gaps.
+func (h *History) RecordSkipped(at time.Time) {
+	h.add(Overall, Sample{At: at, Status: "unreachable"})
+}
+
+func (h *History) add(name string, sample Sample) {
+	samples := append(h.components[name], sample)
+	if len(samples) > h.capacity+h.capacity/4 {
+		samples = append([]Sample(nil), samples[len(samples)-h.capacity:]...)
+	}
+	h.components[name] = samples
+}
+
+func (h *History) Components() []string {
+	names := make([]string, 0, len(h.components))
+	for name := range h.components {
+		if name != Overall {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := h.components[Overall]; ok {
+		names = append([]string{Overall}, names...)
+	}
+	return names
+}
+
+func (h *History) Latest(name string) (Sample, bool) {
+	samples := h.components[name]
+	if len(samples) == 0 {
+		return Sample{}, false
+	}
+	return samples[len(samples)-1], true
+}
+
+func (h *History) Samples(name string, since time.Time) []Sample {
+	samples := h.components[name]
+	idx := sort.Search(len(samples), func(i int) bool {
+		return !samples[i].At.Before(since)
+	})
+	return append([]Sample(nil), samples[idx:]...)
+}
+
+func (h *History) Transitions(name string, since time.Time) int {
+	samples := h.components[name]
+	count := 0
+	for i := 1; i < len(samples); i++ {
+		if samples[i].At.Before(since) {
+			continue
+		}
+		if samples[i].Healthy != samples[i-1].Healthy {
+			count++
+		}
+	}
+	return count
+}
+
+func (h *History) Flapping(name string, now time.Time, threshold int, window time.Duration) bool {
+	if threshold <= 0 || window <= 0 {
+		return false
+	}
+	return h.Transitions(name, now.Add(-window)) >= threshold
+}
+
+func (h *History) Uptime(name string, since, now time.Time) (float64, bool) {
+	samples := h.components[name]
+	var up, total time.Duration
+	for i, sample := range samples {
+		end := now
+		if i+1 < len(samples) {
+			end = samples[i+1].At
+		}
+		start := sample.At
+		if start.Before(since) {
+			start = since
+		}
+		if !end.After(start) {
+			continue
+		}
+		span := end.Sub(start)
+		total += span
+		if sample.Healthy {
+			up += span
+		}
+	}
+	if total <= 0 {
+		return 0, false
+	}
+	return float64(up) / float64(total), true
+}
+
+func (h *History) Strip(name string, since, now time.Time, buckets int) []BucketState {
+	if buckets < 1 || !now.After(since) {
+		return nil
+	}
+	states := make([]BucketState, buckets)
+	span := now.Sub(since)
+	for _, sample := range h.components[name] {
+		if sample.At.Before(since) || sample.At.After(now) {
+			continue
+		}
+		idx := int(sample.At.Sub(since) * time.Duration(buckets) / span)
+		if idx >= buckets {
+			idx = buckets - 1
+		}
+		next := BucketDown
+		if sample.Healthy {
+			next = BucketUp
+		}
+		switch states[idx] {
+		case BucketUnknown:
+			states[idx] = next
+		case BucketUp, BucketDown:
+			if states[idx] != next {
+				states[idx] = BucketMixed
+			}
+		}
+	}
+	return states
+}
+
+func (h *History) Clear() {
+	h.components = map[string][]Sample{}
+}
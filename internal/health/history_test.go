@@ -0,0 +1,74 @@
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+func TestHistoryTracksComponentsAndFlapping(t *testing.T) {
+	history := NewHistory(100)
+	base := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		brokerOK := i%2 == 0
+		status := models.HealthStatus{
+			Healthy: true,
+			Status:  "ok",
+			Checks: map[string]models.HealthCheck{
+				"db":     {Healthy: true, Status: "ok"},
+				"broker": {Healthy: brokerOK, Status: map[bool]string{true: "ok", false: "fail"}[brokerOK]},
+			},
+		}
+		history.Record(base.Add(time.Duration(i)*10*time.Second), status, nil)
+	}
+	now := base.Add(100 * time.Second)
+
+	if got := history.Components(); len(got) != 3 || got[0] != Overall || got[1] != "broker" || got[2] != "db" {
+		t.Fatalf("unexpected components: %v", got)
+	}
+	if got := history.Transitions("broker", base); got != 9 {
+		t.Fatalf("expected 9 broker transitions, got %d", got)
+	}
+	if !history.Flapping("broker", now, 4, 5*time.Minute) {
+		t.Fatalf("expected broker to be flapping")
+	}
+	if history.Flapping("db", now, 4, 5*time.Minute) {
+		t.Fatalf("expected db to be stable")
+	}
+	if history.Flapping("broker", now, 4, 20*time.Second) {
+		t.Fatalf("expected short flap window to ignore older transitions")
+	}
+	if uptime, ok := history.Uptime("broker", base, now); !ok || uptime != 0.5 {
+		t.Fatalf("expected 50%% broker uptime, got %v (%v)", uptime, ok)
+	}
+	strip := history.Strip("db", base, now, 10)
+	for i, state := range strip {
+		if state != BucketUp {
+			t.Fatalf("expected db bucket %d up, got %v", i, state)
+		}
+	}
+	strip = history.Strip("broker", base, now, 5)
+	if strip[0] != BucketMixed {
+		t.Fatalf("expected mixed broker bucket, got %v", strip)
+	}
+}
+
+func TestHistoryRecordsUnreachableAndTrims(t *testing.T) {
+	history := NewHistory(4)
+	base := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 20; i++ {
+		history.Record(base.Add(time.Duration(i)*time.Second), models.HealthStatus{}, errors.New("refused"))
+	}
+	latest, ok := history.Latest(Overall)
+	if !ok || latest.Healthy || latest.Status != "unreachable" {
+		t.Fatalf("unexpected latest sample: %+v", latest)
+	}
+	if got := len(history.Samples(Overall, time.Time{})); got > 5 {
+		t.Fatalf("expected history to be trimmed, got %d samples", got)
+	}
+	if got := history.Samples(Overall, base.Add(19*time.Second)); len(got) != 1 {
+		t.Fatalf("expected samples since cutoff, got %d", len(got))
+	}
+}
@@ -0,0 +1,198 @@
+package health
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	SignalHealth = "health"
+	SignalScrape = "scrape"
+
+	sloResolution = time.Minute
+
+	FastBurnThreshold = 14.4
+	SlowBurnThreshold = 6
+)
+
+type BurnLevel int
+
+const (
+	BurnNone BurnLevel = iota
+	BurnSlow
+	BurnFast
+)
+
+type sloBucket struct {
+	start time.Time
+	good  int
+	total int
+}
+
+type SLOTracker struct {
+	target  float64
+	window  time.Duration
+	signals map[string][]sloBucket
+}
+
+func NewSLOTracker(target float64, window time.Duration) *SLOTracker {
+	if target <= 0 || target >= 1 {
+		target = 0.999
+	}
+	if window < sloResolution {
+		window = sloResolution
+	}
+	return &SLOTracker{
+		target:  target,
+		window:  window,
+		signals: map[string][]sloBucket{},
+	}
+}
+
+func (t *SLOTracker) Target() float64 {
+	return t.target
+}
+
+func (t *SLOTracker) Window() time.Duration {
+	return t.window
+}
+
+func (t *SLOTracker) Record(signal string, at time.Time, ok bool) {
+	start := at.Truncate(sloResolution)
+	buckets := t.signals[signal]
+	idx := sort.Search(len(buckets), func(i int) bool {
+		return !buckets[i].start.Before(start)
+	})
+	if idx == len(buckets) || !buckets[idx].start.Equal(start) {
+		buckets = append(buckets, sloBucket{})
+		copy(buckets[idx+1:], buckets[idx:])
+		buckets[idx] = sloBucket{start: start}
+	}
+	buckets[idx].total++
+	if ok {
+		buckets[idx].good++
+	}
+	t.signals[signal] = t.trim(buckets, buckets[len(buckets)-1].start)
+}
+
+func (t *SLOTracker) trim(buckets []sloBucket, now time.Time) []sloBucket {
+	cutoff := now.Add(-t.window - sloResolution)
+	idx := 0
+	for idx < len(buckets) && buckets[idx].start.Before(cutoff) {
+		idx++
+	}
+	if idx == 0 {
+		return buckets
+	}
+	return append([]sloBucket(nil), buckets[idx:]...)
+}
+
+func (t *SLOTracker) Signals() []string {
+	names := make([]string, 0, len(t.signals))
+	for name := range t.signals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (t *SLOTracker) counts(signal string, since, now time.Time) (good, total int) {
+	for _, bucket := range t.signals[signal] {
+		if !bucket.start.Add(sloResolution).After(since) || bucket.start.After(now) {
+			continue
+		}
+		good += bucket.good
+		total += bucket.total
+	}
+	return good, total
+}
+
+func (t *SLOTracker) Availability(signal string, since, now time.Time) (float64, bool) {
+	good, total := t.counts(signal, since, now)
+	if total == 0 {
+		return 0, false
+	}
+	return float64(good) / float64(total), true
+}
+
+func (t *SLOTracker) BurnRate(signal string, window time.Duration, now time.Time) (float64, bool) {
+	availability, ok := t.Availability(signal, now.Add(-window), now)
+	if !ok {
+		return 0, false
+	}
+	return (1 - availability) / (1 - t.target), true
+}
+
+// BudgetRemaining is the share of the window's error budget left. The budget
+// is sized for a full window of samples at the rate seen so far, so failures
+// only spend their share of it: one failure a minute after startup does not
+// exhaust a 24h budget the way a ratio over the samples collected would.
+func (t *SLOTracker) BudgetRemaining(signal string, now time.Time) (float64, bool) {
+	burn, ok := t.BurnRate(signal, t.window, now)
+	if !ok {
+		return 0, false
+	}
+	return 1 - burn*float64(t.coverage(signal, now))/float64(t.window), true
+}
+
+// coverage is how much of the window ending at now has samples, from the
+// oldest bucket in the window onward, and at least one bucket.
+func (t *SLOTracker) coverage(signal string, now time.Time) time.Duration {
+	since := now.Add(-t.window)
+	for _, bucket := range t.signals[signal] {
+		if !bucket.start.Add(sloResolution).After(since) || bucket.start.After(now) {
+			continue
+		}
+		start := bucket.start
+		if start.Before(since) {
+			start = since
+		}
+		covered := now.Sub(start)
+		if covered < sloResolution {
+			covered = sloResolution
+		}
+		if covered > t.window {
+			covered = t.window
+		}
+		return covered
+	}
+	return sloResolution
+}
+
+func (t *SLOTracker) BurnSeries(signal string, window time.Duration, now time.Time, points int) []float64 {
+	if points < 1 {
+		return nil
+	}
+	out := make([]float64, 0, points)
+	end := now.Truncate(sloResolution).Add(-time.Duration(points-1) * sloResolution)
+	for i := 0; i < points; i++ {
+		at := end.Add(time.Duration(i) * sloResolution)
+		if burn, ok := t.BurnRate(signal, window, at); ok {
+			out = append(out, burn)
+		}
+	}
+	return out
+}
+
+func (t *SLOTracker) BurnLevel(signal string, now time.Time) BurnLevel {
+	above := func(long, short time.Duration, threshold float64) bool {
+		longBurn, ok := t.BurnRate(signal, long, now)
+		if !ok || longBurn < threshold {
+			return false
+		}
+		shortBurn, ok := t.BurnRate(signal, short, now)
+		return ok && shortBurn >= threshold
+	}
+	switch {
+	case above(time.Hour, 5*time.Minute, FastBurnThreshold):
+		return BurnFast
+	case above(6*time.Hour, 30*time.Minute, SlowBurnThreshold):
+		return BurnSlow
+	default:
+		return BurnNone
+	}
+}
+
+func (t *SLOTracker) Clear() {
+	t.signals = map[string][]sloBucket{}
+}
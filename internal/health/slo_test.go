@@ -0,0 +1,83 @@
+package health
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSLOTrackerBudgetAndBurn(t *testing.T) {
+	tracker := NewSLOTracker(0.99, 24*time.Hour)
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 120; i++ {
+		tracker.Record(SignalHealth, base.Add(time.Duration(i)*time.Minute), true)
+	}
+	now := base.Add(2 * time.Hour)
+	for i := 0; i < 20; i++ {
+		tracker.Record(SignalHealth, now.Add(-time.Duration(i)*10*time.Second), false)
+	}
+
+	availability, ok := tracker.Availability(SignalHealth, base, now)
+	if !ok || math.Abs(availability-120.0/140.0) > 1e-9 {
+		t.Fatalf("unexpected availability %v %v", availability, ok)
+	}
+	remaining, ok := tracker.BudgetRemaining(SignalHealth, now)
+	// Two hours of samples spend their burn against a 24h budget.
+	if !ok || math.Abs(remaining-(1-(20.0/140.0)/0.01*2/24)) > 1e-9 {
+		t.Fatalf("unexpected budget remaining %v", remaining)
+	}
+	burn, ok := tracker.BurnRate(SignalHealth, 5*time.Minute, now)
+	if !ok || burn < FastBurnThreshold {
+		t.Fatalf("expected fast short-window burn, got %v", burn)
+	}
+	if level := tracker.BurnLevel(SignalHealth, now); level != BurnFast {
+		t.Fatalf("expected fast burn, got %v", level)
+	}
+	if level := tracker.BurnLevel(SignalHealth, base.Add(time.Hour)); level != BurnNone {
+		t.Fatalf("expected no burn before failures, got %v", level)
+	}
+	if _, ok := tracker.Availability(SignalScrape, base, now); ok {
+		t.Fatalf("expected no scrape samples")
+	}
+	if series := tracker.BurnSeries(SignalHealth, 5*time.Minute, now, 10); len(series) != 10 || series[9] <= series[0] {
+		t.Fatalf("unexpected burn series %v", series)
+	}
+}
+
+func TestSLOTrackerTrimsToWindow(t *testing.T) {
+	tracker := NewSLOTracker(0.999, time.Hour)
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker.Record(SignalScrape, base, false)
+	tracker.Record(SignalScrape, base.Add(3*time.Hour), true)
+
+	if len(tracker.signals[SignalScrape]) != 1 {
+		t.Fatalf("expected old buckets to be trimmed, got %d", len(tracker.signals[SignalScrape]))
+	}
+	remaining, ok := tracker.BudgetRemaining(SignalScrape, base.Add(3*time.Hour))
+	if !ok || remaining != 1 {
+		t.Fatalf("expected full budget, got %v %v", remaining, ok)
+	}
+}
+
+func TestSLOTrackerEarlyFailureSpendsShareOfBudget(t *testing.T) {
+	tracker := NewSLOTracker(0.99, 24*time.Hour)
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker.Record(SignalHealth, base, false)
+	for i := 1; i < 10; i++ {
+		tracker.Record(SignalHealth, base.Add(time.Duration(i)*time.Minute), true)
+	}
+	now := base.Add(10 * time.Minute)
+	remaining, ok := tracker.BudgetRemaining(SignalHealth, now)
+	// 10% failures for 10 minutes burns 10x for 1/144 of the window.
+	if !ok || math.Abs(remaining-(1-10.0/144)) > 1e-9 {
+		t.Fatalf("expected early failure to spend a share of the budget, got %v %v", remaining, ok)
+	}
+
+	for i := 0; i < 24*60; i++ {
+		tracker.Record(SignalHealth, now.Add(time.Duration(i)*time.Minute), i%20 != 0)
+	}
+	remaining, ok = tracker.BudgetRemaining(SignalHealth, now.Add(24*time.Hour))
+	if !ok || remaining > 0 {
+		t.Fatalf("expected a day at 5%% failures to exhaust the budget, got %v %v", remaining, ok)
+	}
+}
@@ -34,9 +34,14 @@ func Scrape(ctx context.Context, httpClient *client.Client, url string, catalog
 		CollectedAt: time.Now(),
 		Latency:     time.Since(start),
 		Values:      values,
+		Workers:     extractWorkerCatalog(metricFamilies, catalog),
 	}, nil
 }
 
+// WorkerLabels are the label names checked, in order, to attribute a sample
+// to a worker.
+var WorkerLabels = []string{"worker_id", "worker"}
+
 func parseMetrics(reader io.Reader) (map[string]*dto.MetricFamily, error) {
 	parser := expfmt.NewTextParser(model.UTF8Validation)
 	return parser.TextToMetricFamilies(reader)
@@ -54,6 +59,52 @@ func extractCatalog(families map[string]*dto.MetricFamily, catalog Catalog) map[
 	return values
 }
 
+func extractWorkerCatalog(families map[string]*dto.MetricFamily, catalog Catalog) map[string]map[string]float64 {
+	selectors := catalog.Selectors
+	if selectors == nil {
+		selectors = compileSelectors(catalog.Mapping)
+	}
+	workers := map[string]map[string]float64{}
+	for key, selector := range selectors {
+		if selector.Name == "" {
+			continue
+		}
+		family, ok := families[selector.Name]
+		if !ok {
+			continue
+		}
+		grouped := map[string][]*dto.Metric{}
+		for _, metric := range filterMetrics(family.Metric, selector.Labels) {
+			if worker := workerLabel(metric); worker != "" {
+				grouped[worker] = append(grouped[worker], metric)
+			}
+		}
+		for worker, metrics := range grouped {
+			values, ok := workers[worker]
+			if !ok {
+				values = map[string]float64{}
+				workers[worker] = values
+			}
+			values[key] = aggregateMetrics(family.GetType(), metrics, key == MetricLatencyP95)
+		}
+	}
+	if len(workers) == 0 {
+		return nil
+	}
+	return workers
+}
+
+func workerLabel(metric *dto.Metric) string {
+	for _, name := range WorkerLabels {
+		for _, pair := range metric.GetLabel() {
+			if pair.GetName() == name && pair.GetValue() != "" {
+				return pair.GetValue()
+			}
+		}
+	}
+	return ""
+}
+
 func extractMetricValue(families map[string]*dto.MetricFamily, selector Selector, isP95 bool) float64 {
 	if selector.Name == "" {
 		return math.NaN()
@@ -62,8 +113,11 @@ func extractMetricValue(families map[string]*dto.MetricFamily, selector Selector
 	if !ok {
 		return math.NaN()
 	}
-	filtered := filterMetrics(family.Metric, selector.Labels)
-	switch family.GetType() {
+	return aggregateMetrics(family.GetType(), filterMetrics(family.Metric, selector.Labels), isP95)
+}
+
+func aggregateMetrics(metricType dto.MetricType, filtered []*dto.Metric, isP95 bool) float64 {
+	switch metricType {
 	case dto.MetricType_GAUGE:
 		return sumGauge(filtered)
 	case dto.MetricType_COUNTER:
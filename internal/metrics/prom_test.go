@@ -5,6 +5,7 @@ import (
 	"math"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -56,4 +57,39 @@ reproq_exec_duration_seconds_count 100
 	if got := snapshot.Values[MetricLatencyP95]; math.Abs(got-0.5) > 1e-9 {
 		t.Fatalf("p95 mismatch: got %v", got)
 	}
+	if snapshot.Workers != nil {
+		t.Fatalf("expected no worker breakdown without worker labels, got %v", snapshot.Workers)
+	}
+}
+
+func TestExtractWorkerCatalog(t *testing.T) {
+	payload := `# TYPE reproq_tasks_processed_total counter
+reproq_tasks_processed_total{status="success",worker_id="w-1"} 40
+reproq_tasks_processed_total{status="failure",worker_id="w-1"} 2
+reproq_tasks_processed_total{status="success",worker="w-2"} 10
+reproq_tasks_processed_total{status="success"} 99
+# TYPE reproq_concurrency_in_use gauge
+reproq_concurrency_in_use{worker_id="w-1"} 3
+reproq_concurrency_in_use{worker_id="w-2"} 1
+`
+	families, err := parseMetrics(strings.NewReader(payload))
+	if err != nil {
+		t.Fatalf("parse metrics: %v", err)
+	}
+	workers := extractWorkerCatalog(families, DefaultCatalog())
+	if len(workers) != 2 {
+		t.Fatalf("expected 2 workers, got %v", workers)
+	}
+	if got := workers["w-1"][MetricTasksTotal]; got != 42 {
+		t.Fatalf("w-1 tasks total mismatch: got %v", got)
+	}
+	if got := workers["w-1"][MetricTasksFailed]; got != 2 {
+		t.Fatalf("w-1 tasks failed mismatch: got %v", got)
+	}
+	if got := workers["w-2"][MetricConcurrencyInUse]; got != 1 {
+		t.Fatalf("w-2 concurrency mismatch: got %v", got)
+	}
+	if _, ok := workers["w-2"][MetricTasksFailed]; ok {
+		t.Fatalf("expected no failed value for w-2, got %v", workers["w-2"])
+	}
 }
@@ -0,0 +1,280 @@
+package stats
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/client"
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+const deltaFullEvery = 12
+
+type FetchInfo struct {
+	Status       int
+	NotModified  bool
+	Delta        bool
+	Bytes        int64
+	TotalBytes   int64
+	Requests     int
+	NotModifieds int
+	ETag         string
+	LastModified string
+	ChangedAt    time.Time
+}
+
+type Fetcher struct {
+	client *client.Client
+	delta  bool
+
+	mu           sync.Mutex
+	url          string
+	etag         string
+	lastModified string
+	cursor       string
+	sinceFull    int
+	last         models.DjangoStats
+	have         bool
+	changedAt    time.Time
+	totalBytes   int64
+	requests     int
+	notModifieds int
+}
+
+func NewFetcher(httpClient *client.Client, delta bool) *Fetcher {
+	return &Fetcher{client: httpClient, delta: delta}
+}
+
+func (f *Fetcher) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reset("")
+}
+
+func (f *Fetcher) reset(target string) {
+	f.url = target
+	f.etag = ""
+	f.lastModified = ""
+	f.cursor = ""
+	f.sinceFull = 0
+	f.last = models.DjangoStats{}
+	f.have = false
+	f.changedAt = time.Time{}
+}
+
+func (f *Fetcher) Fetch(ctx context.Context, target string) (models.DjangoStats, FetchInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if target != f.url {
+		f.reset(target)
+	}
+	requestURL := target
+	useDelta := f.delta && f.have && f.cursor != "" && f.sinceFull < deltaFullEvery
+	if useDelta {
+		requestURL = withSince(target, f.cursor)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return models.DjangoStats{}, f.info(0, 0), err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
+	if f.have {
+		if f.etag != "" {
+			req.Header.Set("If-None-Match", f.etag)
+		}
+		if f.lastModified != "" {
+			req.Header.Set("If-Modified-Since", f.lastModified)
+		}
+	}
+	f.requests++
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return models.DjangoStats{}, f.info(0, 0), err
+	}
+	defer resp.Body.Close()
+	counter := &countingReader{reader: resp.Body}
+	defer func() {
+		f.totalBytes += counter.n
+	}()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && f.have:
+		_, _ = io.Copy(io.Discard, counter)
+		f.notModifieds++
+		f.remember(resp)
+		stats := f.last
+		stats.FetchedAt = time.Now()
+		info := f.info(resp.StatusCode, counter.n)
+		info.NotModified = true
+		return stats, info, nil
+	case resp.StatusCode != http.StatusOK:
+		return models.DjangoStats{}, f.info(resp.StatusCode, counter.n), fmt.Errorf("stats status %d", resp.StatusCode)
+	}
+
+	var body io.Reader = counter
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(counter)
+		if err != nil {
+			return models.DjangoStats{}, f.info(resp.StatusCode, counter.n), err
+		}
+		defer gz.Close()
+		body = gz
+	}
+	var stats models.DjangoStats
+	if err := json.NewDecoder(body).Decode(&stats); err != nil {
+		return models.DjangoStats{}, f.info(resp.StatusCode, counter.n), err
+	}
+	delta := stats.Delta && useDelta
+	if delta {
+		stats = MergeDelta(f.last, stats)
+		f.sinceFull++
+	} else {
+		f.sinceFull = 0
+	}
+	stats.Delta = false
+	stats.Removed = nil
+	stats.FetchedAt = time.Now()
+	normalize(&stats)
+	f.remember(resp)
+	f.last = stats
+	f.have = true
+	f.changedAt = stats.FetchedAt
+	info := f.info(resp.StatusCode, counter.n)
+	info.Delta = delta
+	return stats, info, nil
+}
+
+func (f *Fetcher) remember(resp *http.Response) {
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		f.etag = etag
+	}
+	if modified := resp.Header.Get("Last-Modified"); modified != "" {
+		f.lastModified = modified
+	}
+	f.cursor = ""
+	for _, header := range []string{"Last-Modified", "Date"} {
+		if at, err := http.ParseTime(resp.Header.Get(header)); err == nil {
+			f.cursor = at.UTC().Format(time.RFC3339)
+			break
+		}
+	}
+}
+
+func (f *Fetcher) info(status int, n int64) FetchInfo {
+	return FetchInfo{
+		Status:       status,
+		Bytes:        n,
+		TotalBytes:   f.totalBytes + n,
+		Requests:     f.requests,
+		NotModifieds: f.notModifieds,
+		ETag:         f.etag,
+		LastModified: f.lastModified,
+		ChangedAt:    f.changedAt,
+	}
+}
+
+func withSince(target, since string) string {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return target
+	}
+	query := parsed.Query()
+	query.Set("since", since)
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
+
+// MergeDelta applies a delta payload to base: sections it carries replace
+// base's, listed items are upserted by key, and items in delta.Removed are
+// dropped.
+func MergeDelta(base, delta models.DjangoStats) models.DjangoStats {
+	out := base
+	if delta.Tasks != nil {
+		out.Tasks = delta.Tasks
+	}
+	if delta.Queues != nil {
+		out.Queues = delta.Queues
+	}
+	if delta.WorkerHealth != nil {
+		out.WorkerHealth = delta.WorkerHealth
+	}
+	if delta.Scheduler != nil {
+		out.Scheduler = delta.Scheduler
+	}
+	if delta.TopFailing != nil {
+		out.TopFailing = delta.TopFailing
+	}
+	removed := models.StatsRemovals{}
+	if delta.Removed != nil {
+		removed = *delta.Removed
+	}
+	workerKey := func(w models.WorkerInfo) string {
+		return w.WorkerID
+	}
+	periodicKey := func(p models.PeriodicTask) string {
+		return p.Name
+	}
+	controlKey := func(c models.QueueControl) string {
+		return c.Database + "/" + c.QueueName
+	}
+	databaseKey := func(d models.DatabaseStats) string {
+		return d.Alias
+	}
+	out.Workers = mergeBy(base.Workers, delta.Workers, removed.Workers, workerKey)
+	out.Periodic = mergeBy(base.Periodic, delta.Periodic, removed.Periodic, periodicKey)
+	removedControls := make([]string, 0, len(removed.QueueControls))
+	for _, control := range removed.QueueControls {
+		removedControls = append(removedControls, controlKey(control))
+	}
+	out.QueueControls = mergeBy(base.QueueControls, delta.QueueControls, removedControls, controlKey)
+	out.Databases = mergeBy(base.Databases, delta.Databases, removed.Databases, databaseKey)
+	return out
+}
+
+func mergeBy[T any](base, delta []T, removed []string, key func(T) string) []T {
+	if len(delta) == 0 && len(removed) == 0 {
+		return base
+	}
+	drop := make(map[string]bool, len(removed))
+	for _, name := range removed {
+		drop[name] = true
+	}
+	out := make([]T, 0, len(base)+len(delta))
+	index := make(map[string]int, len(base))
+	for _, item := range base {
+		if drop[key(item)] {
+			continue
+		}
+		index[key(item)] = len(out)
+		out = append(out, item)
+	}
+	for _, item := range delta {
+		if i, ok := index[key(item)]; ok {
+			out[i] = item
+			continue
+		}
+		index[key(item)] = len(out)
+		out = append(out, item)
+	}
+	return out
+}
+
+type countingReader struct {
+	reader io.Reader
+	n      int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.n += int64(n)
+	return n, err
+}
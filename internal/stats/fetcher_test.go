@@ -0,0 +1,155 @@
+package stats
+
+import (
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/client"
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+func TestFetcherConditionalRequests(t *testing.T) {
+	payload := `{"tasks": {"READY": 3}, "workers": [{"worker_id": "w1", "version": "1.0"}]}`
+	modified := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).Format(http.TimeFormat)
+	var full, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` && r.Header.Get("If-Modified-Since") == modified {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", modified)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(client.New(client.Options{Timeout: time.Second}), false)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	first, info, err := fetcher.Fetch(ctx, server.URL)
+	if err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+	if info.NotModified || info.Bytes != int64(len(payload)) || info.ETag != `"v1"` {
+		t.Fatalf("unexpected first fetch info: %+v", info)
+	}
+	second, info, err := fetcher.Fetch(ctx, server.URL)
+	if err != nil {
+		t.Fatalf("second fetch: %v", err)
+	}
+	if !info.NotModified || info.Status != http.StatusNotModified || info.Bytes != 0 {
+		t.Fatalf("expected 304 info, got %+v", info)
+	}
+	if info.Requests != 2 || info.NotModifieds != 1 || info.TotalBytes != int64(len(payload)) {
+		t.Fatalf("unexpected counters: %+v", info)
+	}
+	if second.Tasks["READY"] != 3 || len(second.Workers) != 1 || second.FetchedAt.Before(first.FetchedAt) {
+		t.Fatalf("expected cached payload on 304, got %+v", second)
+	}
+	if !info.ChangedAt.Equal(first.FetchedAt) {
+		t.Fatalf("expected changed time from the last full payload")
+	}
+	if full.Load() != 1 || notModified.Load() != 1 {
+		t.Fatalf("unexpected server hits: full=%d 304=%d", full.Load(), notModified.Load())
+	}
+}
+
+func TestFetcherRejectsUnexpectedNotModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(client.New(client.Options{Timeout: time.Second}), false)
+	if _, _, err := fetcher.Fetch(context.Background(), server.URL); err == nil {
+		t.Fatalf("expected error for 304 without a cached payload")
+	}
+}
+
+func TestFetcherRequestsDeltasAndMerges(t *testing.T) {
+	var calls atomic.Int32
+	var sinceSeen atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("Last-Modified", time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).Format(http.TimeFormat))
+		body := `{"tasks": {"READY": 1}, "workers": [{"worker_id": "w1", "version": "1.0"}, {"worker_id": "w2", "version": "1.0"}], "periodic": [{"name": "cleanup"}]}`
+		if since := r.URL.Query().Get("since"); since != "" {
+			sinceSeen.Store(since)
+			body = `{"delta": true, "tasks": {"READY": 4}, "workers": [{"worker_id": "w2", "version": "1.1"}]}`
+		}
+		gz := gzip.NewWriter(w)
+		_, _ = gz.Write([]byte(body))
+		_ = gz.Close()
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(client.New(client.Options{Timeout: time.Second}), true)
+	ctx := context.Background()
+	if _, _, err := fetcher.Fetch(ctx, server.URL+"?db=default"); err != nil {
+		t.Fatalf("full fetch: %v", err)
+	}
+	stats, info, err := fetcher.Fetch(ctx, server.URL+"?db=default")
+	if err != nil {
+		t.Fatalf("delta fetch: %v", err)
+	}
+	if since, _ := sinceSeen.Load().(string); since != "2024-01-01T12:00:00Z" {
+		t.Fatalf("unexpected since cursor %q", since)
+	}
+	if !info.Delta || info.Bytes == 0 {
+		t.Fatalf("unexpected delta info: %+v", info)
+	}
+	if stats.Tasks["READY"] != 4 || len(stats.Workers) != 2 || stats.Workers[1].Version != "1.1" || len(stats.Periodic) != 1 {
+		t.Fatalf("unexpected merged stats: %+v", stats)
+	}
+}
+
+func TestMergeDeltaAppliesRemovals(t *testing.T) {
+	base := models.DjangoStats{
+		Workers:       []models.WorkerInfo{{WorkerID: "w1"}, {WorkerID: "w2"}, {WorkerID: "w3"}},
+		Periodic:      []models.PeriodicTask{{Name: "cleanup"}, {Name: "digest"}},
+		QueueControls: []models.QueueControl{{QueueName: "bulk", Database: "default"}, {QueueName: "bulk", Database: "tenant_a"}},
+		Databases:     []models.DatabaseStats{{Alias: "default"}, {Alias: "tenant_a"}},
+	}
+	delta := models.DjangoStats{
+		Delta:   true,
+		Workers: []models.WorkerInfo{{WorkerID: "w3", Version: "1.1"}, {WorkerID: "w4"}},
+		Removed: &models.StatsRemovals{
+			Workers:       []string{"w1"},
+			Periodic:      []string{"digest"},
+			QueueControls: []models.QueueControl{{QueueName: "bulk", Database: "tenant_a"}},
+			Databases:     []string{"tenant_a"},
+		},
+	}
+	merged := MergeDelta(base, delta)
+	workers := []string{}
+	for _, worker := range merged.Workers {
+		workers = append(workers, worker.WorkerID)
+	}
+	if strings.Join(workers, ",") != "w2,w3,w4" || merged.Workers[1].Version != "1.1" {
+		t.Fatalf("expected w1 removed and w3 updated, got %+v", merged.Workers)
+	}
+	if len(merged.Periodic) != 1 || merged.Periodic[0].Name != "cleanup" {
+		t.Fatalf("expected digest removed, got %+v", merged.Periodic)
+	}
+	if len(merged.QueueControls) != 1 || merged.QueueControls[0].Database != "default" {
+		t.Fatalf("expected the tenant_a control removed, got %+v", merged.QueueControls)
+	}
+	if len(merged.Databases) != 1 || merged.Databases[0].Alias != "default" {
+		t.Fatalf("expected tenant_a removed, got %+v", merged.Databases)
+	}
+	if len(base.Workers) != 3 {
+		t.Fatalf("expected base to be left untouched, got %+v", base.Workers)
+	}
+}
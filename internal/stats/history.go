@@ -0,0 +1,154 @@
+package stats
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/metrics"
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+const (
+	StatusReady   = "READY"
+	StatusWaiting = "WAITING"
+	StatusRunning = "RUNNING"
+	StatusFailed  = "FAILED"
+	StatusTotal   = "TOTAL"
+
+	WorkersAlive = "workers:alive"
+	WorkersDead  = "workers:dead"
+
+	maxHistoryKeys = 512
+)
+
+func TaskKey(status string) string {
+	return "tasks:" + status
+}
+
+func QueueKey(queue, status string) string {
+	return "queue:" + queue + ":" + status
+}
+
+func DatabaseKey(alias, status string) string {
+	return "db:" + alias + ":" + status
+}
+
+func DatabaseQueueKey(alias, queue, status string) string {
+	return "dbqueue:" + alias + ":" + queue + ":" + status
+}
+
+type History struct {
+	capacity int
+	series   map[string]*metrics.RingBuffer
+}
+
+func NewHistory(capacity int) *History {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &History{
+		capacity: capacity,
+		series:   map[string]*metrics.RingBuffer{},
+	}
+}
+
+func (h *History) Record(stats models.DjangoStats, at time.Time) {
+	h.recordCounts(TaskKey, stats.Tasks, at)
+	for name, counts := range stats.Queues {
+		queue := name
+		h.recordCounts(func(status string) string { return QueueKey(queue, status) }, counts, at)
+	}
+	for _, db := range stats.Databases {
+		alias := db.Alias
+		h.recordCounts(func(status string) string { return DatabaseKey(alias, status) }, db.Tasks, at)
+		for name, counts := range db.Queues {
+			queue := name
+			h.recordCounts(func(status string) string { return DatabaseQueueKey(alias, queue, status) }, counts, at)
+		}
+	}
+	if stats.WorkerHealth != nil {
+		h.add(WorkersAlive, at, float64(stats.WorkerHealth.Alive))
+		h.add(WorkersDead, at, float64(stats.WorkerHealth.Dead))
+	}
+}
+
+func (h *History) recordCounts(key func(string) string, counts map[string]int64, at time.Time) {
+	if counts == nil {
+		return
+	}
+	totals := map[string]int64{
+		StatusReady:   0,
+		StatusWaiting: 0,
+		StatusRunning: 0,
+		StatusFailed:  0,
+		StatusTotal:   0,
+	}
+	for status, count := range counts {
+		if group := StatusGroup(status); group != "" {
+			totals[group] += count
+		}
+		totals[StatusTotal] += count
+	}
+	for status, count := range totals {
+		h.add(key(status), at, float64(count))
+	}
+}
+
+func StatusGroup(status string) string {
+	switch strings.ToUpper(status) {
+	case "READY":
+		return StatusReady
+	case "WAITING", "WAITING_CALLBACK":
+		return StatusWaiting
+	case "RUNNING":
+		return StatusRunning
+	case "FAILED":
+		return StatusFailed
+	default:
+		return ""
+	}
+}
+
+func (h *History) add(key string, at time.Time, value float64) {
+	buf, ok := h.series[key]
+	if !ok {
+		if len(h.series) >= maxHistoryKeys {
+			return
+		}
+		buf = metrics.NewRingBuffer(h.capacity)
+		h.series[key] = buf
+	}
+	buf.Add(models.Sample{Timestamp: at, Value: value})
+}
+
+func (h *History) Series(key string, cutoff time.Time) []models.Sample {
+	buf, ok := h.series[key]
+	if !ok {
+		return nil
+	}
+	return buf.ValuesSince(cutoff)
+}
+
+func (h *History) Growth(key string, cutoff time.Time) (float64, bool) {
+	samples := h.Series(key, cutoff)
+	if len(samples) < 2 {
+		return 0, false
+	}
+	return samples[len(samples)-1].Value - samples[0].Value, true
+}
+
+func (h *History) Keys(prefix string) []string {
+	keys := make([]string, 0, len(h.series))
+	for key := range h.series {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (h *History) Clear() {
+	h.series = map[string]*metrics.RingBuffer{}
+}
@@ -0,0 +1,50 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+func TestHistoryRecordsQueueAndDatabaseSeries(t *testing.T) {
+	history := NewHistory(10)
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		history.Record(models.DjangoStats{
+			Tasks: map[string]int64{"READY": 2, "FAILED": int64(i)},
+			Queues: map[string]map[string]int64{
+				"default": {"READY": 2, "WAITING_CALLBACK": 1, "FAILED": int64(i * 2)},
+			},
+			Databases: []models.DatabaseStats{{
+				Alias: "tenant_a",
+				Tasks: map[string]int64{"RUNNING": int64(i)},
+			}},
+			WorkerHealth: &models.WorkerHealth{Alive: 3, Dead: i},
+		}, base.Add(time.Duration(i)*5*time.Second))
+	}
+
+	if growth, ok := history.Growth(QueueKey("default", StatusFailed), time.Time{}); !ok || growth != 6 {
+		t.Fatalf("expected failed growth of 6, got %v %v", growth, ok)
+	}
+	if growth, ok := history.Growth(QueueKey("default", StatusFailed), base.Add(10*time.Second)); !ok || growth != 2 {
+		t.Fatalf("expected windowed growth of 2, got %v %v", growth, ok)
+	}
+	waiting := history.Series(QueueKey("default", StatusWaiting), time.Time{})
+	if len(waiting) != 4 || waiting[0].Value != 1 {
+		t.Fatalf("expected WAITING_CALLBACK grouped into WAITING, got %v", waiting)
+	}
+	total := history.Series(QueueKey("default", StatusTotal), time.Time{})
+	if total[3].Value != 9 {
+		t.Fatalf("expected queue total of 9, got %v", total[3].Value)
+	}
+	if series := history.Series(DatabaseKey("tenant_a", StatusRunning), time.Time{}); len(series) != 4 || series[3].Value != 3 {
+		t.Fatalf("unexpected database series %v", series)
+	}
+	if growth, ok := history.Growth(WorkersDead, time.Time{}); !ok || growth != 3 {
+		t.Fatalf("expected dead worker growth, got %v %v", growth, ok)
+	}
+	if keys := history.Keys("queue:default:"); len(keys) != 5 {
+		t.Fatalf("expected 5 queue status keys, got %v", keys)
+	}
+}
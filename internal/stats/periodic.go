@@ -0,0 +1,60 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/cron"
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+const (
+	PeriodicDriftTolerance = time.Minute
+	PeriodicMissedGrace    = time.Minute
+	maxMissedRuns          = 99
+)
+
+type PeriodicStatus struct {
+	Task        models.PeriodicTask
+	Schedule    *cron.Schedule
+	ParseErr    error
+	Description string
+	Upcoming    []time.Time
+	Expected    time.Time
+	Drift       time.Duration
+	Drifted     bool
+	Missed      bool
+	MissedRuns  int
+	Overdue     time.Duration
+}
+
+// AnalyzePeriodic compares the server-reported NextRunAt with the schedule
+// computed locally from CronExpr.
+func AnalyzePeriodic(task models.PeriodicTask, now time.Time, loc *time.Location, upcoming int) PeriodicStatus {
+	status := PeriodicStatus{Task: task}
+	schedule, err := cron.Parse(task.CronExpr, loc)
+	if err != nil {
+		status.ParseErr = err
+		status.Description = task.CronExpr
+	} else {
+		status.Schedule = schedule
+		status.Description = schedule.Describe()
+		status.Upcoming = schedule.NextN(now, upcoming)
+		status.Expected = schedule.Next(now)
+	}
+	if !task.Enabled || task.NextRunAt.IsZero() {
+		return status
+	}
+	if now.Sub(task.NextRunAt) > PeriodicMissedGrace {
+		status.Missed = true
+		status.Overdue = now.Sub(task.NextRunAt)
+		if schedule != nil {
+			status.MissedRuns = 1 + schedule.Between(task.NextRunAt, now, maxMissedRuns)
+		}
+		return status
+	}
+	if !status.Expected.IsZero() && task.NextRunAt.After(now) {
+		status.Drift = task.NextRunAt.Sub(status.Expected)
+		status.Drifted = status.Drift >= PeriodicDriftTolerance || status.Drift <= -PeriodicDriftTolerance
+	}
+	return status
+}
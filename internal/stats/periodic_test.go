@@ -0,0 +1,52 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+func TestAnalyzePeriodicDriftAndMissedRuns(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 1, 0, 0, time.UTC)
+
+	inSync := AnalyzePeriodic(models.PeriodicTask{
+		Name: "cleanup", CronExpr: "*/5 * * * *", Enabled: true,
+		NextRunAt: time.Date(2025, 6, 1, 12, 5, 0, 0, time.UTC),
+	}, now, time.UTC, 3)
+	if inSync.Drifted || inSync.Missed || inSync.Description != "every 5 minutes" {
+		t.Fatalf("expected in-sync task, got %+v", inSync)
+	}
+	if len(inSync.Upcoming) != 3 || !inSync.Upcoming[2].Equal(time.Date(2025, 6, 1, 12, 15, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected upcoming fires %v", inSync.Upcoming)
+	}
+
+	drifted := AnalyzePeriodic(models.PeriodicTask{
+		Name: "report", CronExpr: "*/5 * * * *", Enabled: true,
+		NextRunAt: time.Date(2025, 6, 1, 12, 8, 0, 0, time.UTC),
+	}, now, time.UTC, 3)
+	if !drifted.Drifted || drifted.Drift != 3*time.Minute {
+		t.Fatalf("expected +3m drift, got %+v", drifted)
+	}
+
+	missed := AnalyzePeriodic(models.PeriodicTask{
+		Name: "sync", CronExpr: "*/5 * * * *", Enabled: true,
+		NextRunAt: time.Date(2025, 6, 1, 11, 50, 0, 0, time.UTC),
+	}, now, time.UTC, 3)
+	if !missed.Missed || missed.MissedRuns != 3 || missed.Overdue != 11*time.Minute {
+		t.Fatalf("expected 3 missed runs, got %+v", missed)
+	}
+
+	disabled := AnalyzePeriodic(models.PeriodicTask{
+		Name: "old", CronExpr: "*/5 * * * *",
+		NextRunAt: time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC),
+	}, now, time.UTC, 3)
+	if disabled.Missed {
+		t.Fatalf("expected disabled tasks to never be flagged as missed")
+	}
+
+	broken := AnalyzePeriodic(models.PeriodicTask{Name: "bad", CronExpr: "nope", Enabled: true}, now, time.UTC, 3)
+	if broken.ParseErr == nil || broken.Description != "nope" {
+		t.Fatalf("expected parse error, got %+v", broken)
+	}
+}
@@ -0,0 +1,178 @@
+package stats
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+const UnknownVersion = "unknown"
+
+type VersionGroup struct {
+	Version   string
+	FirstSeen time.Time
+	LastSeen  time.Time
+	Workers   []models.WorkerInfo
+}
+
+type Rollout struct {
+	firstSeen map[string]time.Time
+	lastSeen  map[string]time.Time
+}
+
+func NewRollout() *Rollout {
+	return &Rollout{
+		firstSeen: map[string]time.Time{},
+		lastSeen:  map[string]time.Time{},
+	}
+}
+
+func NormalizeVersion(version string) string {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return UnknownVersion
+	}
+	return version
+}
+
+func (r *Rollout) Observe(at time.Time, versions ...string) {
+	for _, version := range versions {
+		version = NormalizeVersion(version)
+		if first, ok := r.firstSeen[version]; !ok || at.Before(first) {
+			r.firstSeen[version] = at
+		}
+		if last, ok := r.lastSeen[version]; !ok || at.After(last) {
+			r.lastSeen[version] = at
+		}
+	}
+}
+
+func (r *Rollout) ObserveWorkers(at time.Time, workers []models.WorkerInfo) {
+	for _, worker := range workers {
+		r.Observe(at, worker.Version)
+	}
+}
+
+func (r *Rollout) FirstSeen(version string) (time.Time, bool) {
+	at, ok := r.firstSeen[NormalizeVersion(version)]
+	return at, ok
+}
+
+func (r *Rollout) Groups(workers []models.WorkerInfo) []VersionGroup {
+	byVersion := map[string]*VersionGroup{}
+	for _, worker := range workers {
+		version := NormalizeVersion(worker.Version)
+		group, ok := byVersion[version]
+		if !ok {
+			group = &VersionGroup{
+				Version:   version,
+				FirstSeen: r.firstSeen[version],
+				LastSeen:  r.lastSeen[version],
+			}
+			byVersion[version] = group
+		}
+		group.Workers = append(group.Workers, worker)
+	}
+	groups := make([]VersionGroup, 0, len(byVersion))
+	for _, group := range byVersion {
+		sort.Slice(group.Workers, func(i, j int) bool {
+			return group.Workers[i].WorkerID < group.Workers[j].WorkerID
+		})
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return NewerVersion(groups[i], groups[j])
+	})
+	return groups
+}
+
+func NewerVersion(a, b VersionGroup) bool {
+	if (a.Version == UnknownVersion) != (b.Version == UnknownVersion) {
+		return b.Version == UnknownVersion
+	}
+	if !a.FirstSeen.Equal(b.FirstSeen) {
+		return a.FirstSeen.After(b.FirstSeen)
+	}
+	if cmp := CompareVersions(a.Version, b.Version); cmp != 0 {
+		return cmp > 0
+	}
+	return a.Version < b.Version
+}
+
+// CompareVersions orders versions by semver precedence, loosened for
+// release strings that are not strict semver: a leading "v" and build
+// metadata after "+" are ignored, release fields compare numerically when
+// both are numbers, and a version with a "-" prerelease suffix sorts below
+// the same version without one ("1.2.0-rc1" < "1.2.0").
+func CompareVersions(a, b string) int {
+	leftRelease, leftPre := splitVersion(a)
+	rightRelease, rightPre := splitVersion(b)
+	if cmp := compareFields(leftRelease, rightRelease); cmp != 0 {
+		return cmp
+	}
+	switch {
+	case leftPre == nil && rightPre == nil:
+		return 0
+	case leftPre == nil:
+		return 1
+	case rightPre == nil:
+		return -1
+	}
+	return compareFields(leftPre, rightPre)
+}
+
+func splitVersion(version string) (release, prerelease []string) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, _, _ = strings.Cut(version, "+")
+	version, pre, hasPre := strings.Cut(version, "-")
+	release = strings.Split(version, ".")
+	if hasPre {
+		prerelease = strings.Split(pre, ".")
+	}
+	return release, prerelease
+}
+
+// compareFields compares dot-separated fields: numbers numerically and below
+// any text, text lexically, and a shorter list below a longer one it prefixes.
+func compareFields(left, right []string) int {
+	for i := 0; i < len(left) && i < len(right); i++ {
+		l, r := left[i], right[i]
+		if l == r {
+			continue
+		}
+		ln, lerr := strconv.Atoi(l)
+		rn, rerr := strconv.Atoi(r)
+		switch {
+		case lerr == nil && rerr == nil:
+			if ln < rn {
+				return -1
+			}
+			if ln > rn {
+				return 1
+			}
+		case lerr == nil:
+			return -1
+		case rerr == nil:
+			return 1
+		case l < r:
+			return -1
+		default:
+			return 1
+		}
+	}
+	switch {
+	case len(left) < len(right):
+		return -1
+	case len(left) > len(right):
+		return 1
+	}
+	return 0
+}
+
+func (r *Rollout) Clear() {
+	r.firstSeen = map[string]time.Time{}
+	r.lastSeen = map[string]time.Time{}
+}
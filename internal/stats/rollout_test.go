@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+func TestRolloutGroupsNewestVersionFirst(t *testing.T) {
+	rollout := NewRollout()
+	base := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	initial := []models.WorkerInfo{
+		{WorkerID: "w-2", Version: "1.9.0"},
+		{WorkerID: "w-1", Version: "1.10.0"},
+		{WorkerID: "w-3", Version: ""},
+	}
+	rollout.ObserveWorkers(base, initial)
+
+	groups := rollout.Groups(initial)
+	if len(groups) != 3 || groups[0].Version != "1.10.0" || groups[1].Version != "1.9.0" || groups[2].Version != UnknownVersion {
+		t.Fatalf("unexpected initial groups: %+v", groups)
+	}
+
+	deployed := []models.WorkerInfo{
+		{WorkerID: "w-1", Version: "1.10.0"},
+		{WorkerID: "w-2", Version: "1.8.5"},
+		{WorkerID: "w-3", Version: "1.10.0"},
+	}
+	rollout.ObserveWorkers(base.Add(time.Minute), deployed)
+	groups = rollout.Groups(deployed)
+	if len(groups) != 2 || groups[0].Version != "1.8.5" {
+		t.Fatalf("expected most recently seen version first, got %+v", groups)
+	}
+	if !groups[0].FirstSeen.Equal(base.Add(time.Minute)) || !groups[1].FirstSeen.Equal(base) {
+		t.Fatalf("unexpected first seen times: %+v", groups)
+	}
+	if len(groups[1].Workers) != 2 || groups[1].Workers[0].WorkerID != "w-1" {
+		t.Fatalf("unexpected workers for 1.10.0: %+v", groups[1].Workers)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.0", "1.2.0", 0},
+		{"v1.10.0", "1.9.3", 1},
+		{"1.2", "1.2.1", -1},
+		{"2024.01.15-abc", "2024.01.15-abd", -1},
+		{"1.2.0-rc1", "1.2.0", -1},
+		{"1.2.0", "1.2.0-rc1", 1},
+		{"demo-prev", "demo", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.2", "1.0.0-alpha.10", -1},
+		{"1.0.0-1", "1.0.0-alpha", -1},
+		{"1.0.0-rc.1", "1.0.0-beta.11", 1},
+		{"1.2.0+build.5", "v1.2.0", 0},
+		{"1.3.0-rc1", "1.2.9", 1},
+	}
+	for _, tc := range cases {
+		if got := CompareVersions(tc.a, tc.b); got != tc.want {
+			t.Fatalf("CompareVersions(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
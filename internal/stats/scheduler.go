@@ -0,0 +1,120 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+const (
+	SchedulerModeBeat   = "beat"
+	SchedulerModePgCron = "pg_cron"
+)
+
+type SchedulerSeverity int
+
+const (
+	SchedulerInfo SchedulerSeverity = iota
+	SchedulerWarn
+	SchedulerBroken
+)
+
+type SchedulerIssue struct {
+	Severity SchedulerSeverity
+	Summary  string
+	Detail   string
+}
+
+type SchedulerDiagnosis struct {
+	Status  *models.SchedulerStatus
+	Issues  []SchedulerIssue
+	Enabled int
+	Overdue []PeriodicStatus
+	// Broken is set when the configuration cannot enqueue periodic tasks or
+	// every enabled periodic task is overdue.
+	Broken bool
+}
+
+func (d SchedulerDiagnosis) Severity() SchedulerSeverity {
+	worst := SchedulerInfo
+	for _, issue := range d.Issues {
+		if issue.Severity > worst {
+			worst = issue.Severity
+		}
+	}
+	return worst
+}
+
+// DiagnoseScheduler explains scheduler misconfigurations reported by Django
+// and correlates them with overdue periodic tasks.
+func DiagnoseScheduler(status *models.SchedulerStatus, periodic []PeriodicStatus) SchedulerDiagnosis {
+	diag := SchedulerDiagnosis{Status: status}
+	for _, item := range periodic {
+		if !item.Task.Enabled {
+			continue
+		}
+		diag.Enabled++
+		if item.Missed {
+			diag.Overdue = append(diag.Overdue, item)
+		}
+	}
+	add := func(severity SchedulerSeverity, summary, detail string) {
+		diag.Issues = append(diag.Issues, SchedulerIssue{Severity: severity, Summary: summary, Detail: detail})
+	}
+	if status == nil {
+		if len(diag.Overdue) > 0 {
+			add(SchedulerWarn, "Scheduler status not reported",
+				"Django did not include scheduler details; upgrade reproq-django to see why tasks are overdue.")
+		}
+	} else {
+		mode := strings.ToLower(strings.TrimSpace(status.Mode))
+		switch mode {
+		case SchedulerModeBeat:
+			switch {
+			case status.BeatConfigured && !status.BeatEnabled:
+				add(SchedulerBroken, "Beat is configured but not enabled",
+					"Periodic tasks have schedules but no beat process is enqueuing them. Start the beat worker or enable beat in settings.")
+			case !status.BeatConfigured && diag.Enabled > 0:
+				add(SchedulerWarn, "Beat mode without a beat schedule",
+					"Scheduler mode is beat but no beat schedule is configured, so enabled periodic tasks may never be enqueued.")
+			}
+		case SchedulerModePgCron:
+			if !status.PgCronAvailable {
+				add(SchedulerBroken, "pg_cron extension unavailable",
+					"Scheduler mode is pg_cron but the extension is not installed or not loaded. Run CREATE EXTENSION pg_cron or switch to beat.")
+			}
+			if status.BeatConfigured && !status.BeatEnabled {
+				add(SchedulerInfo, "Beat schedule configured but unused",
+					"pg_cron enqueues periodic tasks; the beat schedule is ignored while beat is disabled.")
+			}
+		case "", "none", "disabled", "off":
+			if diag.Enabled > 0 {
+				add(SchedulerBroken, "No scheduler running",
+					fmt.Sprintf("%d enabled periodic task(s) exist but no scheduler mode is active.", diag.Enabled))
+			}
+		default:
+			add(SchedulerInfo, "Unknown scheduler mode "+status.Mode, "reproq-tui cannot check this mode's configuration.")
+		}
+		if status.LowMemory {
+			add(SchedulerInfo, "Low-memory mode",
+				"Worker metrics and the events stream are off, so overdue runs are detected from stats polling only and per-run events are not shown.")
+		}
+		if warning := strings.TrimSpace(status.Warning); warning != "" {
+			add(SchedulerWarn, "Server warning", warning)
+		}
+	}
+	if overdue := len(diag.Overdue); overdue > 0 {
+		if diag.Severity() < SchedulerWarn {
+			add(SchedulerWarn, fmt.Sprintf("%d periodic task(s) overdue", overdue),
+				"The scheduler looks configured, so check the beat or pg_cron logs for errors.")
+		}
+		if overdue == diag.Enabled {
+			diag.Broken = true
+		}
+	}
+	if diag.Severity() == SchedulerBroken {
+		diag.Broken = true
+	}
+	return diag
+}
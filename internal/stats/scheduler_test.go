@@ -0,0 +1,84 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+func TestDiagnoseScheduler(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 1, 0, 0, time.UTC)
+	onTime := AnalyzePeriodic(models.PeriodicTask{
+		Name: "cleanup", CronExpr: "*/5 * * * *", Enabled: true,
+		NextRunAt: time.Date(2025, 6, 1, 12, 5, 0, 0, time.UTC),
+	}, now, time.UTC, 0)
+	late := AnalyzePeriodic(models.PeriodicTask{
+		Name: "sync", CronExpr: "*/5 * * * *", Enabled: true,
+		NextRunAt: time.Date(2025, 6, 1, 11, 50, 0, 0, time.UTC),
+	}, now, time.UTC, 0)
+
+	cases := []struct {
+		name     string
+		status   *models.SchedulerStatus
+		periodic []PeriodicStatus
+		severity SchedulerSeverity
+		broken   bool
+		summary  string
+	}{
+		{
+			name:     "healthy pg_cron in low memory",
+			status:   &models.SchedulerStatus{Mode: "pg_cron", PgCronAvailable: true, LowMemory: true},
+			periodic: []PeriodicStatus{onTime},
+			severity: SchedulerInfo,
+			summary:  "Low-memory mode",
+		},
+		{
+			name:     "beat configured but off",
+			status:   &models.SchedulerStatus{Mode: "beat", BeatConfigured: true},
+			periodic: []PeriodicStatus{onTime, late},
+			severity: SchedulerBroken,
+			broken:   true,
+			summary:  "Beat is configured but not enabled",
+		},
+		{
+			name:     "pg_cron missing",
+			status:   &models.SchedulerStatus{Mode: "pg_cron"},
+			severity: SchedulerBroken,
+			broken:   true,
+			summary:  "pg_cron extension unavailable",
+		},
+		{
+			name:     "configured but overdue",
+			status:   &models.SchedulerStatus{Mode: "beat", BeatConfigured: true, BeatEnabled: true},
+			periodic: []PeriodicStatus{onTime, late},
+			severity: SchedulerWarn,
+			summary:  "1 periodic task(s) overdue",
+		},
+		{
+			name:     "everything overdue",
+			status:   &models.SchedulerStatus{Mode: "beat", BeatConfigured: true, BeatEnabled: true},
+			periodic: []PeriodicStatus{late},
+			severity: SchedulerWarn,
+			broken:   true,
+			summary:  "1 periodic task(s) overdue",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			diag := DiagnoseScheduler(tc.status, tc.periodic)
+			if diag.Severity() != tc.severity || diag.Broken != tc.broken {
+				t.Fatalf("expected severity %d broken %v, got %+v", tc.severity, tc.broken, diag)
+			}
+			found := false
+			for _, issue := range diag.Issues {
+				if issue.Summary == tc.summary {
+					found = true
+				}
+			}
+			if !found {
+				t.Fatalf("expected issue %q, got %+v", tc.summary, diag.Issues)
+			}
+		})
+	}
+}
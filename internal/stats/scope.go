@@ -0,0 +1,76 @@
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+func DatabaseAliases(stats models.DjangoStats) []string {
+	aliases := make([]string, 0, len(stats.Databases))
+	for _, db := range stats.Databases {
+		if db.Alias != "" {
+			aliases = append(aliases, db.Alias)
+		}
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// ScopeToDatabase narrows stats to one database alias. Worker health is
+// recounted from the database's workers, treating those last seen before
+// staleBefore as dead. TopFailing is not reported per database, so the
+// fleet-wide list is kept and callers should label it as such.
+func ScopeToDatabase(stats models.DjangoStats, alias string, staleBefore time.Time) (models.DjangoStats, bool) {
+	if alias == "" {
+		return stats, true
+	}
+	scoped := models.DjangoStats{
+		Tasks:         map[string]int64{},
+		Queues:        map[string]map[string]int64{},
+		QueueControls: []models.QueueControl{},
+		Databases:     []models.DatabaseStats{},
+		Scheduler:     stats.Scheduler,
+		TopFailing:    stats.TopFailing,
+		FetchedAt:     stats.FetchedAt,
+	}
+	found := false
+	for _, db := range stats.Databases {
+		if db.Alias != alias {
+			continue
+		}
+		found = true
+		if db.Tasks != nil {
+			scoped.Tasks = db.Tasks
+		}
+		if db.Queues != nil {
+			scoped.Queues = db.Queues
+		}
+		scoped.Workers = db.Workers
+		if stats.WorkerHealth != nil {
+			scoped.WorkerHealth = workerHealth(db.Workers, staleBefore)
+		}
+		scoped.Periodic = db.Periodic
+		scoped.Databases = []models.DatabaseStats{db}
+		break
+	}
+	for _, control := range stats.QueueControls {
+		if control.Database == alias {
+			scoped.QueueControls = append(scoped.QueueControls, control)
+		}
+	}
+	return scoped, found
+}
+
+func workerHealth(workers []models.WorkerInfo, staleBefore time.Time) *models.WorkerHealth {
+	health := &models.WorkerHealth{}
+	for _, worker := range workers {
+		if worker.LastSeenAt.IsZero() || worker.LastSeenAt.Before(staleBefore) {
+			health.Dead++
+			continue
+		}
+		health.Alive++
+	}
+	return health
+}
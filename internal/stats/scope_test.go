@@ -0,0 +1,55 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+func TestScopeToDatabase(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	all := models.DjangoStats{
+		Tasks:        map[string]int64{"READY": 10},
+		WorkerHealth: &models.WorkerHealth{Alive: 4},
+		TopFailing:   []models.FailingTask{{TaskPath: "app.tasks.sync", Count: 3}},
+		Scheduler:    &models.SchedulerStatus{Mode: "beat"},
+		QueueControls: []models.QueueControl{
+			{QueueName: "default", Paused: true, Database: "tenant_a"},
+			{QueueName: "default", Paused: true, Database: "tenant_b"},
+		},
+		Databases: []models.DatabaseStats{
+			{Alias: "tenant_b", Tasks: map[string]int64{"READY": 7}},
+			{
+				Alias:    "tenant_a",
+				Tasks:    map[string]int64{"READY": 3},
+				Queues:   map[string]map[string]int64{"default": {"READY": 3}},
+				Workers:  []models.WorkerInfo{{WorkerID: "w1", LastSeenAt: now}, {WorkerID: "w2", LastSeenAt: now.Add(-time.Hour)}},
+				Periodic: []models.PeriodicTask{{Name: "cleanup"}},
+			},
+		},
+	}
+
+	if aliases := DatabaseAliases(all); len(aliases) != 2 || aliases[0] != "tenant_a" {
+		t.Fatalf("unexpected aliases %v", aliases)
+	}
+	scoped, ok := ScopeToDatabase(all, "tenant_a", now.Add(-time.Minute))
+	if !ok {
+		t.Fatalf("expected tenant_a to be found")
+	}
+	if scoped.Tasks["READY"] != 3 || len(scoped.Queues) != 1 || len(scoped.Workers) != 2 || len(scoped.Periodic) != 1 {
+		t.Fatalf("unexpected scoped stats %+v", scoped)
+	}
+	if len(scoped.QueueControls) != 1 || scoped.QueueControls[0].Database != "tenant_a" {
+		t.Fatalf("expected only tenant_a queue controls, got %+v", scoped.QueueControls)
+	}
+	if scoped.WorkerHealth == nil || scoped.WorkerHealth.Alive != 1 || scoped.WorkerHealth.Dead != 1 {
+		t.Fatalf("expected worker health recounted for tenant_a, got %+v", scoped.WorkerHealth)
+	}
+	if len(scoped.TopFailing) != 1 || scoped.Scheduler == nil || len(scoped.Databases) != 1 {
+		t.Fatalf("unexpected scoped rollups %+v", scoped)
+	}
+	if _, ok := ScopeToDatabase(all, "missing", now); ok {
+		t.Fatalf("expected missing alias to report not found")
+	}
+}
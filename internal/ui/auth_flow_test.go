@@ -2,6 +2,7 @@ package ui
 
 import (
 	"net/http"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -133,7 +134,7 @@ func TestAuthApprovedUpdatesTokenAndPersists(t *testing.T) {
 	model.authFlowActive = true
 
 	expires := time.Now().Add(time.Hour)
-	updated, _ := model.Update(authStatusMsg{
+	updated, cmd := model.Update(authStatusMsg{
 		status: auth.PairStatus{
 			Status:    "approved",
 			Token:     "token-123",
@@ -141,6 +142,13 @@ func TestAuthApprovedUpdatesTokenAndPersists(t *testing.T) {
 		},
 	})
 	model = updated.(*Model)
+	store := auth.NewStore(authPath)
+	if _, err := store.Load(); err == nil {
+		t.Fatalf("expected the token to be saved by a command, not during Update")
+	}
+	// The store write is the first command finishSignIn batches.
+	updated, _ = model.Update(cmd().(tea.BatchMsg)[0]())
+	model = updated.(*Model)
 
 	if model.authFlowActive {
 		t.Fatalf("expected auth flow to finish")
@@ -148,11 +156,10 @@ func TestAuthApprovedUpdatesTokenAndPersists(t *testing.T) {
 	if model.authToken.Value != "token-123" {
 		t.Fatalf("expected auth token to be applied")
 	}
-	if !model.client.HasHeader("Authorization") {
+	if !model.endpoints.django.HasHeader("Authorization") {
 		t.Fatalf("expected authorization header to be set")
 	}
 
-	store := auth.NewStore(authPath)
 	stored, err := store.Load()
 	if err != nil {
 		t.Fatalf("load auth token: %v", err)
@@ -198,7 +205,7 @@ func TestAuthKeySignsOutClearsToken(t *testing.T) {
 	model := newTestModel(t, cfg)
 
 	expires := time.Now().Add(time.Hour)
-	updated, _ := model.Update(authStatusMsg{
+	updated, cmd := model.Update(authStatusMsg{
 		status: auth.PairStatus{
 			Status:    "approved",
 			Token:     "token-123",
@@ -206,8 +213,18 @@ func TestAuthKeySignsOutClearsToken(t *testing.T) {
 		},
 	})
 	model = updated.(*Model)
+	// The store write is the first command finishSignIn batches.
+	updated, _ = model.Update(cmd().(tea.BatchMsg)[0]())
+	model = updated.(*Model)
+	store := auth.NewStore(authPath)
+	if _, err := store.Load(); err != nil {
+		t.Fatalf("expected token to be stored: %v", err)
+	}
 
-	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	model = updated.(*Model)
+	// Clearing the store is the first command of the sign-out batch.
+	updated, _ = model.Update(cmd().(tea.BatchMsg)[0]())
 	model = updated.(*Model)
 
 	if model.authToken.Value != "" {
@@ -217,8 +234,36 @@ func TestAuthKeySignsOutClearsToken(t *testing.T) {
 		t.Fatalf("expected signed out toast, got %q", model.toast)
 	}
 
-	store := auth.NewStore(authPath)
 	if _, err := store.Load(); err == nil {
 		t.Fatalf("expected auth token to be cleared from store")
 	}
 }
+
+func TestAuthSaveKeepsNewestTokenAndReportsFailure(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoURL = "https://django.example.com"
+	model := newTestModel(t, cfg)
+
+	blocker := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatalf("write blocker: %v", err)
+	}
+	model.authStore = auth.NewStore(filepath.Join(blocker, "auth.json"))
+
+	expires := time.Now().Add(time.Hour)
+	older := model.applyAuthToken(auth.Token{Value: "first", ExpiresAt: expires})
+	newer := model.applyAuthToken(auth.Token{Value: "second", ExpiresAt: expires})
+	if msg := older(); msg != nil {
+		t.Fatalf("expected a superseded save to be skipped, got %#v", msg)
+	}
+	msg, ok := newer().(authSaveMsg)
+	if !ok || msg.err == nil {
+		t.Fatalf("expected the newest save to run and fail, got %#v", msg)
+	}
+	updated, _ := model.Update(msg)
+	model = updated.(*Model)
+	if model.authToken.Value != "second" || !strings.Contains(model.toast, "Auth save failed") {
+		t.Fatalf("expected token kept in memory and a save failure toast, got %q / %q", model.authToken.Value, model.toast)
+	}
+}
@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/auth"
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/pkg/client"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	authExpiryCheckInterval = 15 * time.Second
+	authRefreshRetryDelay   = time.Minute
+)
+
+// authExpiryNotice tracks which expiry warning has been shown so each one
+// fires once per token.
+type authExpiryNotice int
+
+const (
+	authExpiryNone authExpiryNotice = iota
+	authExpirySoon
+	authExpiryExpired
+)
+
+type authExpiryTickMsg struct{}
+
+type authRefreshMsg struct {
+	token auth.Token
+	err   error
+}
+
+func authExpiryTickCmd() tea.Cmd {
+	return tea.Tick(authExpiryCheckInterval, func(time.Time) tea.Msg {
+		return authExpiryTickMsg{}
+	})
+}
+
+func refreshAuthCmd(cfg config.Config, httpClient *client.Client) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+		defer cancel()
+		token, err := auth.RefreshToken(ctx, httpClient, cfg.DjangoURL)
+		return authRefreshMsg{token: token, err: err}
+	}
+}
+
+// refreshCmd renews through the identity provider when the token came from
+// the device flow, otherwise through reproq-django.
+func (m *Model) refreshCmd() tea.Cmd {
+	if m.authToken.RefreshToken != "" && deviceConfig(m.cfg).Enabled() {
+		return refreshOAuthCmd(m.cfg, m.deviceConfig, m.authToken.RefreshToken)
+	}
+	return refreshAuthCmd(m.cfg, m.endpoints.django)
+}
+
+// authExpiresSoon reports whether the stored token expires within the
+// configured refresh lead time but has not expired yet.
+func (m *Model) authExpiresSoon(now time.Time) (time.Duration, bool) {
+	if m.authToken.Value == "" || m.authToken.ExpiresAt.IsZero() {
+		return 0, false
+	}
+	remaining := m.authToken.ExpiresAt.Sub(now)
+	return remaining, remaining > 0 && remaining <= m.cfg.AuthRefreshBefore
+}
+
+// checkAuthExpiry refreshes the token ahead of expiry when Django supports it,
+// warns once when it cannot, and falls back to pairing once it has expired.
+func (m *Model) checkAuthExpiry(now time.Time) tea.Cmd {
+	if !m.authHeaderManaged || m.authToken.Value == "" || m.authToken.ExpiresAt.IsZero() || m.authFlowActive {
+		return nil
+	}
+	remaining, soon := m.authExpiresSoon(now)
+	if !soon && remaining > 0 {
+		m.authExpiryNotice = authExpiryNone
+		return nil
+	}
+	if (remaining > 0 || m.authToken.RefreshToken != "") && m.canRefreshAuth(now) {
+		m.authRefreshing = true
+		return m.refreshCmd()
+	}
+	if remaining <= 0 {
+		if m.authExpiryNotice == authExpiryExpired {
+			return nil
+		}
+		m.authExpiryNotice = authExpiryExpired
+		if m.cfg.AutoLogin && m.authEnabled {
+			m.authFlowActive = true
+			m.authPair = auth.Pairing{}
+			return tea.Batch(startAuthCmd(m.cfg, m.endpoints.django), m.showToast("Auth expired: signing in again", 3*time.Second))
+		}
+		return m.showToast(fmt.Sprintf("Auth expired: press %s to sign in", m.keymap.Auth.Help().Key), 5*time.Second)
+	}
+	if m.authExpiryNotice != authExpiryNone || m.authRefreshing {
+		return nil
+	}
+	m.authExpiryNotice = authExpirySoon
+	return m.showToast(fmt.Sprintf("Auth expires in %s: press %s to sign in again", formatRemaining(remaining), m.keymap.Auth.Help().Key), 5*time.Second)
+}
+
+func (m *Model) canRefreshAuth(now time.Time) bool {
+	if !m.authEnabled || m.authRefreshing || m.authRefreshUnsupported || now.Before(m.authRefreshRetryAt) {
+		return false
+	}
+	return m.cfg.DjangoURL != "" || (m.authToken.RefreshToken != "" && deviceConfig(m.cfg).Enabled())
+}
+
+func (m *Model) handleAuthRefresh(msg authRefreshMsg) tea.Cmd {
+	m.authRefreshing = false
+	if msg.err != nil {
+		if errors.Is(msg.err, auth.ErrRefreshUnsupported) || isAuthError(msg.err) || auth.IsOAuthError(msg.err, "invalid_grant", "invalid_client", "unauthorized_client") {
+			m.authRefreshUnsupported = true
+		} else {
+			m.authRefreshRetryAt = time.Now().Add(authRefreshRetryDelay)
+		}
+		return m.checkAuthExpiry(time.Now())
+	}
+	token := msg.token
+	token.Config = m.authToken.Config
+	m.authRefreshRetryAt = time.Time{}
+	return m.applyAuthToken(token)
+}
+
+func formatRemaining(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/auth"
+	"github.com/adpena/reproq-tui/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAuthRefreshUpdatesHeader(t *testing.T) {
+	expires := time.Now().Add(time.Hour).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/reproq/tui/refresh/":
+			if r.Header.Get("Authorization") != "Bearer old" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"token": "new", "expires_at": expires})
+		case "/whoami":
+			_, _ = io.WriteString(w, r.Header.Get("Authorization"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoURL = server.URL
+	model := newTestModel(t, cfg)
+	model.applyAuthToken(auth.Token{Value: "old", ExpiresAt: time.Now().Add(5 * time.Minute), DjangoURL: server.URL})
+
+	if !strings.Contains(model.authStatusSummary(), "Auth expires 4m") {
+		t.Fatalf("expected expiry warning in status, got %q", model.authStatusSummary())
+	}
+	cmd := model.checkAuthExpiry(time.Now())
+	if cmd == nil || !model.authRefreshing {
+		t.Fatalf("expected refresh to start")
+	}
+	_, save := model.Update(cmd())
+	if model.authRefreshing || model.authToken.Value != "new" || model.authToken.ExpiresAt.Unix() != expires {
+		t.Fatalf("expected refreshed token, got %+v", model.authToken)
+	}
+	if save == nil {
+		t.Fatalf("expected the refreshed token to be saved by a command")
+	}
+	model.Update(save())
+	if stored, err := model.authStore.Load(); err != nil || stored.Value != "new" {
+		t.Fatalf("expected refreshed token to be stored, got %+v (%v)", stored, err)
+	}
+	resp, err := model.endpoints.django.Get(context.Background(), server.URL+"/whoami")
+	if err != nil {
+		t.Fatalf("whoami: %v", err)
+	}
+	defer resp.Body.Close()
+	if body, _ := io.ReadAll(resp.Body); string(body) != "Bearer new" {
+		t.Fatalf("expected django client header to be updated, got %q", body)
+	}
+	if cmd := model.checkAuthExpiry(time.Now()); cmd != nil {
+		t.Fatalf("did not expect another refresh for a fresh token")
+	}
+}
+
+func TestAuthRefreshFallsBackToPairing(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoURL = server.URL
+	model := newTestModel(t, cfg)
+	model.applyAuthToken(auth.Token{Value: "old", ExpiresAt: time.Now().Add(3 * time.Minute), DjangoURL: server.URL})
+
+	cmd := model.checkAuthExpiry(time.Now())
+	if cmd == nil {
+		t.Fatalf("expected refresh attempt")
+	}
+	model.Update(cmd())
+	if !model.authRefreshUnsupported {
+		t.Fatalf("expected refresh to be marked unsupported")
+	}
+	if !strings.Contains(model.toast, "Auth expires in 2m: press l to sign in again") {
+		t.Fatalf("expected expiry warning toast, got %q", model.toast)
+	}
+	if cmd := model.checkAuthExpiry(time.Now()); cmd != nil {
+		t.Fatalf("expected a single warning before expiry")
+	}
+
+	if cmd := model.checkAuthExpiry(time.Now().Add(4 * time.Minute)); cmd == nil || !model.authFlowActive {
+		t.Fatalf("expected pairing to start once the token expired")
+	}
+}
+
+func TestAuthKeySignsInAgainWhenTokenExpiresSoon(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoURL = "https://django.example.com"
+	model := newTestModel(t, cfg)
+	model.applyAuthToken(auth.Token{Value: "old", ExpiresAt: time.Now().Add(3 * time.Minute)})
+	model.authRefreshUnsupported = true
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	model = updated.(*Model)
+	if !model.authFlowActive || cmd == nil {
+		t.Fatalf("expected l to start pairing for a token about to expire")
+	}
+	if model.authToken.Value != "old" {
+		t.Fatalf("expected the current token to stay until the new one arrives, got %+v", model.authToken)
+	}
+}
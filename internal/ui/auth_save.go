@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/auth"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// authSaveMsg reports a token store write that ran off the Update goroutine.
+type authSaveMsg struct {
+	cleared bool
+	err     error
+}
+
+// authWriter orders token store writes made from commands. Only the most
+// recently queued write runs once it gets the lock, so a slow keyring or
+// encrypted-file save never lands after a newer token or a sign-out.
+type authWriter struct {
+	queued atomic.Uint64
+	mu     sync.Mutex
+}
+
+// persistAuthCmd saves token, or clears the store when token is empty.
+func (m *Model) persistAuthCmd(token auth.Token) tea.Cmd {
+	if m.authStore == nil {
+		return nil
+	}
+	store, writer := m.authStore, m.authWriter
+	seq := writer.queued.Add(1)
+	return func() tea.Msg {
+		writer.mu.Lock()
+		defer writer.mu.Unlock()
+		if writer.queued.Load() != seq {
+			return nil
+		}
+		if token.Value == "" {
+			return authSaveMsg{cleared: true, err: store.Clear()}
+		}
+		return authSaveMsg{err: store.Save(token)}
+	}
+}
+
+func (m *Model) handleAuthSave(msg authSaveMsg) tea.Cmd {
+	if msg.err == nil {
+		return nil
+	}
+	if msg.cleared {
+		return m.showToast(fmt.Sprintf("Sign out failed: %v", msg.err), 3*time.Second)
+	}
+	return m.showToast(fmt.Sprintf("Auth save failed: %v", msg.err), 3*time.Second)
+}
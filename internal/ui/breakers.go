@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/pkg/client"
+)
+
+// pollBreakers back off polling of endpoints that stop answering; the next
+// tick after a cooldown is the recovery probe.
+type pollBreakers struct {
+	metrics *client.Breaker
+	health  *client.Breaker
+	stats   *client.Breaker
+
+	metricsGap backoffGap
+	healthGap  backoffGap
+}
+
+// backoffGap covers the polls an open breaker pushed back. They are recorded
+// as failures once the next result arrives, so the SLO and health history
+// weigh an outage by how long it lasted rather than how often it was probed.
+type backoffGap struct {
+	from     time.Time
+	interval time.Duration
+	until    time.Time
+}
+
+func newBackoffGap(from time.Time, interval, delay time.Duration) backoffGap {
+	if interval <= 0 || delay <= interval {
+		return backoffGap{}
+	}
+	return backoffGap{from: from, interval: interval, until: from.Add(delay)}
+}
+
+// skipped returns when the polls in the gap would have run, up to at.
+func (g backoffGap) skipped(at time.Time) []time.Time {
+	if g.interval <= 0 {
+		return nil
+	}
+	var out []time.Time
+	for next := g.from.Add(g.interval); next.Before(g.until) && next.Before(at); next = next.Add(g.interval) {
+		out = append(out, next)
+	}
+	return out
+}
+
+func newPollBreakers(cfg config.Config) pollBreakers {
+	breaker := func(interval time.Duration) *client.Breaker {
+		return client.NewBreaker(cfg.BreakerThreshold, 2*interval, cfg.BreakerMaxBackoff)
+	}
+	return pollBreakers{
+		metrics: breaker(cfg.Interval),
+		health:  breaker(cfg.HealthInterval),
+		stats:   breaker(cfg.StatsInterval),
+	}
+}
+
+func (m *Model) breakerBadge(label string, breaker *client.Breaker, now time.Time) string {
+	switch breaker.State(now) {
+	case client.BreakerOpen:
+		wait := breaker.RetryIn(now).Round(time.Second)
+		return m.theme.Styles.StatusDown.Render(label + " down, retry " + formatRemaining(wait))
+	case client.BreakerProbing:
+		return m.theme.Styles.StatusWarn.Render(label + " probing")
+	}
+	return ""
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/internal/health"
+	"github.com/adpena/reproq-tui/pkg/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestMetricsBreakerBacksOffPolling(t *testing.T) {
+	var up atomic.Bool
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if !up.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte("reproq_queue_depth 3\n"))
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = server.URL + "/metrics"
+	cfg.RetryBackoff = time.Millisecond
+	cfg.BreakerThreshold = 2
+	cfg.BreakerMaxBackoff = 30 * time.Second
+	model := newTestModel(t, cfg)
+	defer model.Close()
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 240, Height: 40})
+	model = updated.(*Model)
+
+	poll := func() {
+		t.Helper()
+		updated, _ := model.Update(pollMetricsCmd(cfg, model.endpoints.metrics, model.catalog)())
+		model = updated.(*Model)
+	}
+	poll()
+	if hits.Load() != 3 {
+		t.Fatalf("expected the 502 to be retried twice, got %d requests", hits.Load())
+	}
+	if bar := model.renderStatusBar(); !strings.Contains(bar, "metrics err") {
+		t.Fatalf("expected a single failure to show metrics err, got %q", bar)
+	}
+	poll()
+	bar := model.renderStatusBar()
+	if !strings.Contains(bar, "metrics down, retry 2s") || strings.Contains(bar, "metrics err") {
+		t.Fatalf("expected open breaker in status bar, got %q", bar)
+	}
+	if d := model.breakers.metrics.Delay(cfg.Interval, time.Now()); d <= cfg.Interval {
+		t.Fatalf("expected polling to back off past the interval, got %v", d)
+	}
+
+	up.Store(true)
+	poll()
+	bar = model.renderStatusBar()
+	if strings.Contains(bar, "metrics down") || strings.Contains(bar, "metrics err") {
+		t.Fatalf("expected recovery to clear the breaker, got %q", bar)
+	}
+	if d := model.breakers.metrics.Delay(cfg.Interval, time.Now()); d != cfg.Interval {
+		t.Fatalf("expected normal polling after recovery, got %v", d)
+	}
+}
+
+func TestHealthBackoffCountsSkippedPollsAsDown(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.WorkerHealthURL = "http://worker.local/healthz"
+	cfg.HealthInterval = 5 * time.Second
+	cfg.BreakerThreshold = 1
+	cfg.BreakerMaxBackoff = time.Minute
+	model := newTestModel(t, cfg)
+	defer model.Close()
+
+	send := func(msg healthMsg) {
+		t.Helper()
+		updated, _ := model.Update(msg)
+		model = updated.(*Model)
+	}
+	start := time.Now()
+	send(healthMsg{status: models.HealthStatus{Healthy: true, Status: "ok", CheckedAt: start.Add(-cfg.HealthInterval)}})
+	send(healthMsg{status: models.HealthStatus{CheckedAt: start}, err: &url.Error{Op: "Get", URL: cfg.WorkerHealthURL, Err: errors.New("refused")}})
+	gap := model.breakers.healthGap
+	if gap.until.Sub(gap.from) <= cfg.HealthInterval {
+		t.Fatalf("expected the breaker to push the next poll back, got %v", gap.until.Sub(gap.from))
+	}
+	recovered := gap.until
+	send(healthMsg{status: models.HealthStatus{Healthy: true, Status: "ok", CheckedAt: recovered}})
+
+	skipped := len(gap.skipped(recovered))
+	if skipped == 0 {
+		t.Fatalf("expected the backoff to skip at least one poll")
+	}
+	samples := model.healthHistory.Samples(health.Overall, time.Time{})
+	if len(samples) != 3+skipped {
+		t.Fatalf("expected skipped polls in the history, got %d samples", len(samples))
+	}
+	want := 2 / float64(3+skipped)
+	if ratio, ok := model.slo.Availability(health.SignalHealth, time.Time{}, recovered); !ok || ratio != want {
+		t.Fatalf("expected availability %.3f with skipped polls counted as down, got %.3f", want, ratio)
+	}
+	if model.breakers.healthGap != (backoffGap{}) {
+		t.Fatalf("expected the gap to be cleared once recorded")
+	}
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/adpena/reproq-tui/internal/control"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type confirmPrompt struct {
+	message string
+	run     func(reason string) tea.Cmd
+}
+
+func (m *Model) openConfirm(message string, run func(reason string) tea.Cmd) {
+	m.confirm = &confirmPrompt{message: message, run: run}
+	m.confirmReason.SetValue("")
+	m.confirmReason.Focus()
+}
+
+func (m *Model) handleConfirmInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.confirm = nil
+		m.confirmReason.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		prompt := m.confirm
+		m.confirm = nil
+		m.confirmReason.Blur()
+		return m, prompt.run(strings.TrimSpace(m.confirmReason.Value()))
+	}
+	var cmd tea.Cmd
+	m.confirmReason, cmd = m.confirmReason.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) renderConfirmPrompt() []string {
+	if m.confirm == nil {
+		return nil
+	}
+	return []string{
+		m.theme.Styles.StatusWarn.Render(m.confirm.message),
+		m.labelValue("Reason", m.confirmReason.View()),
+		m.theme.Styles.Muted.Render("enter to confirm | esc to cancel"),
+		"",
+	}
+}
+
+func (m *Model) recordAudit(entry control.AuditEntry) {
+	if m.audit == nil {
+		return
+	}
+	// A failed write stays flagged in the status bar until one succeeds, so
+	// actions taken meanwhile are known to be missing from the log.
+	m.auditErr = m.audit.Record(entry)
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/stats"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func (m *Model) applyDatabaseScope() {
+	ref := m.rawStats.FetchedAt
+	if ref.IsZero() {
+		ref = time.Now()
+	}
+	scoped, found := stats.ScopeToDatabase(m.withPendingQueueControls(m.rawStats), m.dbScope, m.workerActiveCutoff(ref))
+	m.lastStats = scoped
+	m.dbScopeMissing = m.dbScope != "" && !found
+	m.analyzePeriodic()
+}
+
+func (m *Model) cycleDatabaseScope() tea.Cmd {
+	aliases := stats.DatabaseAliases(m.rawStats)
+	if len(aliases) == 0 && m.dbScope == "" {
+		m.toast = "No per-database stats reported"
+	} else {
+		next := ""
+		if m.dbScope == "" {
+			next = aliases[0]
+		} else {
+			for i, alias := range aliases {
+				if alias == m.dbScope && i+1 < len(aliases) {
+					next = aliases[i+1]
+					break
+				}
+			}
+		}
+		m.dbScope = next
+		m.applyDatabaseScope()
+		m.toast = "Scope: all databases"
+		if next != "" {
+			m.toast = "Scope: " + next
+		}
+	}
+	m.toastExpiry = time.Now().Add(2 * time.Second)
+	return tea.Tick(2*time.Second, func(time.Time) tea.Msg {
+		return toastClearMsg{}
+	})
+}
+
+func (m *Model) scopedTaskKey(status string) string {
+	if m.dbScope != "" {
+		return stats.DatabaseKey(m.dbScope, status)
+	}
+	return stats.TaskKey(status)
+}
+
+func (m *Model) scopedQueueKey(queue, status string) string {
+	if m.dbScope != "" {
+		return stats.DatabaseQueueKey(m.dbScope, queue, status)
+	}
+	return stats.QueueKey(queue, status)
+}
+
+func (m *Model) databaseScopeBadge() string {
+	if m.dbScope == "" {
+		return ""
+	}
+	if m.dbScopeMissing {
+		return m.theme.Styles.StatusWarn.Render("db " + m.dbScope + " (missing)")
+	}
+	return m.theme.Styles.AccentAlt.Render("db " + m.dbScope)
+}
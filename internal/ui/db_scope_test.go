@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/pkg/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDatabaseScopeCyclesAndScopesPanels(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoStatsURL = "http://django.local/reproq/stats/"
+	model := newTestModel(t, cfg)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	model = updated.(*Model)
+
+	now := time.Now()
+	updated, _ = model.Update(statsMsg{attempted: now, stats: models.DjangoStats{
+		FetchedAt:    now,
+		Tasks:        map[string]int64{"FAILED": 9},
+		Queues:       map[string]map[string]int64{"default": {"READY": 5}, "bulk": {"READY": 4}},
+		WorkerHealth: &models.WorkerHealth{Alive: 3, Dead: 1},
+		TopFailing:   []models.FailingTask{{TaskPath: "app.tasks.sync", Count: 4}},
+		Databases: []models.DatabaseStats{
+			{
+				Alias:   "tenant_a",
+				Tasks:   map[string]int64{"FAILED": 2},
+				Queues:  map[string]map[string]int64{"default": {"READY": 5}},
+				Workers: []models.WorkerInfo{{WorkerID: "w1", LastSeenAt: now}, {WorkerID: "w2", LastSeenAt: now.Add(-time.Hour)}},
+			},
+			{Alias: "tenant_b", Tasks: map[string]int64{"FAILED": 7}, Queues: map[string]map[string]int64{"bulk": {"READY": 4}}},
+		},
+	}})
+	model = updated.(*Model)
+
+	press := func() {
+		updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+		model = updated.(*Model)
+	}
+
+	press()
+	if model.dbScope != "tenant_a" {
+		t.Fatalf("expected tenant_a scope, got %q", model.dbScope)
+	}
+	if failed, _ := model.statsTaskCount("FAILED"); failed != 2 {
+		t.Fatalf("expected scoped failed count, got %d", failed)
+	}
+	if summaries := model.statsQueueSummaries(); len(summaries) != 1 || summaries[0].Name != "default" {
+		t.Fatalf("expected scoped queues, got %v", summaries)
+	}
+	if !strings.Contains(model.renderStatusBar(), "db tenant_a") {
+		t.Fatalf("expected scope badge in status bar")
+	}
+	if alive, dead, ok := model.statsWorkerHealthCounts(); !ok || alive != 1 || dead != 1 {
+		t.Fatalf("expected worker health for tenant_a, got %d/%d (%v)", alive, dead, ok)
+	}
+	if paths := model.failingTaskPaths(); len(paths) != 1 {
+		t.Fatalf("expected top failing tasks to survive scoping, got %v", paths)
+	}
+
+	press()
+	press()
+	if model.dbScope != "" {
+		t.Fatalf("expected scope to cycle back to all databases, got %q", model.dbScope)
+	}
+	if failed, _ := model.statsTaskCount("FAILED"); failed != 9 {
+		t.Fatalf("expected global failed count, got %d", failed)
+	}
+	if strings.Contains(model.renderStatusBar(), "db tenant") {
+		t.Fatalf("expected no scope badge for all databases")
+	}
+}
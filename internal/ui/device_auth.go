@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/auth"
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/pkg/client"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type deviceAuthMsg struct {
+	cfg    auth.DeviceConfig
+	device auth.DeviceAuthorization
+	err    error
+}
+
+type deviceTokenMsg struct {
+	token auth.Token
+	err   error
+}
+
+func deviceConfig(cfg config.Config) auth.DeviceConfig {
+	return auth.DeviceConfig{Issuer: cfg.OIDCIssuer, ClientID: cfg.OIDCClientID, Scopes: cfg.OIDCScopes}
+}
+
+// idpClient talks to the identity provider without the worker headers or
+// client certificate, so worker credentials never leak to the IdP. The CA
+// bundle still applies since private IdPs usually share it.
+func idpClient(cfg config.Config) *client.Client {
+	return client.New(client.Options{Timeout: cfg.Timeout, InsecureSkipVerify: cfg.InsecureSkipVerify, CAFile: cfg.CAFile})
+}
+
+func startDeviceCmd(cfg config.Config) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*cfg.Timeout)
+		defer cancel()
+		httpClient := idpClient(cfg)
+		resolved, err := auth.DiscoverDevice(ctx, httpClient, deviceConfig(cfg))
+		if err != nil {
+			return deviceAuthMsg{err: err}
+		}
+		device, err := auth.StartDevice(ctx, httpClient, resolved)
+		return deviceAuthMsg{cfg: resolved, device: device, err: err}
+	}
+}
+
+func pollDeviceCmd(cfg config.Config, resolved auth.DeviceConfig, deviceCode string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+		defer cancel()
+		token, err := auth.PollDevice(ctx, idpClient(cfg), resolved, deviceCode)
+		return deviceTokenMsg{token: token, err: err}
+	}
+}
+
+func refreshOAuthCmd(cfg config.Config, resolved auth.DeviceConfig, refreshToken string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*cfg.Timeout)
+		defer cancel()
+		httpClient := idpClient(cfg)
+		if resolved.TokenURL == "" {
+			var err error
+			resolved, err = auth.DiscoverDevice(ctx, httpClient, deviceConfig(cfg))
+			if err != nil {
+				return authRefreshMsg{err: err}
+			}
+		}
+		token, err := auth.RefreshOAuthToken(ctx, httpClient, resolved, refreshToken)
+		return authRefreshMsg{token: token, err: err}
+	}
+}
+
+func deviceTickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return authTickMsg{}
+	})
+}
+
+func (m *Model) handleDeviceAuth(msg deviceAuthMsg) tea.Cmd {
+	if !m.authFlowActive {
+		return nil
+	}
+	if msg.err != nil {
+		m.authErr = msg.err
+		m.authFlowActive = false
+		m.authPair = auth.Pairing{}
+		m.deviceAuth = auth.DeviceAuthorization{}
+		return m.showToast(fmt.Sprintf("Auth start failed: %v", msg.err), 3*time.Second)
+	}
+	m.deviceConfig = msg.cfg
+	m.deviceAuth = msg.device
+	m.authPair = auth.Pairing{Code: msg.device.UserCode, VerifyURL: msg.device.VerifyURL, ExpiresAt: msg.device.ExpiresAt}
+	m.authErr = nil
+	return deviceTickCmd(msg.device.Interval)
+}
+
+func (m *Model) handleDeviceToken(msg deviceTokenMsg) tea.Cmd {
+	if !m.authFlowActive || m.deviceAuth.DeviceCode == "" {
+		return nil
+	}
+	if msg.err == nil {
+		return m.finishSignIn(msg.token)
+	}
+	if auth.IsOAuthError(msg.err, "authorization_pending", "slow_down") {
+		m.deviceAuth.Interval = auth.NextDeviceInterval(m.deviceAuth.Interval, msg.err)
+		return deviceTickCmd(m.deviceAuth.Interval)
+	}
+	m.authFlowActive = false
+	m.authPair = auth.Pairing{}
+	m.deviceAuth = auth.DeviceAuthorization{}
+	switch {
+	case auth.IsOAuthError(msg.err, "expired_token"):
+		return m.showToast("Auth code expired", 3*time.Second)
+	case auth.IsOAuthError(msg.err, "access_denied"):
+		return m.showToast("Auth denied", 3*time.Second)
+	default:
+		m.authErr = msg.err
+		return m.showToast(fmt.Sprintf("Auth failed: %v", msg.err), 3*time.Second)
+	}
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDeviceLoginFromAuthKey(t *testing.T) {
+	polls := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"device_authorization_endpoint": server.URL + "/device",
+				"token_endpoint":                server.URL + "/token",
+			})
+		case "/device":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"device_code":      "dev-1",
+				"user_code":        "WXYZ-1234",
+				"verification_uri": server.URL + "/activate",
+				"expires_in":       600,
+				"interval":         1,
+			})
+		case "/token":
+			if r.Header.Get("Authorization") != "" {
+				t.Errorf("worker credentials leaked to the IdP")
+			}
+			polls++
+			if polls == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = io.WriteString(w, `{"error":"authorization_pending"}`)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token":  "sso-access",
+				"token_type":    "Bearer",
+				"expires_in":    3600,
+				"refresh_token": "sso-refresh",
+			})
+		case "/whoami":
+			_, _ = io.WriteString(w, r.Header.Get("Authorization"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.OIDCIssuer = server.URL
+	cfg.OIDCClientID = "reproq-tui"
+	model := newTestModel(t, cfg)
+	if !model.authEnabled {
+		t.Fatalf("expected OIDC config to enable auth without a Django URL")
+	}
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if cmd == nil || !model.authFlowActive {
+		t.Fatalf("expected device flow to start")
+	}
+	model.Update(cmd())
+	if model.authPair.Code != "WXYZ-1234" || model.authPair.VerifyURL != server.URL+"/activate" {
+		t.Fatalf("unexpected prompt %+v", model.authPair)
+	}
+
+	_, cmd = model.Update(authTickMsg{})
+	model.Update(cmd())
+	if !model.authFlowActive || model.authToken.Value != "" {
+		t.Fatalf("expected flow to keep waiting while authorization is pending")
+	}
+	_, cmd = model.Update(authTickMsg{})
+	model.Update(cmd())
+	if model.authFlowActive || model.authToken.Value != "sso-access" || model.authToken.RefreshToken != "sso-refresh" {
+		t.Fatalf("expected device token to be applied, got %+v", model.authToken)
+	}
+	resp, err := model.client.Get(context.Background(), server.URL+"/whoami")
+	if err != nil {
+		t.Fatalf("whoami: %v", err)
+	}
+	defer resp.Body.Close()
+	if body, _ := io.ReadAll(resp.Body); string(body) != "Bearer sso-access" {
+		t.Fatalf("expected worker client to carry the access token, got %q", body)
+	}
+
+	model.authToken.ExpiresAt = time.Now().Add(-time.Minute)
+	if cmd := model.checkAuthExpiry(time.Now()); cmd == nil || !model.authRefreshing {
+		t.Fatalf("expected an expired device token to be refreshed with its refresh token")
+	}
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (m *Model) renderDiagnosticsDetail() string {
+	lines := []string{m.theme.Styles.PaneHeader.Render("METRICS")}
+	lines = append(lines, m.labelValue("Last scrape", fmt.Sprintf("%s (%s)", formatRelative(m.lastScrapeAt), formatDuration(m.lastScrapeDelay))))
+	if m.lastScrapeErr != nil {
+		lines = append(lines, m.theme.Styles.StatusWarn.Render(truncate(m.lastScrapeErr.Error(), 60)))
+	}
+	if strings.TrimSpace(m.cfg.WorkerHealthURL) != "" {
+		lines = append(lines, "", m.theme.Styles.PaneHeader.Render("HEALTH"),
+			m.labelValue("Last check", fmt.Sprintf("%s (%s)", formatRelative(m.lastHealth.CheckedAt), formatDuration(m.lastHealth.Latency))),
+		)
+		if m.lastHealthErr != nil {
+			lines = append(lines, m.theme.Styles.StatusWarn.Render(truncate(m.lastHealthErr.Error(), 60)))
+		}
+	}
+	lines = append(lines, "", m.theme.Styles.PaneHeader.Render("STATS"))
+	lines = append(lines, m.renderStatsDiagnostics()...)
+	if m.auditErr != nil {
+		lines = append(lines, "", m.theme.Styles.PaneHeader.Render("AUDIT LOG"),
+			m.theme.Styles.StatusDown.Render(truncate(m.auditErr.Error(), 60)),
+		)
+	}
+	if m.historyErr != nil {
+		lines = append(lines, "", m.theme.Styles.PaneHeader.Render("EVENT HISTORY"),
+			m.theme.Styles.StatusDown.Render(truncate(m.historyErr.Error(), 60)),
+		)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) renderStatsDiagnostics() []string {
+	if !m.statsEnabled {
+		return []string{m.theme.Styles.Muted.Render("No Django stats configured.")}
+	}
+	info := m.lastStatsInfo
+	response := "-"
+	switch {
+	case info.NotModified:
+		response = fmt.Sprintf("%d not modified", info.Status)
+	case info.Delta:
+		response = fmt.Sprintf("%d delta", info.Status)
+	case info.Status != 0:
+		response = fmt.Sprintf("%d full", info.Status)
+	}
+	mode := "full"
+	if m.cfg.StatsDelta {
+		mode = "delta (?since=)"
+	}
+	lines := []string{
+		m.labelValue("Last fetch", fmt.Sprintf("%s (%s)", formatRelative(m.lastStatsAt), formatDuration(m.lastStatsDelay))),
+		m.labelValue("Changed", formatRelative(info.ChangedAt)),
+		m.labelValue("Response", response),
+		m.labelValue("Mode", mode),
+		m.labelValue("Bytes", fmt.Sprintf("%s last, %s total", formatBytes(float64(info.Bytes)), formatBytes(float64(info.TotalBytes)))),
+		m.labelValue("Requests", fmt.Sprintf("%d (%d not modified)", info.Requests, info.NotModifieds)),
+	}
+	validators := []string{}
+	if info.ETag != "" {
+		validators = append(validators, "etag "+info.ETag)
+	}
+	if info.LastModified != "" {
+		validators = append(validators, "last-modified "+info.LastModified)
+	}
+	if len(validators) > 0 {
+		lines = append(lines, m.labelValue("Validators", truncate(strings.Join(validators, ", "), 44)))
+	}
+	if m.lastStatsErr != nil {
+		lines = append(lines, m.theme.Styles.StatusWarn.Render(truncate(m.lastStatsErr.Error(), 60)))
+	}
+	return lines
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/internal/stats"
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+func TestDiagnosticsShowStatsFreshnessAndBytes(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoStatsURL = "http://django.local/reproq/stats/"
+	model := newTestModel(t, cfg)
+
+	now := time.Now()
+	updated, _ := model.Update(statsMsg{
+		attempted: now,
+		latency:   40 * time.Millisecond,
+		stats:     models.DjangoStats{FetchedAt: now},
+		info: stats.FetchInfo{
+			Status:       304,
+			NotModified:  true,
+			TotalBytes:   2048,
+			Requests:     5,
+			NotModifieds: 4,
+			ETag:         `"abc"`,
+			ChangedAt:    now.Add(-2 * time.Minute),
+		},
+	})
+	model = updated.(*Model)
+
+	detail := model.renderDiagnosticsDetail()
+	for _, want := range []string{"STATS", "304 not modified", "0 B last, 2.0 KB total", "5 (4 not modified)", "2m ago", `etag "abc"`} {
+		if !strings.Contains(detail, want) {
+			t.Fatalf("expected %q in diagnostics, got %q", want, detail)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/auth"
+	"github.com/adpena/reproq-tui/internal/config"
+)
+
+// authRecorder serves body and remembers the Authorization each target saw.
+type authRecorder struct {
+	mu   sync.Mutex
+	seen map[string]string
+}
+
+func (r *authRecorder) server(t *testing.T, name, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		r.seen[name] = req.Header.Get("Authorization")
+		r.mu.Unlock()
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (r *authRecorder) get(name string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.seen[name]
+}
+
+func TestEndpointsOnlySeeTheirOwnCredentials(t *testing.T) {
+	recorder := &authRecorder{seen: map[string]string{}}
+	worker := recorder.server(t, "worker", "reproq_tasks_total 1\n")
+	django := recorder.server(t, "django", "{}")
+
+	cfg := config.DefaultConfig()
+	cfg.Headers = map[string]string{"Authorization": "Bearer worker-token"}
+	cfg.WorkerMetricsURL = worker.URL + "/metrics"
+	cfg.DjangoURL = django.URL
+	cfg.DjangoStatsURL = django.URL + "/reproq/stats/"
+	model := newTestModel(t, cfg)
+	if !model.authHeaderManaged {
+		t.Fatalf("expected a worker Authorization header to leave Django login enabled")
+	}
+
+	pollStatsCmd(model.cfg, model.statsFetcher)()
+	if got := recorder.get("django"); got != "" {
+		t.Fatalf("expected the worker token to stay off Django, got %q", got)
+	}
+
+	model.applyAuthToken(auth.Token{Value: "login-token", ExpiresAt: time.Now().Add(time.Hour)})
+	pollMetricsCmd(model.cfg, model.endpoints.metrics, model.catalog)()
+	pollStatsCmd(model.cfg, model.statsFetcher)()
+
+	if got := recorder.get("worker"); got != "Bearer worker-token" {
+		t.Fatalf("expected the worker to keep its static token, got %q", got)
+	}
+	if got := recorder.get("django"); got != "Bearer login-token" {
+		t.Fatalf("expected Django to see only the login token, got %q", got)
+	}
+}
+
+func TestLoginTokenReachesWorkersWithoutOwnCredentials(t *testing.T) {
+	recorder := &authRecorder{seen: map[string]string{}}
+	metricsServer := recorder.server(t, "metrics", "reproq_tasks_total 1\n")
+	healthServer := recorder.server(t, "health", `{"status":"ok"}`)
+	django := recorder.server(t, "django", "{}")
+
+	tokenPath := filepath.Join(t.TempDir(), "health-token")
+	if err := os.WriteFile(tokenPath, []byte("health-token\n"), 0o600); err != nil {
+		t.Fatalf("write token: %v", err)
+	}
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = metricsServer.URL + "/metrics"
+	cfg.WorkerHealthURL = healthServer.URL + "/healthz"
+	cfg.DjangoURL = django.URL
+	cfg.DjangoStatsURL = django.URL + "/reproq/stats/"
+	cfg.Endpoints = map[string]config.EndpointAuth{config.EndpointHealth: {TokenFile: tokenPath}}
+	model := newTestModel(t, cfg)
+
+	model.applyAuthToken(auth.Token{Value: "login-token", ExpiresAt: time.Now().Add(time.Hour)})
+	pollMetricsCmd(model.cfg, model.endpoints.metrics, model.catalog)()
+	pollHealthCmd(model.cfg, model.endpoints.health)()
+	pollStatsCmd(model.cfg, model.statsFetcher)()
+
+	if got := recorder.get("metrics"); got != "Bearer login-token" {
+		t.Fatalf("expected metrics without credentials to get the login token, got %q", got)
+	}
+	if got := recorder.get("health"); got != "Bearer health-token" {
+		t.Fatalf("expected health to see only its own token, got %q", got)
+	}
+	if got := recorder.get("django"); got != "Bearer login-token" {
+		t.Fatalf("expected Django to get the login token, got %q", got)
+	}
+
+	model.clearAuthToken()
+	pollMetricsCmd(model.cfg, model.endpoints.metrics, model.catalog)()
+	if got := recorder.get("metrics"); got != "" {
+		t.Fatalf("expected sign out to clear the worker token, got %q", got)
+	}
+}
@@ -2,52 +2,27 @@ package ui
 
 import (
 	"net/url"
-	"strings"
 )
 
 type sseFilter struct {
 	queue    string
 	workerID string
 	taskID   string
-	local    string
+	query    eventQuery
 }
 
-func parseSSEFilter(input string) sseFilter {
-	filter := sseFilter{}
-	fields := strings.Fields(input)
-	local := make([]string, 0, len(fields))
-	for _, field := range fields {
-		key, val, ok := splitFilterToken(field)
-		if !ok {
-			local = append(local, field)
-			continue
-		}
-		switch key {
-		case "queue":
-			filter.queue = val
-		case "worker", "worker_id":
-			filter.workerID = val
-		case "task", "task_id":
-			filter.taskID = val
-		default:
-			local = append(local, field)
-		}
-	}
-	filter.local = strings.TrimSpace(strings.Join(local, " "))
-	return filter
-}
-
-func splitFilterToken(token string) (string, string, bool) {
-	sep := strings.IndexAny(token, ":=")
-	if sep <= 0 || sep >= len(token)-1 {
-		return "", "", false
-	}
-	key := strings.ToLower(strings.TrimSpace(token[:sep]))
-	val := strings.TrimSpace(token[sep+1:])
-	if key == "" || val == "" {
-		return "", "", false
-	}
-	return key, val, true
+func parseSSEFilter(input string) (sseFilter, error) {
+	query, err := parseEventQuery(input)
+	if err != nil {
+		return sseFilter{}, err
+	}
+	pushed, rest := query.pushdownTerms()
+	return sseFilter{
+		queue:    pushed["queue"],
+		workerID: pushed["worker"],
+		taskID:   pushed["task"],
+		query:    rest,
+	}, nil
 }
 
 func buildEventsURL(base string, filter sseFilter) string {
@@ -1,9 +1,17 @@
 package ui
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
 
 func TestParseSSEFilter(t *testing.T) {
-	filter := parseSSEFilter("queue:default error worker=w1 task=42")
+	filter, err := parseSSEFilter("queue:default error worker=w1 task=42")
+	if err != nil {
+		t.Fatalf("parse filter: %v", err)
+	}
 	if filter.queue != "default" {
 		t.Fatalf("expected queue filter, got %q", filter.queue)
 	}
@@ -13,8 +21,29 @@ func TestParseSSEFilter(t *testing.T) {
 	if filter.taskID != "42" {
 		t.Fatalf("expected task filter, got %q", filter.taskID)
 	}
-	if filter.local != "error" {
-		t.Fatalf("expected local filter 'error', got %q", filter.local)
+	now := time.Now()
+	if !filter.query.match(models.Event{Message: "boom error"}, now) {
+		t.Fatalf("expected local query to match 'error'")
+	}
+	if filter.query.match(models.Event{Message: "ok"}, now) {
+		t.Fatalf("expected local query to reject events without 'error'")
+	}
+}
+
+func TestParseSSEFilterKeepsOrGroupsLocal(t *testing.T) {
+	filter, err := parseSSEFilter("(queue:a OR queue:b) -queue:c")
+	if err != nil {
+		t.Fatalf("parse filter: %v", err)
+	}
+	if filter.queue != "" {
+		t.Fatalf("expected no pushdown for OR/negated terms, got %q", filter.queue)
+	}
+	now := time.Now()
+	if !filter.query.match(models.Event{Queue: "b"}, now) {
+		t.Fatalf("expected queue b to match")
+	}
+	if filter.query.match(models.Event{Queue: "c"}, now) {
+		t.Fatalf("expected queue c to be excluded")
 	}
 }
 
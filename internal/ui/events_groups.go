@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/events"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m *Model) filteredGroups() []events.Group {
+	groups := m.eventGroups.Groups()
+	if m.filterQuery.empty() {
+		return groups
+	}
+	now := time.Now()
+	out := groups[:0]
+	for _, group := range groups {
+		for _, sample := range group.Samples {
+			if m.filterQuery.match(sample, now) {
+				out = append(out, group)
+				break
+			}
+		}
+	}
+	return out
+}
+
+func (m *Model) selectedGroupIndex(groups []events.Group) int {
+	if len(groups) == 0 {
+		return -1
+	}
+	for i, group := range groups {
+		if group.Fingerprint == m.eventsSelected {
+			return i
+		}
+	}
+	return len(groups) - 1
+}
+
+func (m *Model) moveEventSelection(delta int) {
+	groups := m.filteredGroups()
+	if len(groups) == 0 {
+		return
+	}
+	idx := clampInt(m.selectedGroupIndex(groups)+delta, 0, len(groups)-1)
+	m.eventsSelected = groups[idx].Fingerprint
+}
+
+func (m *Model) toggleEventExpansion() {
+	groups := m.filteredGroups()
+	idx := m.selectedGroupIndex(groups)
+	if idx < 0 {
+		return
+	}
+	fingerprint := groups[idx].Fingerprint
+	m.eventsSelected = fingerprint
+	if m.eventsExpanded == fingerprint {
+		m.eventsExpanded = ""
+		return
+	}
+	m.eventsExpanded = fingerprint
+}
+
+func (m *Model) renderGroupedEvents(width int) []string {
+	groups := m.filteredGroups()
+	selected := -1
+	if m.focus == focusRight {
+		selected = m.selectedGroupIndex(groups)
+	}
+	lines := make([]string, 0, len(groups))
+	for i, group := range groups {
+		rawLine := m.groupLine(group)
+		if i == selected {
+			rawLine = "› " + rawLine
+		}
+		style := m.levelStyle(group.Level)
+		if i == selected {
+			style = m.theme.Styles.Accent
+		}
+		lines = append(lines, style.Render(truncate(rawLine, width)))
+		if group.Fingerprint != m.eventsExpanded {
+			continue
+		}
+		detail := fmt.Sprintf("  first %s  last %s  workers %s",
+			formatTimestamp(group.FirstSeen),
+			formatTimestamp(group.LastSeen),
+			formatGroupWorkers(group.Workers, 0),
+		)
+		lines = append(lines, m.theme.Styles.Muted.Render(truncate(detail, width)))
+		for _, sample := range group.Samples {
+			sampleLine := fmt.Sprintf("  %s %s", formatTimestamp(sample.Timestamp), sample.Message)
+			if sample.WorkerID != "" {
+				sampleLine = fmt.Sprintf("  %s %s %s", formatTimestamp(sample.Timestamp), sample.WorkerID, sample.Message)
+			}
+			lines = append(lines, m.theme.Styles.Muted.Render(truncate(sampleLine, width)))
+		}
+	}
+	return lines
+}
+
+func (m *Model) groupLine(group events.Group) string {
+	latest := group.Latest()
+	timestamp := formatTimestamp(group.LastSeen)
+	meta := formatEventMeta(latest.Metadata)
+	message := latest.Message
+	if meta != "" {
+		message = fmt.Sprintf("[%s] %s", meta, message)
+	}
+	if group.Count <= 1 {
+		return fmt.Sprintf("%s %s", timestamp, message)
+	}
+	line := fmt.Sprintf("%s x%d %s (since %s", timestamp, group.Count, message, formatTimestamp(group.FirstSeen))
+	if len(group.Workers) > 0 {
+		line += ", " + formatGroupWorkers(group.Workers, 2)
+	}
+	return line + ")"
+}
+
+func (m *Model) levelStyle(level string) lipgloss.Style {
+	switch strings.ToLower(level) {
+	case "error":
+		return m.theme.Styles.StatusDown
+	case "warn", "warning":
+		return m.theme.Styles.StatusWarn
+	default:
+		return m.theme.Styles.Muted
+	}
+}
+
+func formatGroupWorkers(workers []string, limit int) string {
+	if len(workers) == 0 {
+		return "-"
+	}
+	if limit <= 0 || len(workers) <= limit {
+		return strings.Join(workers, ", ")
+	}
+	return fmt.Sprintf("%d workers", len(workers))
+}
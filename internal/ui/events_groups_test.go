@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/pkg/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestGroupedEventsCollapseAndExpand(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.EventsURL = "http://worker.local/events"
+	model := newTestModel(t, cfg)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	model = updated.(*Model)
+
+	base := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 30; i++ {
+		model.recordEvent(models.Event{
+			Timestamp: base.Add(time.Duration(i) * time.Second),
+			Level:     "error",
+			Type:      "task_failed",
+			Queue:     "default",
+			Message:   fmt.Sprintf("task %d failed", i),
+			WorkerID:  fmt.Sprintf("w%d", i%3),
+		})
+	}
+	model.recordEvent(models.Event{Timestamp: base.Add(time.Minute), Level: "info", Type: "task_completed", Message: "task done"})
+
+	lines := model.renderGroupedEvents(120)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 grouped rows, got %d: %v", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], "x30") || !strings.Contains(lines[0], "3 workers") {
+		t.Fatalf("expected collapsed flood row, got %q", lines[0])
+	}
+
+	model.focus = focusRight
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyUp})
+	model = updated.(*Model)
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(*Model)
+	lines = model.renderGroupedEvents(120)
+	if !strings.Contains(lines[1], "first 09:00:00") {
+		t.Fatalf("expected expanded group details, got %v", lines)
+	}
+
+	errors := model.renderErrorList()
+	if strings.Count(errors, "\n") != 0 || !strings.Contains(errors, "x30") {
+		t.Fatalf("expected a single grouped error row, got %q", errors)
+	}
+	model.recordEvent(models.Event{Timestamp: base.Add(2 * time.Minute), Level: "info", Type: "task_failed", Queue: "default", Message: "task 30 failed"})
+	if errors := model.renderErrorList(); !strings.Contains(errors, "x31") {
+		t.Fatalf("expected a quieter event to keep the group in the error list, got %q", errors)
+	}
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/events"
+	"github.com/adpena/reproq-tui/pkg/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const historySearchLimit = 200
+
+type historyMsg struct {
+	filter string
+	events []models.Event
+	err    error
+}
+
+func historySearchCmd(history *events.History, filter string, query eventQuery) tea.Cmd {
+	if history == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		// Events recorded just before the filter changed may still be queued.
+		history.Flush()
+		now := time.Now()
+		found, err := history.Search(func(event models.Event) bool {
+			return query.match(event, now)
+		}, historySearchLimit)
+		return historyMsg{filter: filter, events: found, err: err}
+	}
+}
+
+func (m *Model) searchHistoryCmd() tea.Cmd {
+	if m.eventHistory == nil {
+		return nil
+	}
+	query, err := parseEventQuery(m.filter)
+	if err != nil {
+		return nil
+	}
+	return historySearchCmd(m.eventHistory, m.filter, query)
+}
+
+func (m *Model) handleHistory(msg historyMsg) tea.Cmd {
+	if msg.filter != m.filter {
+		return nil
+	}
+	if msg.err != nil {
+		m.toast = fmt.Sprintf("Event history unavailable: %v", msg.err)
+		m.toastExpiry = time.Now().Add(3 * time.Second)
+		return tea.Tick(3*time.Second, func(time.Time) tea.Msg {
+			return toastClearMsg{}
+		})
+	}
+	added := m.mergeHistory(msg.events)
+	if added == 0 {
+		return nil
+	}
+	m.toast = fmt.Sprintf("Loaded %d events from history", added)
+	m.toastExpiry = time.Now().Add(2 * time.Second)
+	return tea.Tick(2*time.Second, func(time.Time) tea.Msg {
+		return toastClearMsg{}
+	})
+}
+
+func (m *Model) mergeHistory(loaded []models.Event) int {
+	current := m.eventsBuffer.Items()
+	seen := make(map[string]bool, len(current)+len(loaded))
+	merged := make([]models.Event, 0, len(current)+len(loaded))
+	for _, event := range current {
+		seen[historyKey(event)] = true
+		merged = append(merged, event)
+	}
+	added := 0
+	for _, event := range loaded {
+		key := historyKey(event)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, event)
+		added++
+	}
+	if added == 0 {
+		return 0
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Timestamp.Before(merged[j].Timestamp)
+	})
+	m.eventsBuffer.Clear()
+	m.eventGroups.Clear()
+	for _, event := range merged {
+		m.eventsBuffer.Add(event)
+		m.eventGroups.Add(event)
+	}
+	return added
+}
+
+func historyKey(event models.Event) string {
+	return fmt.Sprintf("%d|%s|%s|%s|%s|%s|%s", event.Timestamp.UnixNano(), event.Level, event.Type, event.Queue, event.TaskID, event.WorkerID, event.Message)
+}
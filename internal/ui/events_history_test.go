@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/internal/events"
+	"github.com/adpena/reproq-tui/pkg/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFilterChangeRestoresEventsFromHistory(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.EventsURL = "http://worker.local/events"
+	cfg.EventHistoryFile = filepath.Join(t.TempDir(), "events.jsonl")
+	model := newTestModel(t, cfg)
+	defer model.Close()
+	if model.eventHistory == nil {
+		t.Fatalf("expected event history to be enabled")
+	}
+
+	base := time.Now().Add(-time.Minute)
+	model.recordEvent(models.Event{Timestamp: base, Level: "error", Type: "task_failed", Queue: "emails", Message: "smtp down"})
+	model.recordEvent(models.Event{Timestamp: base.Add(time.Second), Level: "info", Type: "task_completed", Queue: "default", Message: "ok"})
+
+	model.filterActive = true
+	model.filterInput.SetValue("queue:emails")
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(*Model)
+	if len(model.eventsBuffer.Items()) != 0 {
+		t.Fatalf("expected filter change to restart the stream with an empty buffer")
+	}
+	if cmd == nil {
+		t.Fatalf("expected history search command")
+	}
+	updated, _ = model.Update(cmd())
+	model = updated.(*Model)
+
+	items := model.eventsBuffer.Items()
+	if len(items) != 1 || items[0].Message != "smtp down" {
+		t.Fatalf("expected matching event restored from history, got %+v", items)
+	}
+	if model.eventGroups.Len() != 1 {
+		t.Fatalf("expected restored event to be grouped")
+	}
+}
+
+func TestEventHistoryFailuresAreFlagged(t *testing.T) {
+	blocker := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatalf("write blocker: %v", err)
+	}
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.EventsURL = "http://worker.local/events"
+	cfg.EventHistoryFile = filepath.Join(blocker, "events.jsonl")
+	model := newTestModel(t, cfg)
+	defer model.Close()
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 240, Height: 40})
+	model = updated.(*Model)
+	if model.historyErr == nil || !strings.Contains(model.renderStatusBar(), "history err") {
+		t.Fatalf("expected a failed open to be flagged in the status bar")
+	}
+	if !strings.Contains(model.renderDiagnosticsDetail(), "EVENT HISTORY") {
+		t.Fatalf("expected the history error in diagnostics")
+	}
+
+	history, err := events.OpenHistory(filepath.Join(t.TempDir(), "events.jsonl"), time.Hour)
+	if err != nil {
+		t.Fatalf("open history: %v", err)
+	}
+	model.eventHistory = history
+	model.recordEvent(models.Event{Timestamp: time.Now(), Level: "info", Message: "ok"})
+	if model.historyErr != nil {
+		t.Fatalf("expected a successful append to clear the flag, got %v", model.historyErr)
+	}
+	_ = history.Close()
+	model.recordEvent(models.Event{Timestamp: time.Now(), Level: "info", Message: "lost"})
+	if model.historyErr == nil || !strings.Contains(model.renderStatusBar(), "history err") {
+		t.Fatalf("expected a failed append to be flagged")
+	}
+}
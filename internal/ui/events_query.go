@@ -0,0 +1,515 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/events"
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+type eventQuery struct {
+	root queryNode
+}
+
+func (q eventQuery) empty() bool {
+	return q.root == nil
+}
+
+func (q eventQuery) match(event models.Event, now time.Time) bool {
+	if q.root == nil {
+		return true
+	}
+	return q.root.match(event, now)
+}
+
+type queryNode interface {
+	match(event models.Event, now time.Time) bool
+}
+
+type queryAnd []queryNode
+
+func (n queryAnd) match(event models.Event, now time.Time) bool {
+	for _, child := range n {
+		if !child.match(event, now) {
+			return false
+		}
+	}
+	return true
+}
+
+type queryOr []queryNode
+
+func (n queryOr) match(event models.Event, now time.Time) bool {
+	for _, child := range n {
+		if child.match(event, now) {
+			return true
+		}
+	}
+	return false
+}
+
+type queryNot struct {
+	node queryNode
+}
+
+func (n queryNot) match(event models.Event, now time.Time) bool {
+	return !n.node.match(event, now)
+}
+
+type queryText struct {
+	needle string
+}
+
+func (n queryText) match(event models.Event, _ time.Time) bool {
+	return strings.Contains(eventHaystack(event), n.needle)
+}
+
+type queryTerm struct {
+	field   string
+	op      string
+	value   string
+	pattern *regexp.Regexp
+	level   int
+	at      time.Time
+	ago     time.Duration
+}
+
+func (n queryTerm) match(event models.Event, now time.Time) bool {
+	switch n.field {
+	case "level":
+		if n.op == "~" {
+			return n.pattern.MatchString(event.Level)
+		}
+		if n.op == ":" || n.op == "=" || n.op == "!=" {
+			equal := events.LevelRank(event.Level) == n.level && n.level >= 0
+			if n.op == "!=" {
+				return !equal
+			}
+			return equal
+		}
+		rank := events.LevelRank(event.Level)
+		if rank < 0 {
+			return false
+		}
+		switch n.op {
+		case ">":
+			return rank > n.level
+		case ">=":
+			return rank >= n.level
+		case "<":
+			return rank < n.level
+		case "<=":
+			return rank <= n.level
+		}
+		return false
+	case "since", "until":
+		if event.Timestamp.IsZero() {
+			return false
+		}
+		bound := n.at
+		if bound.IsZero() {
+			bound = now.Add(-n.ago)
+		}
+		if n.field == "since" {
+			return !event.Timestamp.Before(bound)
+		}
+		return event.Timestamp.Before(bound)
+	}
+	actual, ok := eventField(event, n.field)
+	switch n.op {
+	case "~":
+		return ok && n.pattern.MatchString(actual)
+	case "!=":
+		return !ok || !strings.EqualFold(actual, n.value)
+	default:
+		return ok && strings.EqualFold(actual, n.value)
+	}
+}
+
+var queryFieldAliases = map[string]string{
+	"level":     "level",
+	"severity":  "level",
+	"type":      "type",
+	"msg":       "msg",
+	"message":   "msg",
+	"queue":     "queue",
+	"worker":    "worker",
+	"worker_id": "worker",
+	"task":      "task",
+	"task_id":   "task",
+	"since":     "since",
+	"until":     "until",
+}
+
+var queryOperators = []string{">=", "<=", "!=", ":", "=", "~", ">", "<"}
+
+func parseEventQuery(input string) (eventQuery, error) {
+	tokens, err := tokenizeQuery(input)
+	if err != nil {
+		return eventQuery{}, err
+	}
+	if len(tokens) == 0 {
+		return eventQuery{}, nil
+	}
+	parser := &queryParser{tokens: tokens}
+	root, err := parser.parseOr()
+	if err != nil {
+		return eventQuery{}, err
+	}
+	if parser.pos < len(parser.tokens) {
+		tok := parser.tokens[parser.pos]
+		if tok.kind == tokenClose {
+			return eventQuery{}, errors.New("unexpected )")
+		}
+		return eventQuery{}, fmt.Errorf("unexpected %q", tok.text)
+	}
+	return eventQuery{root: root}, nil
+}
+
+type queryTokenKind int
+
+const (
+	tokenWord queryTokenKind = iota
+	tokenOpen
+	tokenClose
+	tokenNot
+	tokenAnd
+	tokenOr
+)
+
+type queryToken struct {
+	kind    queryTokenKind
+	text    string
+	literal bool
+}
+
+func tokenizeQuery(input string) ([]queryToken, error) {
+	var tokens []queryToken
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t':
+			i++
+			continue
+		case r == '(':
+			tokens = append(tokens, queryToken{kind: tokenOpen, text: "("})
+			i++
+			continue
+		case r == ')':
+			tokens = append(tokens, queryToken{kind: tokenClose, text: ")"})
+			i++
+			continue
+		case (r == '-' || r == '!') && i+1 < len(runes) && runes[i+1] != ' ' && runes[i+1] != '\t':
+			tokens = append(tokens, queryToken{kind: tokenNot, text: string(r)})
+			i++
+			continue
+		}
+		var word strings.Builder
+		quoted := false
+		literal := r == '"'
+		for i < len(runes) {
+			r = runes[i]
+			if r == ' ' || r == '\t' || r == '(' || r == ')' {
+				break
+			}
+			if r == '"' {
+				end := i + 1
+				for end < len(runes) && runes[end] != '"' {
+					end++
+				}
+				if end >= len(runes) {
+					return nil, errors.New("unterminated quote")
+				}
+				word.WriteString(string(runes[i+1 : end]))
+				quoted = true
+				i = end + 1
+				continue
+			}
+			word.WriteRune(r)
+			i++
+		}
+		text := word.String()
+		kind := tokenWord
+		if !quoted {
+			switch text {
+			case "AND":
+				kind = tokenAnd
+			case "OR":
+				kind = tokenOr
+			case "NOT":
+				kind = tokenNot
+			}
+		}
+		tokens = append(tokens, queryToken{kind: kind, text: text, literal: literal})
+	}
+	return tokens, nil
+}
+
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) peek() (queryToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return queryToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	nodes := queryOr{first}
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind != tokenOr {
+			break
+		}
+		p.pos++
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, next)
+	}
+	if len(nodes) == 1 {
+		return first, nil
+	}
+	return nodes, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	var nodes queryAnd
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind == tokenOr || tok.kind == tokenClose {
+			break
+		}
+		if tok.kind == tokenAnd {
+			if len(nodes) == 0 {
+				return nil, errors.New("AND needs a term on each side")
+			}
+			p.pos++
+			if next, ok := p.peek(); !ok || next.kind == tokenOr || next.kind == tokenClose || next.kind == tokenAnd {
+				return nil, errors.New("AND needs a term on each side")
+			}
+			continue
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	switch len(nodes) {
+	case 0:
+		if tok, ok := p.peek(); ok && tok.kind == tokenOr {
+			return nil, errors.New("OR needs a term on each side")
+		}
+		if tok, ok := p.peek(); ok && tok.kind == tokenClose {
+			return nil, errors.New("empty group")
+		}
+		return nil, errors.New("OR needs a term on each side")
+	case 1:
+		return nodes[0], nil
+	}
+	return nodes, nil
+}
+
+func (p *queryParser) parseUnary() (queryNode, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, errors.New("unexpected end of filter")
+	}
+	switch tok.kind {
+	case tokenNot:
+		p.pos++
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return queryNot{node: node}, nil
+	case tokenOpen:
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		closing, ok := p.peek()
+		if !ok || closing.kind != tokenClose {
+			return nil, errors.New("missing )")
+		}
+		p.pos++
+		return node, nil
+	case tokenWord:
+		p.pos++
+		return parseQueryTerm(tok)
+	}
+	return nil, fmt.Errorf("unexpected %q", tok.text)
+}
+
+func parseQueryTerm(tok queryToken) (queryNode, error) {
+	key, op, value, ok := splitQueryTerm(tok.text)
+	if !ok || tok.literal {
+		needle := strings.ToLower(strings.TrimSpace(tok.text))
+		if needle == "" {
+			return nil, errors.New("empty search term")
+		}
+		return queryText{needle: needle}, nil
+	}
+	field, ok := queryFieldAliases[key]
+	if !ok {
+		if !strings.HasPrefix(key, "meta.") || len(key) == len("meta.") {
+			// Not a field filter, e.g. "refused:" in an error message; search
+			// for the text as typed.
+			return queryText{needle: strings.ToLower(strings.TrimSpace(tok.text))}, nil
+		}
+		field = key
+	}
+	if value == "" {
+		return nil, fmt.Errorf("missing value for %s", key)
+	}
+	term := queryTerm{field: field, op: op, value: value}
+	if op == "~" {
+		pattern, err := regexp.Compile("(?i)" + value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern for %s: %v", key, err)
+		}
+		term.pattern = pattern
+		if field == "since" || field == "until" {
+			return nil, fmt.Errorf("%s does not support ~", key)
+		}
+		return term, nil
+	}
+	switch field {
+	case "level":
+		term.level = events.LevelRank(value)
+		if term.level < 0 {
+			return nil, fmt.Errorf("unknown level %q", value)
+		}
+	case "since", "until":
+		if op != ":" && op != "=" {
+			return nil, fmt.Errorf("%s does not support %s", key, op)
+		}
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			term.ago = d
+		} else if ts, err := time.Parse(time.RFC3339, value); err == nil {
+			term.at = ts
+		} else {
+			return nil, fmt.Errorf("invalid %s %q (use 5m or RFC3339)", key, value)
+		}
+	default:
+		if op != ":" && op != "=" && op != "!=" {
+			return nil, fmt.Errorf("%s does not support %s", key, op)
+		}
+	}
+	return term, nil
+}
+
+func splitQueryTerm(text string) (string, string, string, bool) {
+	best := -1
+	bestOp := ""
+	for _, op := range queryOperators {
+		idx := strings.Index(text, op)
+		if idx <= 0 {
+			continue
+		}
+		if best == -1 || idx < best || (idx == best && len(op) > len(bestOp)) {
+			best = idx
+			bestOp = op
+		}
+	}
+	if best <= 0 {
+		return "", "", "", false
+	}
+	key := text[:best]
+	for _, r := range strings.ToLower(key) {
+		if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '_' && r != '.' && r != '-' {
+			return "", "", "", false
+		}
+	}
+	// Field names are case-insensitive; metadata keys keep their case.
+	if prefix := len("meta."); len(key) > prefix && strings.EqualFold(key[:prefix], "meta.") {
+		key = "meta." + key[prefix:]
+	} else {
+		key = strings.ToLower(key)
+	}
+	value := strings.TrimSpace(text[best+len(bestOp):])
+	return key, bestOp, value, true
+}
+
+func eventField(event models.Event, field string) (string, bool) {
+	switch field {
+	case "level":
+		return event.Level, true
+	case "type":
+		return event.Type, true
+	case "msg":
+		return event.Message, true
+	case "queue":
+		return event.Queue, true
+	case "worker":
+		return event.WorkerID, true
+	case "task":
+		return event.TaskID, true
+	}
+	if key, ok := strings.CutPrefix(field, "meta."); ok {
+		val, ok := event.Metadata[key]
+		return val, ok
+	}
+	return "", false
+}
+
+func eventHaystack(event models.Event) string {
+	return strings.ToLower(strings.Join([]string{
+		event.Message,
+		event.Type,
+		event.Level,
+		event.Queue,
+		event.TaskID,
+		event.WorkerID,
+		flattenMeta(event.Metadata),
+	}, " "))
+}
+
+func (q eventQuery) pushdownTerms() (map[string]string, eventQuery) {
+	pushed := map[string]string{}
+	var conjuncts queryAnd
+	switch root := q.root.(type) {
+	case nil:
+		return pushed, q
+	case queryAnd:
+		conjuncts = root
+	default:
+		conjuncts = queryAnd{root}
+	}
+	rest := make(queryAnd, 0, len(conjuncts))
+	for _, node := range conjuncts {
+		term, ok := node.(queryTerm)
+		if ok && (term.op == ":" || term.op == "=") {
+			switch term.field {
+			case "queue", "worker", "task":
+				if _, exists := pushed[term.field]; !exists {
+					pushed[term.field] = term.value
+					continue
+				}
+			}
+		}
+		rest = append(rest, node)
+	}
+	switch len(rest) {
+	case 0:
+		return pushed, eventQuery{}
+	case 1:
+		return pushed, eventQuery{root: rest[0]}
+	}
+	return pushed, eventQuery{root: rest}
+}
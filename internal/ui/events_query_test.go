@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+func TestEventQueryMatching(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	failed := models.Event{
+		Timestamp: now.Add(-2 * time.Minute),
+		Level:     "error",
+		Type:      "task_failed",
+		Message:   "task 42 failed",
+		Queue:     "default",
+		WorkerID:  "w1",
+		Metadata:  map[string]string{"exception": "TimeoutError: read timed out", "requestId": "r-7"},
+	}
+	retry := models.Event{
+		Timestamp: now.Add(-20 * time.Minute),
+		Level:     "warn",
+		Type:      "task_retry",
+		Message:   "retrying task",
+		Queue:     "slow",
+	}
+	info := models.Event{
+		Timestamp: now.Add(-time.Minute),
+		Level:     "info",
+		Type:      "task_completed",
+		Message:   "task completed",
+		Queue:     "default",
+	}
+
+	cases := []struct {
+		query string
+		want  []bool
+	}{
+		{"", []bool{true, true, true}},
+		{"level>=warn", []bool{true, true, false}},
+		{"level<warn", []bool{false, false, true}},
+		{"type:task_failed", []bool{true, false, false}},
+		{`meta.exception~"timeout"`, []bool{true, false, false}},
+		{"META.requestId:r-7", []bool{true, false, false}},
+		{"meta.requestid:r-7", []bool{false, false, false}},
+		{"QUEUE:slow", []bool{false, true, false}},
+		{"-retry", []bool{true, false, true}},
+		{"NOT level:info", []bool{true, true, false}},
+		{"type:task_retry OR type:task_failed", []bool{true, true, false}},
+		{"(queue:slow OR level:error) since:5m", []bool{true, false, false}},
+		{"since:10m", []bool{true, false, true}},
+		{"until:10m", []bool{false, true, false}},
+		{"queue!=default", []bool{false, true, false}},
+		{`"task completed"`, []bool{false, false, true}},
+		{"TimeoutError: read", []bool{true, false, false}},
+		{"connection refused: db", []bool{false, false, false}},
+	}
+	for _, tc := range cases {
+		query, err := parseEventQuery(tc.query)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tc.query, err)
+		}
+		for i, event := range []models.Event{failed, retry, info} {
+			if got := query.match(event, now); got != tc.want[i] {
+				t.Fatalf("query %q event %d: got %v, want %v", tc.query, i, got, tc.want[i])
+			}
+		}
+	}
+}
+
+func TestEventQueryErrors(t *testing.T) {
+	for _, input := range []string{
+		"level>=loud",
+		"since:soon",
+		"queue>a",
+		"(level:error",
+		"level:error)",
+		"OR level:error",
+		`msg:"open`,
+		"meta.exception~(",
+		"type:",
+	} {
+		if _, err := parseEventQuery(input); err == nil {
+			t.Fatalf("expected parse error for %q", input)
+		}
+	}
+}
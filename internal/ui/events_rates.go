@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/charts"
+	"github.com/adpena/reproq-tui/internal/events"
+	"github.com/adpena/reproq-tui/internal/metrics"
+	"github.com/adpena/reproq-tui/pkg/client"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var eventFailureRateKey = events.RateKeyType("task_failed")
+
+type eventRateTickMsg struct{}
+
+func eventRateTickCmd(interval time.Duration) tea.Cmd {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return eventRateTickMsg{}
+	})
+}
+
+func (m *Model) eventRateValues(key string) []float64 {
+	if m.eventRates == nil {
+		return nil
+	}
+	cutoff := metrics.WindowCutoff(m.currentWindow(), time.Now())
+	return valuesFromSamples(m.eventRates.Series(key, cutoff))
+}
+
+func (m *Model) eventRateLatest(key string) float64 {
+	if m.eventRates == nil {
+		return math.NaN()
+	}
+	value, ok := m.eventRates.Latest(key)
+	if !ok {
+		return math.NaN()
+	}
+	return value
+}
+
+// eventRatesFallback swaps the metrics cards for event rates when there is
+// no metrics endpoint or it has been unreachable long enough to open its
+// breaker; a single failed scrape keeps the metrics cards.
+func (m *Model) eventRatesFallback() bool {
+	if !m.eventsEnabled {
+		return false
+	}
+	if strings.TrimSpace(m.cfg.WorkerMetricsURL) == "" {
+		return true
+	}
+	return m.breakers.metrics.State(time.Now()) != client.BreakerClosed
+}
+
+func (m *Model) eventRatesDisagree() bool {
+	fromEvents := m.eventRateLatest(eventFailureRateKey)
+	fromMetrics := m.latestValue(seriesErrors)
+	if math.IsNaN(fromEvents) || math.IsNaN(fromMetrics) {
+		return false
+	}
+	diff := math.Abs(fromEvents - fromMetrics)
+	return diff >= 0.5 && diff > 0.5*math.Max(fromEvents, fromMetrics)
+}
+
+func (m *Model) renderEventRateCards(width, height int) string {
+	gap := 1
+	cardHeight := maxInt(6, (height-gap)/2)
+	chartWidth := maxInt(10, width-6)
+	sparkline := func(values []float64, style lipgloss.Style) string {
+		if len(values) == 0 {
+			return m.theme.Styles.Muted.Render("No data yet")
+		}
+		return style.Render(charts.Sparkline(values, chartWidth))
+	}
+	updated := m.lastEventAt
+	first := m.chartCard("Event rate", formatRate(m.eventRateLatest(events.RateAll)), sparkline(m.eventRateValues(events.RateAll), m.theme.Styles.Accent), width, cardHeight, m.focus == focusCenter, updated)
+	second := m.chartCard("Event failures", formatRate(m.eventRateLatest(eventFailureRateKey)), sparkline(m.eventRateValues(eventFailureRateKey), m.theme.Styles.StatusWarn), width, cardHeight, false, updated)
+	return lipgloss.JoinVertical(lipgloss.Left, first, strings.Repeat("\n", gap), second)
+}
+
+func (m *Model) renderEventRatesDetail() string {
+	if !m.eventsEnabled || m.eventRates == nil {
+		return m.theme.Styles.Muted.Render("No events stream configured.")
+	}
+	lines := []string{
+		m.labelValue("Events", formatRate(m.eventRateLatest(events.RateAll))),
+		m.labelValue("Failures (events)", formatRate(m.eventRateLatest(eventFailureRateKey))),
+		m.labelValue("Errors (metrics)", formatRate(m.latestValue(seriesErrors))),
+	}
+	if m.eventRatesDisagree() {
+		lines = append(lines, m.theme.Styles.StatusWarn.Render("Events and worker metrics disagree on failure rate."))
+	}
+	failures := m.eventRateValues(eventFailureRateKey)
+	if len(failures) > 0 {
+		lines = append(lines, "", "Failures trend", m.theme.Styles.StatusWarn.Render(charts.Sparkline(failures, 40)))
+	}
+	if levels := m.eventRateRows("level:", 4); len(levels) > 0 {
+		lines = append(lines, "", "By level")
+		lines = append(lines, levels...)
+	}
+	if types := m.eventRateRows("type:", 6); len(types) > 0 {
+		lines = append(lines, "", "By type")
+		lines = append(lines, types...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) eventRateRows(prefix string, limit int) []string {
+	type row struct {
+		name  string
+		key   string
+		value float64
+	}
+	rows := []row{}
+	for _, key := range m.eventRates.Keys(prefix) {
+		value := m.eventRateLatest(key)
+		if math.IsNaN(value) {
+			continue
+		}
+		rows = append(rows, row{name: strings.TrimPrefix(key, prefix), key: key, value: value})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].value > rows[j].value
+	})
+	lines := []string{}
+	for i, r := range rows {
+		if i >= limit {
+			break
+		}
+		line := fmt.Sprintf("%-20s %8s %s", truncate(r.name, 20), formatRate(r.value), charts.Sparkline(m.eventRateValues(r.key), 20))
+		lines = append(lines, truncate(line, 60))
+	}
+	return lines
+}
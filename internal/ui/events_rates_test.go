@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/pkg/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestEventRatesFallbackWithoutMetrics(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.EventsURL = "http://worker.local/events"
+	model := newTestModel(t, cfg)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	model = updated.(*Model)
+
+	if model.eventRatesFallback() {
+		t.Fatalf("expected metrics cards while scrapes succeed")
+	}
+	scrapeErr := &url.Error{Op: "Get", URL: cfg.WorkerMetricsURL, Err: errors.New("connection refused")}
+	updated, _ = model.Update(metricsMsg{err: scrapeErr, attempted: time.Now()})
+	model = updated.(*Model)
+	if model.eventRatesFallback() {
+		t.Fatalf("expected a single failed scrape to keep the metrics cards")
+	}
+	for i := 1; i < cfg.BreakerThreshold; i++ {
+		updated, _ = model.Update(metricsMsg{err: scrapeErr, attempted: time.Now()})
+		model = updated.(*Model)
+	}
+	if !model.eventRatesFallback() {
+		t.Fatalf("expected event rate cards once the metrics breaker opens")
+	}
+
+	model.eventRates.Flush(time.Now().Add(-time.Second))
+	for i := 0; i < 3; i++ {
+		model.recordEvent(models.Event{Type: "task_failed", Level: "error", Message: "boom"})
+	}
+	updated, _ = model.Update(eventRateTickMsg{})
+	model = updated.(*Model)
+
+	center := model.renderCenterPane(60, 20)
+	if !strings.Contains(center, "Event failures") {
+		t.Fatalf("expected event failures card, got %q", center)
+	}
+	detail := model.renderEventRatesDetail()
+	if !strings.Contains(detail, "task_failed") || !strings.Contains(detail, "By level") {
+		t.Fatalf("expected per-type and per-level rates, got %q", detail)
+	}
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/adpena/reproq-tui/pkg/models"
@@ -55,6 +56,13 @@ func formatPercent(value float64) string {
 	return fmt.Sprintf("%.1f%%", value*100)
 }
 
+func formatAvailability(value float64) string {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f%%", value*100)
+}
+
 func formatBytes(value float64) string {
 	if math.IsNaN(value) || math.IsInf(value, 0) {
 		return "-"
@@ -84,6 +92,20 @@ func formatDuration(d time.Duration) string {
 	return d.Truncate(time.Second).String()
 }
 
+func formatWindow(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	label := d.Truncate(time.Second).String()
+	if strings.HasSuffix(label, "m0s") {
+		label = strings.TrimSuffix(label, "0s")
+	}
+	if strings.HasSuffix(label, "h0m") {
+		label = strings.TrimSuffix(label, "0m")
+	}
+	return label
+}
+
 func formatYesNo(value bool) string {
 	if value {
 		return "yes"
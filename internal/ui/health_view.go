@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/health"
+)
+
+const healthStripWidth = 24
+
+func (m *Model) recordHealth(at time.Time) {
+	if at.IsZero() {
+		at = time.Now()
+	}
+	for _, skipped := range m.breakers.healthGap.skipped(at) {
+		m.healthHistory.RecordSkipped(skipped)
+		m.slo.Record(health.SignalHealth, skipped, false)
+	}
+	m.breakers.healthGap = backoffGap{}
+	m.healthHistory.Record(at, m.lastHealth, m.lastHealthErr)
+	m.slo.Record(health.SignalHealth, at, m.lastHealthErr == nil && m.lastHealth.Healthy)
+}
+
+func (m *Model) flappingComponents(now time.Time) []string {
+	out := []string{}
+	for _, name := range m.healthHistory.Components() {
+		if m.healthHistory.Flapping(name, now, m.cfg.HealthFlapCount, m.cfg.HealthFlapWindow) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func (m *Model) renderHealthDetail() string {
+	if strings.TrimSpace(m.cfg.WorkerHealthURL) == "" {
+		return m.theme.Styles.Muted.Render("No health endpoint configured.")
+	}
+	components := m.healthHistory.Components()
+	if len(components) == 0 {
+		return m.theme.Styles.Muted.Render("Waiting for health checks.")
+	}
+	now := time.Now()
+	window := m.currentWindow()
+	since := now.Add(-window)
+	lines := []string{
+		m.theme.Styles.Muted.Render(fmt.Sprintf("Window %s  flap: %d changes in %s", formatDuration(window), m.cfg.HealthFlapCount, formatDuration(m.cfg.HealthFlapWindow))),
+		"",
+	}
+	for _, name := range components {
+		latest, _ := m.healthHistory.Latest(name)
+		status := latest.Status
+		if status == "" {
+			status = "-"
+		}
+		statusStyle := m.theme.Styles.StatusOK
+		if !latest.Healthy {
+			statusStyle = m.theme.Styles.StatusDown
+		}
+		uptime := "-"
+		if ratio, ok := m.healthHistory.Uptime(name, since, now); ok {
+			uptime = formatPercent(ratio)
+		}
+		line := fmt.Sprintf("%-12s %s %7s %s",
+			truncate(name, 12),
+			statusStyle.Render(fmt.Sprintf("%-6s", truncate(status, 6))),
+			uptime,
+			m.renderUptimeStrip(m.healthHistory.Strip(name, since, now, healthStripWidth)),
+		)
+		if m.healthHistory.Flapping(name, now, m.cfg.HealthFlapCount, m.cfg.HealthFlapWindow) {
+			changes := m.healthHistory.Transitions(name, now.Add(-m.cfg.HealthFlapWindow))
+			line += " " + m.theme.Styles.StatusWarn.Render(fmt.Sprintf("flapping (%d)", changes))
+		}
+		lines = append(lines, line)
+	}
+	if m.lastHealthErr == nil {
+		for _, name := range components {
+			check, ok := m.lastHealth.Checks[name]
+			if !ok || check.Healthy || strings.TrimSpace(check.Message) == "" {
+				continue
+			}
+			lines = append(lines, m.theme.Styles.Muted.Render(truncate(fmt.Sprintf("%s: %s", name, check.Message), 60)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) renderUptimeStrip(states []health.BucketState) string {
+	var b strings.Builder
+	for _, state := range states {
+		switch state {
+		case health.BucketUp:
+			b.WriteString(m.theme.Styles.StatusOK.Render("█"))
+		case health.BucketDown:
+			b.WriteString(m.theme.Styles.StatusDown.Render("█"))
+		case health.BucketMixed:
+			b.WriteString(m.theme.Styles.StatusWarn.Render("▒"))
+		default:
+			b.WriteString(m.theme.Styles.Muted.Render("·"))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/pkg/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestHealthDrilldownShowsComponentsAndFlapping(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.WorkerHealthURL = "http://worker.local/healthz"
+	cfg.HealthFlapCount = 3
+	model := newTestModel(t, cfg)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	model = updated.(*Model)
+
+	base := time.Now().Add(-time.Minute)
+	for i := 0; i < 6; i++ {
+		brokerOK := i%2 == 0
+		status := models.HealthStatus{
+			Healthy:   true,
+			Status:    "ok",
+			CheckedAt: base.Add(time.Duration(i) * 5 * time.Second),
+			Checks: map[string]models.HealthCheck{
+				"db":     {Healthy: true, Status: "ok"},
+				"broker": {Healthy: brokerOK, Status: "fail", Message: "connection refused"},
+			},
+		}
+		updated, _ = model.Update(healthMsg{status: status})
+		model = updated.(*Model)
+	}
+
+	detail := model.renderHealthDetail()
+	for _, want := range []string{"overall", "broker", "db", "flapping (5)", "broker: connection refused"} {
+		if !strings.Contains(detail, want) {
+			t.Fatalf("expected %q in health detail, got %q", want, detail)
+		}
+	}
+	if !strings.Contains(model.renderStatusBar(), "flapping broker") {
+		t.Fatalf("expected flapping badge in status bar")
+	}
+}
@@ -38,7 +38,7 @@ func TestHeadlessUpdateLoop(t *testing.T) {
 		updated, _ := model.Update(msg)
 		model = updated.(*Model)
 	}
-	msg = pollStatsCmd(cfg, model.client)()
+	msg = pollStatsCmd(cfg, model.statsFetcher)()
 	if msg != nil {
 		updated, _ := model.Update(msg)
 		model = updated.(*Model)
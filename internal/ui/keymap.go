@@ -3,38 +3,62 @@ package ui
 import "github.com/charmbracelet/bubbles/key"
 
 type keyMap struct {
-	Quit         key.Binding
-	Help         key.Binding
-	Pause        key.Binding
-	Refresh      key.Binding
-	WindowShort  key.Binding
-	WindowMid    key.Binding
-	WindowLong   key.Binding
-	FocusNext    key.Binding
-	Filter       key.Binding
-	ToggleEvents key.Binding
-	ToggleTheme  key.Binding
-	Snapshot     key.Binding
-	Drilldown    key.Binding
-	Auth         key.Binding
+	Quit           key.Binding
+	Help           key.Binding
+	Pause          key.Binding
+	Refresh        key.Binding
+	WindowShort    key.Binding
+	WindowMid      key.Binding
+	WindowLong     key.Binding
+	FocusNext      key.Binding
+	Filter         key.Binding
+	ToggleEvents   key.Binding
+	GroupEvents    key.Binding
+	EventUp        key.Binding
+	EventDown      key.Binding
+	EventExpand    key.Binding
+	ToggleTheme    key.Binding
+	Snapshot       key.Binding
+	Drilldown      key.Binding
+	Auth           key.Binding
+	Database       key.Binding
+	QueueAction    key.Binding
+	TaskRetry      key.Binding
+	TaskRetryAll   key.Binding
+	TaskCancel     key.Binding
+	PeriodicToggle key.Binding
+	PeriodicRun    key.Binding
+	WorkerSort     key.Binding
 }
 
 func newKeyMap() keyMap {
 	return keyMap{
-		Quit:         key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
-		Help:         key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
-		Pause:        key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "pause")),
-		Refresh:      key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
-		WindowShort:  key.NewBinding(key.WithKeys("1"), key.WithHelp("1", "1m window")),
-		WindowMid:    key.NewBinding(key.WithKeys("2"), key.WithHelp("2", "5m window")),
-		WindowLong:   key.NewBinding(key.WithKeys("3"), key.WithHelp("3", "15m window")),
-		FocusNext:    key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next pane")),
-		Filter:       key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter")),
-		ToggleEvents: key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "toggle events")),
-		ToggleTheme:  key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "theme")),
-		Snapshot:     key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "snapshot")),
-		Drilldown:    key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "details")),
-		Auth:         key.NewBinding(key.WithKeys("l"), key.WithHelp("l", "login/logout")),
+		Quit:           key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+		Help:           key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
+		Pause:          key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "pause")),
+		Refresh:        key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+		WindowShort:    key.NewBinding(key.WithKeys("1"), key.WithHelp("1", "1m window")),
+		WindowMid:      key.NewBinding(key.WithKeys("2"), key.WithHelp("2", "5m window")),
+		WindowLong:     key.NewBinding(key.WithKeys("3"), key.WithHelp("3", "15m window")),
+		FocusNext:      key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next pane")),
+		Filter:         key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter")),
+		ToggleEvents:   key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "toggle events")),
+		GroupEvents:    key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "group events")),
+		EventUp:        key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "older group")),
+		EventDown:      key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "newer group")),
+		EventExpand:    key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "expand group")),
+		ToggleTheme:    key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "theme")),
+		Snapshot:       key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "snapshot")),
+		Drilldown:      key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "details")),
+		Auth:           key.NewBinding(key.WithKeys("l"), key.WithHelp("l", "login/logout")),
+		Database:       key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "database scope")),
+		QueueAction:    key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "pause/resume queue")),
+		TaskRetry:      key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "retry task")),
+		TaskRetryAll:   key.NewBinding(key.WithKeys("A"), key.WithHelp("A", "retry all failed")),
+		TaskCancel:     key.NewBinding(key.WithKeys("C"), key.WithHelp("C", "cancel queued")),
+		PeriodicToggle: key.NewBinding(key.WithKeys("E"), key.WithHelp("E", "enable/disable periodic")),
+		PeriodicRun:    key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "run periodic now")),
+		WorkerSort:     key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "sort workers")),
 	}
 }
 
@@ -47,7 +71,10 @@ func (k keyMap) FullHelp() [][]key.Binding {
 		{k.Help, k.Pause, k.Refresh, k.Snapshot},
 		{k.WindowShort, k.WindowMid, k.WindowLong, k.FocusNext},
 		{k.Filter, k.Drilldown, k.ToggleEvents, k.ToggleTheme},
-		{k.Auth},
+		{k.GroupEvents, k.EventUp, k.EventDown, k.EventExpand},
+		{k.Auth, k.Database, k.QueueAction},
+		{k.TaskRetry, k.TaskRetryAll, k.TaskCancel},
+		{k.PeriodicToggle, k.PeriodicRun, k.WorkerSort},
 		{k.Quit},
 	}
 }
@@ -2,15 +2,20 @@ package ui
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/adpena/reproq-tui/internal/auth"
 	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/internal/control"
 	"github.com/adpena/reproq-tui/internal/events"
+	"github.com/adpena/reproq-tui/internal/health"
 	"github.com/adpena/reproq-tui/internal/metrics"
+	"github.com/adpena/reproq-tui/internal/stats"
 	"github.com/adpena/reproq-tui/internal/theme"
 	"github.com/adpena/reproq-tui/pkg/client"
 	"github.com/adpena/reproq-tui/pkg/models"
@@ -42,10 +47,78 @@ const (
 	setupWorker
 )
 
+// endpointClients route each target through its configured credentials.
+// Worker targets without any fall back to the shared client. Django always
+// gets its own client so a static worker Authorization header never reaches
+// it.
+type endpointClients struct {
+	metrics *client.Client
+	health  *client.Client
+	events  *client.Client
+	django  *client.Client
+	// login carries the TUI login token: Django, and every worker target
+	// with no Authorization of its own.
+	login []*client.Client
+}
+
+func newEndpointClients(base *client.Client, endpoints map[string]config.EndpointAuth) endpointClients {
+	credentials := func(name string) client.Credentials {
+		endpoint := endpoints[name]
+		return client.Credentials{
+			Token:    client.Secret{File: endpoint.TokenFile, Command: endpoint.TokenCommand},
+			Username: endpoint.Username,
+			Password: client.Secret{File: endpoint.PasswordFile, Command: endpoint.PasswordCommand},
+			Headers:  endpoint.Headers,
+		}
+	}
+	forWorker := func(name string) *client.Client {
+		creds := credentials(name)
+		if creds.IsZero() {
+			return base
+		}
+		return base.WithCredentials(creds)
+	}
+	clients := endpointClients{
+		metrics: forWorker(config.EndpointMetrics),
+		health:  forWorker(config.EndpointHealth),
+		events:  forWorker(config.EndpointEvents),
+		django:  base.WithCredentials(credentials(config.EndpointDjango)),
+	}
+	clients.login = []*client.Client{clients.django}
+	sharedAuth := base.HasHeader("Authorization")
+	workers := []struct {
+		name   string
+		target *client.Client
+	}{
+		{config.EndpointMetrics, clients.metrics},
+		{config.EndpointHealth, clients.health},
+		{config.EndpointEvents, clients.events},
+	}
+	for _, worker := range workers {
+		if endpoints[worker.name].SetsAuthorization() || (worker.target == base && sharedAuth) || slices.Contains(clients.login, worker.target) {
+			continue
+		}
+		clients.login = append(clients.login, worker.target)
+	}
+	return clients
+}
+
+// setLoginToken sends token to every login target; empty clears it.
+func (e endpointClients) setLoginToken(token string) {
+	for _, target := range e.login {
+		if token == "" {
+			target.ClearHeader("Authorization")
+		} else {
+			target.SetHeader("Authorization", "Bearer "+token)
+		}
+	}
+}
+
 type Model struct {
-	cfg     config.Config
-	client  *client.Client
-	catalog metrics.Catalog
+	cfg       config.Config
+	client    *client.Client
+	endpoints endpointClients
+	catalog   metrics.Catalog
 
 	width  int
 	height int
@@ -62,7 +135,8 @@ type Model struct {
 	filterInput  textinput.Model
 	filterActive bool
 	filter       string
-	filterLocal  string
+	filterQuery  eventQuery
+	filterErr    error
 
 	setupActive    bool
 	setupStage     setupStage
@@ -82,18 +156,49 @@ type Model struct {
 	lastCounters map[string]models.Sample
 
 	lastSnapshot    models.MetricSnapshot
+	prevSnapshot    models.MetricSnapshot
 	lastScrapeErr   error
 	lastScrapeAt    time.Time
 	lastScrapeDelay time.Duration
 
+	breakers pollBreakers
+
 	lastHealth    models.HealthStatus
 	lastHealthErr error
+	healthHistory *health.History
+	slo           *health.SLOTracker
+	rollout       *stats.Rollout
 
 	statsEnabled   bool
 	lastStats      models.DjangoStats
 	lastStatsErr   error
 	lastStatsAt    time.Time
 	lastStatsDelay time.Duration
+	lastStatsInfo  stats.FetchInfo
+	rawStats       models.DjangoStats
+	dbScope        string
+	dbScopeMissing bool
+	statsFetcher   *stats.Fetcher
+	statsHistory   *stats.History
+
+	confirm       *confirmPrompt
+	confirmReason textinput.Model
+	audit         *control.AuditLog
+	auditErr      error
+	queueSelected int
+	pendingQueues map[string]pendingQueueAction
+	taskSelected  int
+	taskOps       *taskOps
+
+	periodicSelected int
+	periodicExpanded bool
+	cronLocation     *time.Location
+	periodicAnalysis []stats.PeriodicStatus
+	schedulerDiag    stats.SchedulerDiagnosis
+
+	workerSelected string
+	workerExpanded bool
+	workerSort     workerSortMode
 
 	authURLInput  textinput.Model
 	authURLActive bool
@@ -101,24 +206,41 @@ type Model struct {
 
 	authEnabled       bool
 	authHeaderManaged bool
-	authStore         *auth.Store
+	authStore         auth.TokenStore
+	authWriter        *authWriter
 	authToken         auth.Token
 	authFlowActive    bool
 	authPair          auth.Pairing
+	deviceConfig      auth.DeviceConfig
+	deviceAuth        auth.DeviceAuthorization
 	authNeeded        bool
 	authErr           error
+	authRefreshing    bool
+	authExpiryNotice  authExpiryNotice
+	// authRefreshUnsupported is set once Django rejects refresh, leaving
+	// pairing as the only way to renew.
+	authRefreshUnsupported bool
+	authRefreshRetryAt     time.Time
 
 	lowMemoryMode bool
 
-	eventsEnabled bool
-	eventsBuffer  *events.Buffer
-	eventsCh      chan models.Event
-	eventsBaseURL string
-	eventsURL     string
-	eventsCancel  context.CancelFunc
-	lastEventAt   time.Time
-	ctx           context.Context
-	cancel        context.CancelFunc
+	eventsEnabled  bool
+	eventsBuffer   *events.Buffer
+	eventGroups    *events.GroupBuffer
+	groupEvents    bool
+	eventsSelected string
+	eventsExpanded string
+	eventSchema    events.Schema
+	eventRates     *events.RateTracker
+	eventHistory   *events.History
+	historyErr     error
+	eventsCh       chan models.Event
+	eventsBaseURL  string
+	eventsURL      string
+	eventsCancel   context.CancelFunc
+	lastEventAt    time.Time
+	ctx            context.Context
+	cancel         context.CancelFunc
 
 	toast       string
 	toastExpiry time.Time
@@ -133,7 +255,13 @@ func NewModel(cfg config.Config) *Model {
 		Timeout:            cfg.Timeout,
 		Headers:            cfg.Headers,
 		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		CAFile:             cfg.CAFile,
+		CertFile:           cfg.ClientCertFile,
+		KeyFile:            cfg.ClientKeyFile,
+		ServerName:         cfg.TLSServerName,
+		Retry:              client.RetryPolicy{Retries: cfg.Retries, BaseDelay: cfg.RetryBackoff},
 	})
+	endpoints := newEndpointClients(httpClient, cfg.Endpoints)
 	catalog := metrics.NewCatalog(cfg.Metrics)
 	windowOptions := []time.Duration{time.Minute, 5 * time.Minute, 15 * time.Minute}
 	windowIndex := 1
@@ -168,9 +296,21 @@ func NewModel(cfg config.Config) *Model {
 		seriesErrors:                    metrics.NewRingBuffer(capacity),
 	}
 
+	healthInterval := cfg.HealthInterval
+	if healthInterval <= 0 {
+		healthInterval = 500 * time.Millisecond
+	}
+	healthCapacity := int(maxWindow/healthInterval) + 5
+
+	statsInterval := cfg.StatsInterval
+	if statsInterval <= 0 {
+		statsInterval = 5 * time.Second
+	}
+	statsCapacity := int(maxWindow/statsInterval) + 5
+
 	filter := textinput.New()
-	filter.Placeholder = "filter events (queue, task, worker)"
-	filter.CharLimit = 64
+	filter.Placeholder = "level>=warn queue:default -retry"
+	filter.CharLimit = 200
 	filter.Width = 36
 
 	setupWorkerInput := textinput.New()
@@ -183,16 +323,34 @@ func NewModel(cfg config.Config) *Model {
 	setupDjangoInput.CharLimit = 200
 	setupDjangoInput.Width = 52
 
+	confirmReason := textinput.New()
+	confirmReason.Placeholder = "optional"
+	confirmReason.CharLimit = 200
+	confirmReason.Width = 40
+
 	authURL := textinput.New()
 	authURL.Placeholder = "django.example.com"
 	authURL.CharLimit = 200
 	authURL.Width = 52
 
+	cronLocation, err := time.LoadLocation(cfg.CronTimezone)
+	if err != nil || cfg.CronTimezone == "" {
+		cronLocation = time.UTC
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
-	authStore := auth.DefaultStore()
-	authHeaderManaged := !httpClient.HasHeader("Authorization")
+	var authStoreErr error
+	authStore, err := auth.OpenStore(auth.StoreOptions{Backend: cfg.AuthStore, PassphraseFile: cfg.AuthPassphraseFile, Profile: cfg.Profile})
+	if err != nil {
+		authStoreErr = fmt.Errorf("token store: %w", err)
+	} else if cfg.Profile == "" {
+		if profile, ok := auth.FindProfile(authStore, cfg.DjangoURL); ok {
+			authStore = authStore.WithProfile(profile)
+		}
+	}
+	authHeaderManaged := !cfg.Endpoints[config.EndpointDjango].SetsAuthorization()
 	authToken := auth.Token{}
-	if authHeaderManaged {
+	if authHeaderManaged && authStore != nil {
 		if stored, err := authStore.Load(); err == nil {
 			if strings.TrimSpace(cfg.DjangoURL) == "" && stored.DjangoURL != "" {
 				cfg.DjangoURL = stored.DjangoURL
@@ -201,17 +359,17 @@ func NewModel(cfg config.Config) *Model {
 				}
 			}
 			if stored.Valid(time.Now()) {
-				httpClient.SetHeader("Authorization", "Bearer "+stored.Value)
+				endpoints.setLoginToken(stored.Value)
 				authToken = stored
 			} else if stored.Value != "" {
 				authToken = stored
-				if stored.DjangoURL == "" {
+				if stored.DjangoURL == "" && stored.RefreshToken == "" {
 					_ = authStore.Clear()
 				}
 			}
 		}
 	}
-	authEnabled := strings.TrimSpace(cfg.DjangoURL) != ""
+	authEnabled := strings.TrimSpace(cfg.DjangoURL) != "" || deviceConfig(cfg).Enabled()
 	if cfg.WorkerURL != "" {
 		setupWorkerInput.SetValue(cfg.WorkerURL)
 	}
@@ -236,6 +394,8 @@ func NewModel(cfg config.Config) *Model {
 	model := &Model{
 		cfg:               cfg,
 		client:            httpClient,
+		endpoints:         endpoints,
+		breakers:          newPollBreakers(cfg),
 		catalog:           catalog,
 		theme:             theme.Resolve(cfg.Theme),
 		keymap:            newKeyMap(),
@@ -249,17 +409,32 @@ func NewModel(cfg config.Config) *Model {
 		windowOptions:     windowOptions,
 		windowIndex:       windowIndex,
 		showEvents:        true,
-		detailViews:       []string{"Queues", "Workers", "Periodic", "Databases", "Tasks", "Errors"},
+		detailViews:       []string{"Queues", "Workers", "Periodic", "Scheduler", "Databases", "Tasks", "Errors", "Event rates", "Health", "SLO", "Rollout", "Diagnostics"},
 		series:            series,
 		lastCounters:      map[string]models.Sample{},
+		healthHistory:     health.NewHistory(healthCapacity),
+		slo:               health.NewSLOTracker(cfg.SLOTarget, cfg.SLOWindow),
+		rollout:           stats.NewRollout(),
 		statsEnabled:      cfg.DjangoStatsURL != "",
+		statsFetcher:      stats.NewFetcher(endpoints.django, cfg.StatsDelta),
+		statsHistory:      stats.NewHistory(statsCapacity),
+		dbScope:           cfg.Database,
+		confirmReason:     confirmReason,
+		cronLocation:      cronLocation,
+		pendingQueues:     map[string]pendingQueueAction{},
 		authURLInput:      authURL,
 		authEnabled:       authEnabled,
 		authStore:         authStore,
+		authWriter:        &authWriter{},
+		authErr:           authStoreErr,
 		authToken:         authToken,
 		authHeaderManaged: authHeaderManaged,
 		eventsEnabled:     cfg.EventsURL != "",
 		eventsBuffer:      events.NewBuffer(200),
+		eventGroups:       events.NewGroupBuffer(200),
+		eventRates:        events.NewRateTracker(capacity),
+		groupEvents:       true,
+		eventSchema:       events.NewSchema(cfg.EventFields, cfg.EventLevelAliases),
 		eventsCh:          make(chan models.Event, 50),
 		eventsBaseURL:     cfg.EventsURL,
 		eventsURL:         cfg.EventsURL,
@@ -268,6 +443,16 @@ func NewModel(cfg config.Config) *Model {
 		spinner:           spinner.New(spinner.WithSpinner(spinner.Dot), spinner.WithStyle(lipgloss.NewStyle().Foreground(theme.Resolve(cfg.Theme).Palette.Accent))),
 		safeTop:           safeTopPadding(),
 	}
+	if cfg.AuditLogFile != "" {
+		model.audit = control.NewAuditLog(cfg.AuditLogFile)
+	}
+	if cfg.EventsURL != "" && cfg.EventHistory && cfg.EventHistoryFile != "" {
+		if history, err := events.OpenHistory(cfg.EventHistoryFile, cfg.EventHistoryMaxAge); err == nil {
+			model.eventHistory = history
+		} else {
+			model.historyErr = fmt.Errorf("open event history: %w", err)
+		}
+	}
 	model.applyInputStyles()
 	return model
 }
@@ -275,14 +460,18 @@ func NewModel(cfg config.Config) *Model {
 func (m *Model) Init() tea.Cmd {
 	if m.setupActive {
 		if m.setupStage == setupWorker && strings.TrimSpace(m.cfg.DjangoURL) != "" {
-			return fetchTUIConfigCmd(m.cfg, m.client)
+			return fetchTUIConfigCmd(m.cfg, m.endpoints.django)
 		}
 		return nil
 	}
 	if m.eventsEnabled {
 		m.startEvents()
 	}
-	return tea.Batch(m.spinner.Tick, m.startPollingCmds())
+	cmds := []tea.Cmd{m.spinner.Tick, m.startPollingCmds()}
+	if m.authHeaderManaged {
+		cmds = append(cmds, m.checkAuthExpiry(time.Now()), authExpiryTickCmd())
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m *Model) applyInputStyles() {
@@ -297,6 +486,7 @@ func (m *Model) applyInputStyles() {
 	set(&m.setupWorkerURL)
 	set(&m.setupDjangoURL)
 	set(&m.authURLInput)
+	set(&m.confirmReason)
 }
 
 func (m *Model) Close() {
@@ -306,6 +496,9 @@ func (m *Model) Close() {
 	if m.eventsCancel != nil {
 		m.eventsCancel()
 	}
+	if m.eventHistory != nil {
+		_ = m.eventHistory.Close()
+	}
 }
 
 func (m *Model) startEvents() {
@@ -318,16 +511,19 @@ func (m *Model) startEvents() {
 func (m *Model) startPollingCmds() tea.Cmd {
 	cmds := []tea.Cmd{}
 	if m.cfg.WorkerMetricsURL != "" {
-		cmds = append(cmds, pollMetricsCmd(m.cfg, m.client, m.catalog))
+		cmds = append(cmds, pollMetricsCmd(m.cfg, m.endpoints.metrics, m.catalog))
 	}
 	if m.cfg.WorkerHealthURL != "" {
-		cmds = append(cmds, pollHealthCmd(m.cfg, m.client))
+		cmds = append(cmds, pollHealthCmd(m.cfg, m.endpoints.health))
 	}
 	if m.statsEnabled {
-		cmds = append(cmds, pollStatsCmd(m.cfg, m.client))
+		cmds = append(cmds, pollStatsCmd(m.cfg, m.statsFetcher))
 	}
 	if m.eventsEnabled {
-		cmds = append(cmds, listenEventsCmd(m.eventsCh))
+		cmds = append(cmds, listenEventsCmd(m.eventsCh), eventRateTickCmd(m.cfg.Interval))
+		if cmd := m.searchHistoryCmd(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 	}
 	if len(cmds) == 0 {
 		return nil
@@ -344,6 +540,10 @@ func (m *Model) restartEvents(url string, clear bool) {
 	}
 	if clear {
 		m.eventsBuffer.Clear()
+		m.eventGroups.Clear()
+		m.eventRates.Clear()
+		m.eventsSelected = ""
+		m.eventsExpanded = ""
 	}
 	ctx := m.ctx
 	if ctx == nil {
@@ -352,7 +552,7 @@ func (m *Model) restartEvents(url string, clear bool) {
 	eventsCtx, cancel := context.WithCancel(ctx)
 	m.eventsCancel = cancel
 	m.eventsURL = url
-	go events.Listen(eventsCtx, m.client, url, m.eventsCh)
+	go events.Listen(eventsCtx, m.endpoints.events, url, m.eventSchema, m.eventsCh)
 }
 
 func (m *Model) applyLowMemoryMode(enabled bool) {
@@ -370,6 +570,17 @@ func (m *Model) applyLowMemoryMode(enabled bool) {
 	if m.eventsBuffer != nil {
 		m.eventsBuffer.Clear()
 	}
+	if m.eventGroups != nil {
+		m.eventGroups.Clear()
+	}
+	if m.eventRates != nil {
+		m.eventRates.Clear()
+	}
+	if m.eventHistory != nil {
+		_ = m.eventHistory.Close()
+		m.eventHistory = nil
+	}
+	m.historyErr = nil
 }
 
 func (m *Model) currentWindow() time.Duration {
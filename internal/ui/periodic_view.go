@@ -0,0 +1,328 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/internal/control"
+	"github.com/adpena/reproq-tui/internal/stats"
+	"github.com/adpena/reproq-tui/pkg/client"
+	"github.com/adpena/reproq-tui/pkg/models"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const periodicUpcoming = 5
+
+type periodicActionMsg struct {
+	action   string
+	req      control.PeriodicRequest
+	task     models.PeriodicTask
+	resultID int64
+	err      error
+}
+
+func (m *Model) periodicDetailActive() bool {
+	return m.detailActive && m.detailViews[m.detailIndex%len(m.detailViews)] == "Periodic"
+}
+
+func (m *Model) periodicStatuses() []stats.PeriodicStatus {
+	if !m.statsAvailable() {
+		return nil
+	}
+	return m.periodicAnalysis
+}
+
+// analyzePeriodic parses schedules and diagnoses the scheduler once per
+// stats update, so views and the status bar read cached results each frame.
+func (m *Model) analyzePeriodic() {
+	periodic := m.statsPeriodicByNextRun()
+	ref := m.referenceTime()
+	statuses := make([]stats.PeriodicStatus, 0, len(periodic))
+	for _, task := range periodic {
+		statuses = append(statuses, stats.AnalyzePeriodic(task, ref, m.cronLocation, periodicUpcoming))
+	}
+	sched, _ := m.statsScheduler()
+	m.periodicAnalysis = statuses
+	m.schedulerDiag = stats.DiagnoseScheduler(sched, statuses)
+}
+
+func (m *Model) selectedPeriodic() (models.PeriodicTask, bool) {
+	periodic := m.statsPeriodicByNextRun()
+	if len(periodic) == 0 {
+		return models.PeriodicTask{}, false
+	}
+	if m.periodicSelected >= len(periodic) {
+		m.periodicSelected = len(periodic) - 1
+	}
+	return periodic[m.periodicSelected], true
+}
+
+func (m *Model) handlePeriodicKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch {
+	case msg.Type == tea.KeyEsc && m.periodicExpanded:
+		m.periodicExpanded = false
+		return nil, true
+	case key.Matches(msg, m.keymap.EventUp), key.Matches(msg, m.keymap.EventDown):
+		if count := len(m.statsPeriodicByNextRun()); count > 0 {
+			delta := 1
+			if key.Matches(msg, m.keymap.EventUp) {
+				delta = -1
+			}
+			m.periodicSelected = (m.periodicSelected + delta + count) % count
+		}
+		return nil, true
+	case key.Matches(msg, m.keymap.EventExpand):
+		if _, ok := m.selectedPeriodic(); ok {
+			m.periodicExpanded = !m.periodicExpanded
+		}
+		return nil, true
+	case key.Matches(msg, m.keymap.PeriodicToggle):
+		task, ok := m.selectedPeriodic()
+		if !ok {
+			return nil, true
+		}
+		action := control.ActionDisable
+		if !task.Enabled {
+			action = control.ActionEnable
+		}
+		return m.confirmPeriodicAction(action, task), true
+	case key.Matches(msg, m.keymap.PeriodicRun):
+		task, ok := m.selectedPeriodic()
+		if !ok {
+			return nil, true
+		}
+		return m.confirmPeriodicAction(control.ActionRunNow, task), true
+	}
+	return nil, false
+}
+
+func (m *Model) confirmPeriodicAction(action string, task models.PeriodicTask) tea.Cmd {
+	baseURL := m.actionBaseURL()
+	if baseURL == "" {
+		return m.showToast("Periodic actions need a Django URL", 3*time.Second)
+	}
+	message := fmt.Sprintf("Run %s now?", task.Name)
+	switch action {
+	case control.ActionEnable:
+		message = fmt.Sprintf("Enable %s?", task.Name)
+	case control.ActionDisable:
+		message = fmt.Sprintf("Disable %s?", task.Name)
+	}
+	database := m.dbScope
+	m.openConfirm(message, func(reason string) tea.Cmd {
+		req := control.PeriodicRequest{Name: task.Name, Database: database, Reason: reason}
+		return periodicActionCmd(m.cfg, m.endpoints.django, baseURL, action, req)
+	})
+	return nil
+}
+
+func (m *Model) handlePeriodicAction(msg periodicActionMsg) tea.Cmd {
+	entry := control.AuditEntry{
+		Action:   "periodic." + msg.action,
+		Target:   msg.req.Name,
+		Database: msg.req.Database,
+		Reason:   msg.req.Reason,
+	}
+	if msg.err != nil {
+		entry.Error = msg.err.Error()
+	}
+	m.recordAudit(entry)
+	if msg.err != nil {
+		if isAuthError(msg.err) {
+			return m.showToast("Periodic action denied: press l to sign in", 3*time.Second)
+		}
+		return m.showToast(fmt.Sprintf("%s %s failed: %v", msg.action, msg.req.Name, msg.err), 3*time.Second)
+	}
+	switch msg.action {
+	case control.ActionRunNow:
+		if msg.resultID > 0 {
+			return m.showToast(fmt.Sprintf("Queued %s (#%d)", msg.req.Name, msg.resultID), 2*time.Second)
+		}
+		return m.showToast("Queued "+msg.req.Name, 2*time.Second)
+	default:
+		m.rawStats.Periodic = patchPeriodic(m.rawStats.Periodic, msg.req.Name, msg.task)
+		databases := append([]models.DatabaseStats(nil), m.rawStats.Databases...)
+		for i := range databases {
+			databases[i].Periodic = patchPeriodic(databases[i].Periodic, msg.req.Name, msg.task)
+		}
+		m.rawStats.Databases = databases
+		m.applyDatabaseScope()
+		verb := "Disabled"
+		if msg.task.Enabled {
+			verb = "Enabled"
+		}
+		return m.showToast(fmt.Sprintf("%s %s", verb, msg.req.Name), 2*time.Second)
+	}
+}
+
+func (m *Model) renderPeriodicDetail() string {
+	statuses := m.periodicStatuses()
+	if len(statuses) == 0 {
+		if m.statsAvailable() {
+			return m.theme.Styles.Muted.Render("No periodic tasks reported.")
+		}
+		return m.theme.Styles.Muted.Render("No Django stats configured.")
+	}
+	selected := minInt(m.periodicSelected, len(statuses)-1)
+	if m.periodicExpanded {
+		return m.renderPeriodicTask(statuses[selected])
+	}
+	ref := m.referenceTime()
+	missed, drifted := 0, 0
+	for _, status := range statuses {
+		if status.Missed {
+			missed++
+		}
+		if status.Drifted {
+			drifted++
+		}
+	}
+	lines := []string{
+		m.labelValue("Total tasks", formatCount(int64(len(statuses)))),
+	}
+	if missed > 0 || drifted > 0 {
+		lines = append(lines, m.labelValue("Missed/drift", fmt.Sprintf("%d / %d", missed, drifted)))
+	}
+	if sched, ok := m.statsScheduler(); ok {
+		lines = append(lines,
+			m.labelValue("Scheduler", formatScheduler(sched)),
+			m.labelValue("Beat", formatYesNo(sched.BeatEnabled)),
+			m.labelValue("pg_cron", formatYesNo(sched.PgCronAvailable)),
+		)
+		if sched.Warning != "" {
+			lines = append(lines, "", m.theme.Styles.StatusWarn.Render(sched.Warning))
+		}
+	}
+	if diag, ok := m.schedulerDiagnosis(); ok && diag.Broken {
+		lines = append(lines, m.theme.Styles.StatusDown.Render("Scheduling looks broken; see the Scheduler view (tab)."))
+	}
+	lines = append(lines, "")
+	start := maxInt(0, selected-9)
+	for i, status := range statuses {
+		if i < start || i >= start+10 {
+			continue
+		}
+		task := status.Task
+		marker := "  "
+		if i == selected {
+			marker = "> "
+		}
+		statusIcon := m.theme.Styles.AccentAlt.Render("●")
+		if !task.Enabled {
+			statusIcon = m.theme.Styles.Muted.Render("○")
+		}
+		line := fmt.Sprintf("%-22s %s", truncate(task.Name, 22), formatScheduleDetailed(task.NextRunAt, ref))
+		if !task.Enabled {
+			line = m.theme.Styles.Muted.Render(line)
+		}
+		line = marker + statusIcon + " " + line
+		if flag := m.periodicFlag(status); flag != "" {
+			line += " " + flag
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) periodicFlag(status stats.PeriodicStatus) string {
+	switch {
+	case status.Missed:
+		return m.theme.Styles.StatusDown.Render(fmt.Sprintf("missed %d", status.MissedRuns))
+	case status.Drifted:
+		return m.theme.Styles.StatusWarn.Render("drift " + formatDrift(status.Drift))
+	case status.ParseErr != nil:
+		return m.theme.Styles.StatusWarn.Render("bad cron")
+	}
+	return ""
+}
+
+func (m *Model) renderPeriodicTask(status stats.PeriodicStatus) string {
+	task := status.Task
+	ref := m.referenceTime()
+	enabled := m.theme.Styles.StatusOK.Render("enabled")
+	if !task.Enabled {
+		enabled = m.theme.Styles.Muted.Render("disabled")
+	}
+	schedule := status.Description
+	if status.ParseErr != nil {
+		schedule = m.theme.Styles.StatusWarn.Render(truncate(status.ParseErr.Error(), 48))
+	} else {
+		schedule += " (" + m.cronLocation.String() + ")"
+	}
+	lines := []string{
+		m.labelValue("Task", truncate(task.Name, 48)),
+		m.labelValue("Status", enabled),
+		m.labelValue("Cron", firstNonEmptyString(task.CronExpr, "-")),
+		m.labelValue("Schedule", schedule),
+		m.labelValue("Next (server)", formatScheduleDetailed(task.NextRunAt, ref)),
+		m.labelValue("Next (local)", formatScheduleDetailed(status.Expected, ref)),
+	}
+	switch {
+	case status.Missed:
+		lines = append(lines, m.labelValue("Missed", m.theme.Styles.StatusDown.Render(
+			fmt.Sprintf("%d run(s), overdue %s", status.MissedRuns, formatDuration(status.Overdue)))))
+	case status.Drifted:
+		lines = append(lines, m.labelValue("Drift", m.theme.Styles.StatusWarn.Render(formatDrift(status.Drift))))
+	case !status.Expected.IsZero() && task.Enabled:
+		lines = append(lines, m.labelValue("Drift", m.theme.Styles.StatusOK.Render("in sync")))
+	}
+	if len(status.Upcoming) > 0 {
+		lines = append(lines, "", fmt.Sprintf("Next %d fires", len(status.Upcoming)))
+		for _, at := range status.Upcoming {
+			lines = append(lines, "  "+formatScheduleDetailed(at, ref))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) periodicActionHint() string {
+	if !m.periodicDetailActive() || len(m.statsPeriodicByNextRun()) == 0 {
+		return ""
+	}
+	if m.periodicExpanded {
+		return "E enable/disable | N run now | esc back"
+	}
+	return "j/k select | enter details | E enable/disable | N run now"
+}
+
+// patchPeriodic copies the slice so the fetcher's cached payload is untouched.
+func patchPeriodic(periodic []models.PeriodicTask, name string, updated models.PeriodicTask) []models.PeriodicTask {
+	out := append([]models.PeriodicTask(nil), periodic...)
+	for i, task := range out {
+		if task.Name != name {
+			continue
+		}
+		out[i].Enabled = updated.Enabled
+		if !updated.NextRunAt.IsZero() {
+			out[i].NextRunAt = updated.NextRunAt
+		}
+	}
+	return out
+}
+
+func formatDrift(drift time.Duration) string {
+	if drift < 0 {
+		return "-" + formatDuration(-drift)
+	}
+	return "+" + formatDuration(drift)
+}
+
+func periodicActionCmd(cfg config.Config, httpClient *client.Client, baseURL, action string, req control.PeriodicRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+		defer cancel()
+		msg := periodicActionMsg{action: action, req: req}
+		if action == control.ActionRunNow {
+			var result control.PeriodicRunResult
+			result, msg.err = control.RunPeriodicNow(ctx, httpClient, baseURL, req)
+			msg.resultID = result.ResultID
+			return msg
+		}
+		msg.task, msg.err = control.SetPeriodicEnabled(ctx, httpClient, baseURL, req, action == control.ActionEnable)
+		return msg
+	}
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/internal/control"
+	"github.com/adpena/reproq-tui/pkg/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPeriodicDetailFlagsAndToggle(t *testing.T) {
+	var got control.PeriodicRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/reproq/periodic/disable/" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"name":"sync","cron_expr":"*/5 * * * *","enabled":false}`))
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoStatsURL = server.URL + "/reproq/stats/"
+	model := newTestModel(t, cfg)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	model = updated.(*Model)
+
+	now := time.Date(2025, 6, 1, 12, 1, 0, 0, time.UTC)
+	updated, _ = model.Update(statsMsg{attempted: now, stats: models.DjangoStats{
+		FetchedAt: now,
+		Periodic: []models.PeriodicTask{
+			{Name: "sync", CronExpr: "*/5 * * * *", Enabled: true, NextRunAt: now.Add(-11 * time.Minute)},
+			{Name: "report", CronExpr: "0 9 * * 1-5", Enabled: true, NextRunAt: time.Date(2025, 6, 2, 9, 30, 0, 0, time.UTC)},
+		},
+	}})
+	model = updated.(*Model)
+
+	send := func(msg tea.Msg) tea.Cmd {
+		updated, cmd := model.Update(msg)
+		model = updated.(*Model)
+		return cmd
+	}
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	for model.detailViews[model.detailIndex] != "Periodic" {
+		send(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	view := model.View()
+	if !strings.Contains(view, "missed 3") || !strings.Contains(view, "drift +30m") {
+		t.Fatalf("expected missed and drift flags, got:\n%s", view)
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	view = model.View()
+	if !strings.Contains(view, "every 5 minutes (UTC)") || !strings.Contains(view, "Next 5 fires") {
+		t.Fatalf("expected expanded schedule, got:\n%s", view)
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")})
+	if model.confirm == nil || !strings.Contains(model.confirm.message, "Disable sync") {
+		t.Fatalf("expected disable confirmation")
+	}
+	cmd := send(tea.KeyMsg{Type: tea.KeyEnter})
+	send(cmd())
+	if got.Name != "sync" {
+		t.Fatalf("unexpected request %+v", got)
+	}
+	if task, _ := model.selectedPeriodic(); task.Name != "sync" || task.Enabled {
+		t.Fatalf("expected sync to be disabled locally, got %+v", task)
+	}
+	send(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.periodicExpanded || !model.detailActive {
+		t.Fatalf("expected esc to collapse the periodic task")
+	}
+}
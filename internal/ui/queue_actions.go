@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/internal/control"
+	"github.com/adpena/reproq-tui/pkg/client"
+	"github.com/adpena/reproq-tui/pkg/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type queueActionMsg struct {
+	req     control.QueueRequest
+	pause   bool
+	control models.QueueControl
+	err     error
+}
+
+type pendingQueueAction struct {
+	control models.QueueControl
+	at      time.Time
+}
+
+// defaultDatabase is Django's DEFAULT_DB_ALIAS. Queue actions sent without a
+// database apply to it, so controls reported for it count as unscoped.
+const defaultDatabase = "default"
+
+func queueDatabase(database string) string {
+	if database == "" {
+		return defaultDatabase
+	}
+	return database
+}
+
+func queueActionKey(database, queue string) string {
+	return queueDatabase(database) + "/" + queue
+}
+
+func (m *Model) actionBaseURL() string {
+	if base := strings.TrimSpace(m.cfg.DjangoURL); base != "" {
+		return base
+	}
+	if m.cfg.DjangoStatsURL == "" {
+		return ""
+	}
+	return config.DeriveDjangoURL(m.cfg.DjangoStatsURL)
+}
+
+func (m *Model) queueDetailActive() bool {
+	return m.detailActive && m.detailViews[m.detailIndex%len(m.detailViews)] == "Queues"
+}
+
+func (m *Model) moveQueueSelection(delta int) {
+	summaries := m.statsQueueSummaries()
+	if len(summaries) == 0 {
+		m.queueSelected = 0
+		return
+	}
+	m.queueSelected = (m.queueSelected + delta + len(summaries)) % len(summaries)
+}
+
+func (m *Model) selectedQueue() (string, bool) {
+	summaries := m.statsQueueSummaries()
+	if len(summaries) == 0 {
+		return "", false
+	}
+	if m.queueSelected >= len(summaries) {
+		m.queueSelected = len(summaries) - 1
+	}
+	return summaries[m.queueSelected].Name, true
+}
+
+// queuePaused reports whether queue is paused in database, where an empty
+// database is the one unscoped actions target.
+func (m *Model) queuePaused(database, queue string) bool {
+	for _, control := range m.lastStats.QueueControls {
+		if control.QueueName == queue && queueDatabase(control.Database) == queueDatabase(database) && control.Paused {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Model) startQueuePrompt() tea.Cmd {
+	queue, ok := m.selectedQueue()
+	if !ok {
+		return m.showToast("No queue selected", 2*time.Second)
+	}
+	if m.actionBaseURL() == "" {
+		return m.showToast("Queue actions need a Django URL", 3*time.Second)
+	}
+	database := m.dbScope
+	pause := !m.queuePaused(database, queue)
+	verb := "Resume"
+	if pause {
+		verb = "Pause"
+	}
+	target := queue
+	if database != "" {
+		target = fmt.Sprintf("%s@%s", queue, database)
+	}
+	m.openConfirm(fmt.Sprintf("%s queue %s?", verb, target), func(reason string) tea.Cmd {
+		req := control.QueueRequest{Queue: queue, Database: database, Reason: reason}
+		m.applyPendingQueueAction(req, pause, time.Now())
+		return queueActionCmd(m.cfg, m.endpoints.django, m.actionBaseURL(), req, pause)
+	})
+	return nil
+}
+
+func (m *Model) applyPendingQueueAction(req control.QueueRequest, pause bool, at time.Time) {
+	pending := models.QueueControl{
+		QueueName: req.Queue,
+		Paused:    pause,
+		Reason:    req.Reason,
+		UpdatedAt: at,
+		Database:  req.Database,
+	}
+	if pause {
+		pending.PausedAt = at
+	}
+	m.pendingQueues[queueActionKey(req.Database, req.Queue)] = pendingQueueAction{control: pending, at: at}
+	m.applyDatabaseScope()
+}
+
+func (m *Model) handleQueueAction(msg queueActionMsg) tea.Cmd {
+	action, done := "resume", "Resumed"
+	if msg.pause {
+		action, done = "pause", "Paused"
+	}
+	key := queueActionKey(msg.req.Database, msg.req.Queue)
+	entry := control.AuditEntry{Action: action, Target: msg.req.Queue, Database: msg.req.Database, Reason: msg.req.Reason}
+	if msg.err != nil {
+		entry.Error = msg.err.Error()
+	}
+	m.recordAudit(entry)
+	if msg.err != nil {
+		delete(m.pendingQueues, key)
+		m.applyDatabaseScope()
+		if isAuthError(msg.err) {
+			return m.showToast("Queue action denied: press l to sign in", 3*time.Second)
+		}
+		return m.showToast(fmt.Sprintf("%s %s failed: %v", action, msg.req.Queue, msg.err), 3*time.Second)
+	}
+	if pending, ok := m.pendingQueues[key]; ok {
+		pending.control = msg.control
+		if pending.control.Database == "" {
+			pending.control.Database = msg.req.Database
+		}
+		m.pendingQueues[key] = pending
+		m.applyDatabaseScope()
+	}
+	return m.showToast(fmt.Sprintf("%s %s", done, msg.req.Queue), 2*time.Second)
+}
+
+// reconcileQueueActions drops optimistic queue changes once the stats
+// payload agrees with them or they have waited too long to be confirmed.
+func (m *Model) reconcileQueueActions(now time.Time) {
+	ttl := 2 * m.cfg.StatsInterval
+	if ttl < 10*time.Second {
+		ttl = 10 * time.Second
+	}
+	for key, pending := range m.pendingQueues {
+		if now.Sub(pending.at) > ttl || queueControlMatches(m.rawStats.QueueControls, pending.control) {
+			delete(m.pendingQueues, key)
+		}
+	}
+}
+
+func queueControlMatches(controls []models.QueueControl, want models.QueueControl) bool {
+	found := false
+	for _, control := range controls {
+		if control.QueueName != want.QueueName || queueDatabase(control.Database) != queueDatabase(want.Database) {
+			continue
+		}
+		found = true
+		if control.Paused != want.Paused {
+			return false
+		}
+	}
+	return found || !want.Paused
+}
+
+func (m *Model) withPendingQueueControls(snapshot models.DjangoStats) models.DjangoStats {
+	if len(m.pendingQueues) == 0 {
+		return snapshot
+	}
+	controls := append([]models.QueueControl(nil), snapshot.QueueControls...)
+	for _, pending := range m.pendingQueues {
+		want := pending.control
+		matched := false
+		for i, existing := range controls {
+			if existing.QueueName != want.QueueName || queueDatabase(existing.Database) != queueDatabase(want.Database) {
+				continue
+			}
+			matched = true
+			controls[i].Paused = want.Paused
+			controls[i].Reason = want.Reason
+			controls[i].UpdatedAt = want.UpdatedAt
+			if want.Paused {
+				controls[i].PausedAt = want.PausedAt
+			}
+		}
+		if !matched && want.Paused {
+			controls = append(controls, want)
+		}
+	}
+	snapshot.QueueControls = controls
+	return snapshot
+}
+
+func (m *Model) showToast(text string, ttl time.Duration) tea.Cmd {
+	m.toast = text
+	m.toastExpiry = time.Now().Add(ttl)
+	return tea.Tick(ttl, func(time.Time) tea.Msg {
+		return toastClearMsg{}
+	})
+}
+
+func (m *Model) queueActionHint() string {
+	if !m.queueDetailActive() || len(m.statsQueueSummaries()) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("j/k select | %s pause/resume", m.keymap.QueueAction.Help().Key)
+}
+
+func queueActionCmd(cfg config.Config, httpClient *client.Client, baseURL string, req control.QueueRequest, pause bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+		defer cancel()
+		var (
+			result models.QueueControl
+			err    error
+		)
+		if pause {
+			result, err = control.PauseQueue(ctx, httpClient, baseURL, req)
+		} else {
+			result, err = control.ResumeQueue(ctx, httpClient, baseURL, req)
+		}
+		return queueActionMsg{req: req, pause: pause, control: result, err: err}
+	}
+}
@@ -0,0 +1,210 @@
+package ui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/internal/control"
+	"github.com/adpena/reproq-tui/pkg/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestQueuePauseIsOptimisticAndReconciles(t *testing.T) {
+	var gotAuth string
+	var gotBody map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/reproq/queues/pause/" {
+			http.NotFound(w, r)
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"queue_name": "bulk", "paused": true, "database": "default"})
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoStatsURL = server.URL + "/reproq/stats/"
+	model := newTestModel(t, cfg)
+	model.endpoints.django.SetHeader("Authorization", "Bearer secret")
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	model = updated.(*Model)
+
+	now := time.Now()
+	snapshot := models.DjangoStats{
+		FetchedAt: now,
+		Queues:    map[string]map[string]int64{"default": {"READY": 5}, "bulk": {"READY": 4}},
+	}
+	updated, _ = model.Update(statsMsg{attempted: now, stats: snapshot})
+	model = updated.(*Model)
+
+	keys := func(values ...string) tea.Cmd {
+		var cmd tea.Cmd
+		for _, value := range values {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(value)}
+			switch value {
+			case "enter":
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			}
+			updated, cmd = model.Update(msg)
+			model = updated.(*Model)
+		}
+		return cmd
+	}
+
+	keys("d", "j", "x")
+	if model.confirm == nil {
+		t.Fatalf("expected confirmation prompt")
+	}
+	if !strings.Contains(model.View(), "Pause queue bulk?") {
+		t.Fatalf("expected confirmation prompt in Queues detail")
+	}
+	keys("d", "r", "a", "i", "n")
+	cmd := keys("enter")
+	if model.confirm != nil {
+		t.Fatalf("expected prompt to close on confirm")
+	}
+	if !model.queuePaused("", "bulk") {
+		t.Fatalf("expected optimistic pause before the server responds")
+	}
+
+	updated, _ = model.Update(cmd())
+	model = updated.(*Model)
+	if gotAuth != "Bearer secret" {
+		t.Fatalf("expected bearer token on queue action, got %q", gotAuth)
+	}
+	if gotBody["queue_name"] != "bulk" || gotBody["reason"] != "drain" {
+		t.Fatalf("unexpected request body %v", gotBody)
+	}
+
+	updated, _ = model.Update(statsMsg{attempted: now.Add(time.Second), stats: snapshot})
+	model = updated.(*Model)
+	if !model.queuePaused("", "bulk") || len(model.pendingQueues) != 1 {
+		t.Fatalf("expected pending pause to survive a stale stats poll")
+	}
+
+	confirmed := snapshot
+	confirmed.QueueControls = []models.QueueControl{{QueueName: "bulk", Paused: true, Database: "default"}}
+	updated, _ = model.Update(statsMsg{attempted: now.Add(2 * time.Second), stats: confirmed})
+	model = updated.(*Model)
+	if len(model.pendingQueues) != 0 || !model.queuePaused("", "bulk") {
+		t.Fatalf("expected pending pause to reconcile with stats")
+	}
+}
+
+func TestQueueActionFailureRevertsOptimisticUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoStatsURL = server.URL + "/reproq/stats/"
+	model := newTestModel(t, cfg)
+
+	now := time.Now()
+	updated, _ := model.Update(statsMsg{attempted: now, stats: models.DjangoStats{
+		FetchedAt:     now,
+		Queues:        map[string]map[string]int64{"default": {"READY": 1}},
+		QueueControls: []models.QueueControl{{QueueName: "default", Paused: true, Database: "default"}},
+	}})
+	model = updated.(*Model)
+
+	model.detailActive = true
+	if cmd := model.startQueuePrompt(); cmd != nil {
+		t.Fatalf("expected prompt, got toast %q", model.toast)
+	}
+	if model.confirm == nil || !strings.Contains(model.confirm.message, "Resume queue default") {
+		t.Fatalf("expected resume prompt for a paused queue")
+	}
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(*Model)
+	if model.queuePaused("", "default") {
+		t.Fatalf("expected optimistic resume")
+	}
+	updated, _ = model.Update(cmd())
+	model = updated.(*Model)
+	if !model.queuePaused("", "default") {
+		t.Fatalf("expected failed resume to revert")
+	}
+	if !strings.Contains(model.toast, "sign in") {
+		t.Fatalf("expected sign-in hint, got %q", model.toast)
+	}
+}
+
+func TestQueuePausedIsScopedToDatabase(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoStatsURL = "http://django.local/reproq/stats/"
+	model := newTestModel(t, cfg)
+
+	now := time.Now()
+	updated, _ := model.Update(statsMsg{attempted: now, stats: models.DjangoStats{
+		FetchedAt:     now,
+		Queues:        map[string]map[string]int64{"emails": {"READY": 2}},
+		QueueControls: []models.QueueControl{{QueueName: "emails", Paused: true, Database: "tenant_a"}},
+		Databases: []models.DatabaseStats{
+			{Alias: "tenant_a", Queues: map[string]map[string]int64{"emails": {"READY": 1}}},
+			{Alias: "tenant_b", Queues: map[string]map[string]int64{"emails": {"READY": 1}}},
+		},
+	}})
+	model = updated.(*Model)
+
+	if model.queuePaused("", "emails") {
+		t.Fatalf("expected a tenant_a pause not to mark the default database paused")
+	}
+	model.detailActive = true
+	model.startQueuePrompt()
+	if model.confirm == nil || !strings.Contains(model.confirm.message, "Pause queue emails?") {
+		t.Fatalf("expected a pause prompt for the unscoped queue")
+	}
+	model.confirm = nil
+
+	model.dbScope = "tenant_a"
+	model.applyDatabaseScope()
+	if !model.queuePaused("tenant_a", "emails") {
+		t.Fatalf("expected emails paused in tenant_a")
+	}
+	model.applyPendingQueueAction(control.QueueRequest{Queue: "emails", Database: "tenant_b"}, false, now)
+	if !model.queuePaused("tenant_a", "emails") {
+		t.Fatalf("expected resuming tenant_b to leave tenant_a paused")
+	}
+}
+
+func TestAuditWriteFailureIsFlagged(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoStatsURL = "http://django.local/reproq/stats/"
+	model := newTestModel(t, cfg)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 200, Height: 40})
+	model = updated.(*Model)
+
+	blocker := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatalf("write blocker: %v", err)
+	}
+	model.audit = control.NewAuditLog(filepath.Join(blocker, "audit.jsonl"))
+	req := control.QueueRequest{Queue: "bulk"}
+	model.handleQueueAction(queueActionMsg{req: req, pause: true, control: models.QueueControl{QueueName: "bulk", Paused: true}})
+	if model.auditErr == nil || !strings.Contains(model.renderStatusBar(), "audit log err") {
+		t.Fatalf("expected failed audit write to be flagged in the status bar")
+	}
+	if !strings.Contains(model.renderDiagnosticsDetail(), "AUDIT LOG") {
+		t.Fatalf("expected audit error in diagnostics")
+	}
+
+	model.audit = control.NewAuditLog(filepath.Join(t.TempDir(), "audit.jsonl"))
+	model.handleQueueAction(queueActionMsg{req: req, pause: false})
+	if model.auditErr != nil || strings.Contains(model.renderStatusBar(), "audit log err") {
+		t.Fatalf("expected a successful write to clear the flag")
+	}
+}
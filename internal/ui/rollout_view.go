@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/stats"
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+func (m *Model) rolloutGroups() []stats.VersionGroup {
+	workers := m.statsWorkersByRecent()
+	if len(workers) == 0 {
+		return nil
+	}
+	active, _ := m.splitWorkersByStatus(workers, m.referenceTime())
+	return m.rollout.Groups(active)
+}
+
+func (m *Model) mixedVersions() int {
+	if groups := m.rolloutGroups(); len(groups) > 1 {
+		return len(groups)
+	}
+	return 0
+}
+
+func (m *Model) renderRolloutDetail() string {
+	groups := m.rolloutGroups()
+	if len(groups) == 0 {
+		if m.statsAvailable() {
+			return m.theme.Styles.Muted.Render("No active workers reported.")
+		}
+		return m.theme.Styles.Muted.Render("No Django stats configured.")
+	}
+	latest := groups[0]
+	fleet := m.theme.Styles.StatusOK.Render("single version")
+	if len(groups) > 1 {
+		fleet = m.theme.Styles.StatusWarn.Render(fmt.Sprintf("mixed fleet (%d versions)", len(groups)))
+	}
+	lines := []string{
+		m.labelValue("Fleet", fleet),
+		m.labelValue("Latest", fmt.Sprintf("%s  since %s", latest.Version, formatTimestamp(latest.FirstSeen))),
+	}
+	if version := strings.TrimSpace(m.lastHealth.Version); version != "" {
+		label := version
+		if build := strings.TrimSpace(m.lastHealth.Build); build != "" {
+			label += " " + build
+		}
+		if commit := strings.TrimSpace(m.lastHealth.Commit); commit != "" {
+			label += " " + truncate(commit, 12)
+		}
+		if stats.NormalizeVersion(version) != latest.Version {
+			label = m.theme.Styles.StatusWarn.Render(label + " (old build)")
+		}
+		lines = append(lines, m.labelValue("Health", label))
+	}
+	lines = append(lines, "", "Versions")
+	for _, group := range groups {
+		count := fmt.Sprintf("%d workers", len(group.Workers))
+		if len(group.Workers) == 1 {
+			count = "1 worker"
+		}
+		line := fmt.Sprintf("%-16s %-10s first seen %s", truncate(group.Version, 16), count, formatTimestamp(group.FirstSeen))
+		if group.Version != latest.Version {
+			line = m.theme.Styles.StatusWarn.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	outdated := []models.WorkerInfo{}
+	for _, group := range groups[1:] {
+		outdated = append(outdated, group.Workers...)
+	}
+	if len(outdated) > 0 {
+		behind := "-"
+		if !latest.FirstSeen.IsZero() {
+			behind = formatDuration(time.Since(latest.FirstSeen))
+		}
+		lines = append(lines, "", fmt.Sprintf("Old builds (%d) behind %s for %s", len(outdated), latest.Version, behind))
+		for i, worker := range outdated {
+			if i >= 5 {
+				lines = append(lines, m.theme.Styles.Muted.Render(fmt.Sprintf("+%d more", len(outdated)-i)))
+				break
+			}
+			line := fmt.Sprintf("%s (%s) %s", worker.WorkerID, worker.Hostname, stats.NormalizeVersion(worker.Version))
+			lines = append(lines, m.theme.Styles.StatusWarn.Render(truncate(line, 60)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/pkg/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRolloutDrilldownFlagsOldBuilds(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoStatsURL = "http://django.local/reproq/stats/"
+	model := newTestModel(t, cfg)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	model = updated.(*Model)
+
+	now := time.Now()
+	workers := func(versions ...string) []models.WorkerInfo {
+		out := []models.WorkerInfo{}
+		for i, version := range versions {
+			out = append(out, models.WorkerInfo{
+				WorkerID:   "w-" + string(rune('1'+i)),
+				Hostname:   "host",
+				LastSeenAt: now,
+				Version:    version,
+			})
+		}
+		return out
+	}
+	updated, _ = model.Update(statsMsg{attempted: now.Add(-10 * time.Minute), stats: models.DjangoStats{FetchedAt: now, Workers: workers("1.4.0", "1.4.0")}})
+	model = updated.(*Model)
+	if model.mixedVersions() != 0 {
+		t.Fatalf("expected a single-version fleet")
+	}
+
+	updated, _ = model.Update(statsMsg{attempted: now, stats: models.DjangoStats{FetchedAt: now, Workers: workers("1.5.0", "1.4.0")}})
+	model = updated.(*Model)
+	model.lastHealth = models.HealthStatus{Healthy: true, Version: "1.4.0", Build: "ci-12"}
+
+	detail := model.renderRolloutDetail()
+	for _, want := range []string{"mixed fleet (2 versions)", "Latest", "1.5.0", "1.4.0 ci-12 (old build)", "Old builds (1) behind 1.5.0", "w-2 (host) 1.4.0"} {
+		if !strings.Contains(detail, want) {
+			t.Fatalf("expected %q in rollout detail, got %q", want, detail)
+		}
+	}
+	if !strings.Contains(model.renderStatusBar(), "mixed versions 2") {
+		t.Fatalf("expected mixed versions badge in status bar")
+	}
+}
+
+func TestRolloutRanksReleaseAbovePrerelease(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoStatsURL = "http://django.local/reproq/stats/"
+	model := newTestModel(t, cfg)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	model = updated.(*Model)
+
+	now := time.Now()
+	updated, _ = model.Update(statsMsg{attempted: now, stats: models.DjangoStats{FetchedAt: now, Workers: []models.WorkerInfo{
+		{WorkerID: "w-1", Hostname: "host", LastSeenAt: now, Version: "1.2.0-rc1"},
+		{WorkerID: "w-2", Hostname: "host", LastSeenAt: now, Version: "1.2.0"},
+	}}})
+	model = updated.(*Model)
+
+	groups := model.rolloutGroups()
+	if len(groups) != 2 || groups[0].Version != "1.2.0" {
+		t.Fatalf("expected the release to lead the rollout, got %+v", groups)
+	}
+	if detail := model.renderRolloutDetail(); !strings.Contains(detail, "w-1 (host) 1.2.0-rc1") {
+		t.Fatalf("expected the prerelease worker to be flagged as behind, got %q", detail)
+	}
+}
+
+func TestRolloutIgnoresHealthPollOrder(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoStatsURL = "http://django.local/reproq/stats/"
+	model := newTestModel(t, cfg)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	model = updated.(*Model)
+
+	now := time.Now()
+	updated, _ = model.Update(healthMsg{status: models.HealthStatus{Healthy: true, Status: "ok", Version: "1.2.0", CheckedAt: now.Add(-time.Second)}})
+	model = updated.(*Model)
+	updated, _ = model.Update(statsMsg{attempted: now, stats: models.DjangoStats{FetchedAt: now, Workers: []models.WorkerInfo{
+		{WorkerID: "w-1", Hostname: "host", LastSeenAt: now, Version: "1.2.0"},
+		{WorkerID: "w-2", Hostname: "host", LastSeenAt: now, Version: "1.1.0"},
+	}}})
+	model = updated.(*Model)
+
+	groups := model.rolloutGroups()
+	if len(groups) != 2 || groups[0].Version != "1.2.0" {
+		t.Fatalf("expected the health poll not to demote 1.2.0, got %+v", groups)
+	}
+	detail := model.renderRolloutDetail()
+	if !strings.Contains(detail, "Old builds (1) behind 1.2.0") || strings.Contains(detail, "(old build)") {
+		t.Fatalf("expected only the 1.1.0 worker to be flagged, got %q", detail)
+	}
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adpena/reproq-tui/internal/stats"
+)
+
+const schedulerOverdueShown = 4
+
+func (m *Model) schedulerDiagnosis() (stats.SchedulerDiagnosis, bool) {
+	if !m.statsAvailable() {
+		return stats.SchedulerDiagnosis{}, false
+	}
+	return m.schedulerDiag, true
+}
+
+func (m *Model) schedulerStatusBadge() string {
+	diag, ok := m.schedulerDiagnosis()
+	if !ok || !diag.Broken {
+		return ""
+	}
+	return m.theme.Styles.StatusDown.Render("scheduler broken")
+}
+
+func (m *Model) renderSchedulerDetail() string {
+	diag, ok := m.schedulerDiagnosis()
+	if !ok {
+		return m.theme.Styles.Muted.Render("No Django stats configured.")
+	}
+	verdict := m.theme.Styles.StatusOK.Render("healthy")
+	switch {
+	case diag.Broken:
+		verdict = m.theme.Styles.StatusDown.Render("likely broken")
+	case diag.Severity() == stats.SchedulerWarn:
+		verdict = m.theme.Styles.StatusWarn.Render("needs attention")
+	}
+	lines := []string{m.labelValue("Verdict", verdict)}
+	if sched := diag.Status; sched != nil {
+		lines = append(lines,
+			m.labelValue("Mode", formatScheduler(sched)),
+			m.labelValue("Beat", fmt.Sprintf("enabled %s, configured %s", formatYesNo(sched.BeatEnabled), formatYesNo(sched.BeatConfigured))),
+			m.labelValue("pg_cron", formatYesNo(sched.PgCronAvailable)),
+		)
+	} else {
+		lines = append(lines, m.labelValue("Mode", m.theme.Styles.Muted.Render("not reported")))
+	}
+	overdue := fmt.Sprintf("%d of %d enabled", len(diag.Overdue), diag.Enabled)
+	if len(diag.Overdue) > 0 {
+		overdue = m.theme.Styles.StatusWarn.Render(overdue)
+	}
+	lines = append(lines, m.labelValue("Overdue", overdue))
+
+	if len(diag.Issues) == 0 {
+		lines = append(lines, "", m.theme.Styles.Muted.Render("No scheduler problems detected."))
+	} else {
+		lines = append(lines, "")
+		for _, issue := range diag.Issues {
+			lines = append(lines, m.schedulerIssueIcon(issue.Severity)+" "+issue.Summary)
+			for _, line := range wrapWords(issue.Detail, 60) {
+				lines = append(lines, "  "+m.theme.Styles.Muted.Render(line))
+			}
+		}
+	}
+	if len(diag.Overdue) > 0 {
+		lines = append(lines, "", "Overdue periodic tasks")
+		for i, status := range diag.Overdue {
+			if i >= schedulerOverdueShown {
+				lines = append(lines, m.theme.Styles.Muted.Render(fmt.Sprintf("  +%d more", len(diag.Overdue)-i)))
+				break
+			}
+			line := fmt.Sprintf("  %-24s overdue %s, missed %d", truncate(status.Task.Name, 24), formatDuration(status.Overdue), status.MissedRuns)
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) schedulerIssueIcon(severity stats.SchedulerSeverity) string {
+	switch severity {
+	case stats.SchedulerBroken:
+		return m.theme.Styles.StatusDown.Render("✗")
+	case stats.SchedulerWarn:
+		return m.theme.Styles.StatusWarn.Render("!")
+	}
+	return m.theme.Styles.Muted.Render("i")
+}
+
+func wrapWords(text string, width int) []string {
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(text) {
+		if current != "" && len(current)+1+len(word) > width {
+			lines = append(lines, current)
+			current = ""
+		}
+		if current != "" {
+			current += " "
+		}
+		current += word
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/pkg/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSchedulerPanelAndBadge(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoStatsURL = "http://django.local/reproq/stats/"
+	model := newTestModel(t, cfg)
+	send := func(msg tea.Msg) {
+		updated, _ := model.Update(msg)
+		model = updated.(*Model)
+	}
+	send(tea.WindowSizeMsg{Width: 180, Height: 50})
+
+	now := time.Date(2025, 6, 1, 12, 1, 0, 0, time.UTC)
+	stats := models.DjangoStats{
+		FetchedAt: now,
+		Periodic: []models.PeriodicTask{
+			{Name: "sync", CronExpr: "*/5 * * * *", Enabled: true, NextRunAt: now.Add(-11 * time.Minute)},
+		},
+		Scheduler: &models.SchedulerStatus{Mode: "beat", BeatEnabled: true, BeatConfigured: true},
+	}
+	send(statsMsg{attempted: now, stats: stats})
+	if !strings.Contains(model.View(), "scheduler broken") {
+		t.Fatalf("expected badge when every enabled periodic task is overdue")
+	}
+
+	stats.Periodic = append(stats.Periodic, models.PeriodicTask{Name: "cleanup", CronExpr: "*/5 * * * *", Enabled: true, NextRunAt: now.Add(4 * time.Minute)})
+	send(statsMsg{attempted: now, stats: stats})
+	if badge := model.schedulerStatusBadge(); badge != "" {
+		t.Fatalf("expected no badge while the scheduler is configured and only one task is late, got %q", badge)
+	}
+
+	stats.Scheduler = &models.SchedulerStatus{Mode: "beat", BeatConfigured: true}
+	send(statsMsg{attempted: now, stats: stats})
+	if !strings.Contains(model.View(), "scheduler broken") {
+		t.Fatalf("expected scheduler broken badge, got:\n%s", model.View())
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	for model.detailViews[model.detailIndex] != "Scheduler" {
+		send(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	view := model.View()
+	for _, want := range []string{"likely broken", "Beat is configured but not enabled", "1 of 2 enabled", "sync"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in scheduler panel, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestSchedulerPanelExplainsInfoIssues(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoStatsURL = "http://django.local/reproq/stats/"
+	model := newTestModel(t, cfg)
+
+	now := time.Date(2025, 6, 1, 12, 1, 0, 0, time.UTC)
+	updated, _ := model.Update(statsMsg{attempted: now, stats: models.DjangoStats{
+		FetchedAt: now,
+		Periodic:  []models.PeriodicTask{{Name: "sync", CronExpr: "*/5 * * * *", Enabled: true, NextRunAt: now.Add(4 * time.Minute)}},
+		Scheduler: &models.SchedulerStatus{Mode: "pg_cron", PgCronAvailable: true, BeatConfigured: true, LowMemory: true},
+	}})
+	model = updated.(*Model)
+
+	detail := model.renderSchedulerDetail()
+	for _, want := range []string{"Beat schedule configured but unused", "ignored while beat is disabled", "Low-memory mode", "detected from stats polling only"} {
+		if !strings.Contains(detail, want) {
+			t.Fatalf("expected %q in scheduler panel, got:\n%s", want, detail)
+		}
+	}
+
+	statuses := model.periodicStatuses()
+	if len(statuses) != 1 || &model.periodicStatuses()[0] != &statuses[0] {
+		t.Fatalf("expected periodic analysis to be cached between frames")
+	}
+}
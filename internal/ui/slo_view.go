@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/charts"
+	"github.com/adpena/reproq-tui/internal/health"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	sloTrendWindow = 5 * time.Minute
+	sloTrendPoints = 56
+)
+
+var sloBurnWindows = []time.Duration{5 * time.Minute, time.Hour, 6 * time.Hour}
+
+func (m *Model) recordScrape(at time.Time, err error) {
+	if at.IsZero() {
+		at = time.Now()
+	}
+	for _, skipped := range m.breakers.metricsGap.skipped(at) {
+		m.slo.Record(health.SignalScrape, skipped, false)
+	}
+	m.breakers.metricsGap = backoffGap{}
+	m.slo.Record(health.SignalScrape, at, err == nil)
+}
+
+func (m *Model) sloSignal() string {
+	if strings.TrimSpace(m.cfg.WorkerHealthURL) != "" {
+		if _, ok := m.slo.Availability(health.SignalHealth, time.Time{}, time.Now()); ok {
+			return health.SignalHealth
+		}
+	}
+	return health.SignalScrape
+}
+
+func (m *Model) sloBurnStyle(level health.BurnLevel) lipgloss.Style {
+	switch level {
+	case health.BurnFast:
+		return m.theme.Styles.StatusDown
+	case health.BurnSlow:
+		return m.theme.Styles.StatusWarn
+	default:
+		return m.theme.Styles.StatusOK
+	}
+}
+
+func (m *Model) sloStatusBadge(now time.Time) string {
+	signal := m.sloSignal()
+	if remaining, ok := m.slo.BudgetRemaining(signal, now); ok && remaining <= 0 {
+		return m.theme.Styles.StatusDown.Render("budget exhausted")
+	}
+	switch level := m.slo.BurnLevel(signal, now); level {
+	case health.BurnFast:
+		return m.sloBurnStyle(level).Render("fast burn")
+	case health.BurnSlow:
+		return m.sloBurnStyle(level).Render("slow burn")
+	}
+	return ""
+}
+
+// sloCard shows the budget left on the primary signal and its short-window
+// burn-rate trend, colored by burn level.
+func (m *Model) sloCard(width, height, chartWidth int) string {
+	now := time.Now()
+	signal := m.sloSignal()
+	level := m.slo.BurnLevel(signal, now)
+	value := "-"
+	if remaining, ok := m.slo.BudgetRemaining(signal, now); ok {
+		value = formatPercent(remaining) + " left"
+	}
+	chart := m.theme.Styles.Muted.Render("No burn-rate data yet")
+	if trend := m.slo.BurnSeries(signal, sloTrendWindow, now, chartWidth); len(trend) > 0 {
+		chart = m.sloBurnStyle(level).Render(charts.Sparkline(trend, chartWidth))
+	}
+	return m.chartCard("Error budget", value, chart, width, height, false, maxTime(m.lastScrapeAt, m.lastHealth.CheckedAt))
+}
+
+func (m *Model) renderSLODetail() string {
+	signals := m.slo.Signals()
+	if len(signals) == 0 {
+		return m.theme.Styles.Muted.Render("Waiting for health checks and scrapes.")
+	}
+	now := time.Now()
+	window := m.currentWindow()
+	primary := m.sloSignal()
+	budget := "-"
+	if remaining, ok := m.slo.BudgetRemaining(primary, now); ok {
+		budget = formatPercent(remaining)
+	}
+	level := m.slo.BurnLevel(primary, now)
+	lines := []string{
+		m.theme.Styles.Muted.Render(fmt.Sprintf("Target %s over %s (%s)", formatSLOTarget(m.slo.Target()), formatWindow(m.slo.Window()), primary)),
+		fmt.Sprintf("%s  %s", m.theme.Styles.CardTitle.Render("Budget remaining"), m.sloBurnStyle(level).Render(budget)),
+	}
+	if trend := m.slo.BurnSeries(primary, sloTrendWindow, now, sloTrendPoints); len(trend) > 0 {
+		lines = append(lines, m.sloBurnStyle(level).Render(charts.Sparkline(trend, sloTrendPoints)))
+	} else {
+		lines = append(lines, m.theme.Styles.Muted.Render("No burn-rate data yet"))
+	}
+	lines = append(lines, "")
+	for _, signal := range signals {
+		lines = append(lines, m.theme.Styles.PaneHeader.Render(strings.ToUpper(signal)))
+		current := "-"
+		if ratio, ok := m.slo.Availability(signal, now.Add(-window), now); ok {
+			current = formatAvailability(ratio)
+		}
+		overall := "-"
+		if ratio, ok := m.slo.Availability(signal, now.Add(-m.slo.Window()), now); ok {
+			overall = formatAvailability(ratio)
+		}
+		budget = "-"
+		if remaining, ok := m.slo.BudgetRemaining(signal, now); ok {
+			budget = formatPercent(remaining)
+		}
+		burns := make([]string, 0, len(sloBurnWindows))
+		for _, burnWindow := range sloBurnWindows {
+			burn := "-"
+			if rate, ok := m.slo.BurnRate(signal, burnWindow, now); ok {
+				burn = fmt.Sprintf("%.1fx", rate)
+			}
+			burns = append(burns, fmt.Sprintf("%s %s", formatWindow(burnWindow), burn))
+		}
+		level = m.slo.BurnLevel(signal, now)
+		lines = append(lines,
+			m.labelValue("Available "+formatWindow(window), current),
+			m.labelValue("Available "+formatWindow(m.slo.Window()), overall),
+			m.labelValue("Budget left", budget),
+			m.labelValue("Burn", m.sloBurnStyle(level).Render(strings.Join(burns, "  "))),
+		)
+		lines = append(lines, "")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+func formatSLOTarget(target float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.3f", target*100), "0"), ".") + "%"
+}
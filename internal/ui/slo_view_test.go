@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/internal/health"
+	"github.com/adpena/reproq-tui/pkg/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSLODrilldownShowsBudgetAndBurn(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.WorkerHealthURL = "http://worker.local/healthz"
+	model := newTestModel(t, cfg)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	model = updated.(*Model)
+
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		at := now.Add(-time.Duration(i) * time.Second)
+		updated, _ = model.Update(healthMsg{status: models.HealthStatus{Healthy: true, Status: "ok", CheckedAt: at}})
+		model = updated.(*Model)
+		var err error
+		if i%2 == 0 {
+			err = errors.New("connection refused")
+		}
+		updated, _ = model.Update(metricsMsg{attempted: at, err: err})
+		model = updated.(*Model)
+	}
+
+	detail := model.renderSLODetail()
+	for _, want := range []string{"Target 99.9% over 24h (health)", "Budget remaining  100.0%", "HEALTH", "SCRAPE", "50.00%", "5m 500.0x"} {
+		if !strings.Contains(detail, want) {
+			t.Fatalf("expected %q in SLO detail, got %q", want, detail)
+		}
+	}
+	if strings.Contains(model.renderStatusBar(), "burn") {
+		t.Fatalf("expected no burn badge while health is within budget")
+	}
+
+	if view := model.View(); !strings.Contains(view, "Error budget") || !strings.Contains(view, "100.0% left") {
+		t.Fatalf("expected error budget card on the dashboard, got %q", view)
+	}
+
+	model.cfg.WorkerHealthURL = ""
+	status := model.renderStatusBar()
+	if !strings.Contains(status, "fast burn") || strings.Contains(status, "budget exhausted") {
+		t.Fatalf("expected seconds of failing scrapes to burn fast without exhausting the budget, got %q", status)
+	}
+	for i := 1; i <= 24*60; i++ {
+		model.slo.Record(health.SignalScrape, now.Add(-time.Duration(i)*time.Minute), false)
+	}
+	if !strings.Contains(model.renderStatusBar(), "budget exhausted") {
+		t.Fatalf("expected budget badge after a day of failing scrapes")
+	}
+}
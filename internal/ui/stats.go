@@ -247,7 +247,7 @@ func (m *Model) splitWorkersByStatus(workers []models.WorkerInfo, now time.Time)
 	active := make([]models.WorkerInfo, 0, len(workers))
 	stale := make([]models.WorkerInfo, 0, len(workers))
 	for _, worker := range workers {
-		if worker.LastSeenAt.IsZero() || worker.LastSeenAt.Before(cutoff) {
+		if workerIsStale(worker, cutoff) {
 			stale = append(stale, worker)
 			continue
 		}
@@ -257,6 +257,14 @@ func (m *Model) splitWorkersByStatus(workers []models.WorkerInfo, now time.Time)
 }
 
 func (m *Model) workerActiveCutoff(now time.Time) time.Time {
+	return now.Add(-m.workerStaleAfter())
+}
+
+// workerStaleAfter is the heartbeat gap after which a worker counts as stale.
+func (m *Model) workerStaleAfter() time.Duration {
+	if m.cfg.WorkerStaleAfter > 0 {
+		return m.cfg.WorkerStaleAfter
+	}
 	window := 90 * time.Second
 	interval := m.cfg.StatsInterval
 	if interval <= 0 {
@@ -265,7 +273,7 @@ func (m *Model) workerActiveCutoff(now time.Time) time.Time {
 	if candidate := interval * 6; candidate > window {
 		window = candidate
 	}
-	return now.Add(-window)
+	return window
 }
 
 func (m *Model) statsPeriodicByNextRun() []models.PeriodicTask {
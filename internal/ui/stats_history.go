@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/charts"
+	"github.com/adpena/reproq-tui/internal/metrics"
+	"github.com/adpena/reproq-tui/internal/stats"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const statsTrendWidth = 10
+
+func (m *Model) statsHistoryCutoff() time.Time {
+	return metrics.WindowCutoff(m.currentWindow(), time.Now())
+}
+
+func (m *Model) statsTrendValues(key string) []float64 {
+	return valuesFromSamples(m.statsHistory.Series(key, m.statsHistoryCutoff()))
+}
+
+func (m *Model) statsGrowth(key string) string {
+	growth, ok := m.statsHistory.Growth(key, m.statsHistoryCutoff())
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%+d", int64(growth))
+}
+
+func (m *Model) statsTrendSuffix(key func(string) string) string {
+	values := m.statsTrendValues(key(stats.StatusTotal))
+	if len(values) < 2 {
+		return ""
+	}
+	failed := m.statsGrowth(key(stats.StatusFailed))
+	suffix := fmt.Sprintf(" %s F%s", charts.Sparkline(values, statsTrendWidth), failed)
+	if growth, ok := m.statsHistory.Growth(key(stats.StatusFailed), m.statsHistoryCutoff()); ok && growth > 0 {
+		return m.theme.Styles.StatusWarn.Render(suffix)
+	}
+	return suffix
+}
+
+func withTrend(line, suffix string, width int) string {
+	if suffix == "" {
+		return truncate(line, width)
+	}
+	return truncate(line, maxInt(10, width-lipgloss.Width(suffix))) + suffix
+}
+
+func (m *Model) queueTrendSuffix(queue string) string {
+	return m.statsTrendSuffix(func(status string) string {
+		return m.scopedQueueKey(queue, status)
+	})
+}
+
+func (m *Model) databaseTrendSuffix(alias string) string {
+	return m.statsTrendSuffix(func(status string) string {
+		return stats.DatabaseKey(alias, status)
+	})
+}
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/pkg/models"
+)
+
+func TestQueueAndDatabaseDrilldownsShowStatsTrends(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoStatsURL = "http://django.local/reproq/stats/"
+	model := newTestModel(t, cfg)
+
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		at := now.Add(time.Duration(i-5) * 10 * time.Second)
+		updated, _ := model.Update(statsMsg{attempted: at, stats: models.DjangoStats{
+			FetchedAt: at,
+			Tasks:     map[string]int64{"READY": 4, "FAILED": int64(i)},
+			Queues: map[string]map[string]int64{
+				"default": {"READY": int64(4 + i), "FAILED": int64(i)},
+			},
+			Databases: []models.DatabaseStats{{
+				Alias: "tenant_a",
+				Tasks: map[string]int64{"READY": 4, "FAILED": int64(i * 2)},
+			}},
+		}})
+		model = updated.(*Model)
+	}
+
+	queues := model.detailBody("Queues")
+	for _, want := range []string{"Failed 5m", "+4", "F+4"} {
+		if !strings.Contains(queues, want) {
+			t.Fatalf("expected %q in queues drilldown, got %q", want, queues)
+		}
+	}
+	databases := model.detailBody("Databases")
+	if !strings.Contains(databases, "tenant_a") || !strings.Contains(databases, "F+8") {
+		t.Fatalf("expected database trend, got %q", databases)
+	}
+}
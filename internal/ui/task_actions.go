@@ -0,0 +1,401 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/internal/control"
+	"github.com/adpena/reproq-tui/pkg/client"
+	"github.com/adpena/reproq-tui/pkg/models"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const failedRunsLimit = 20
+
+type taskRunsMsg struct {
+	taskPath string
+	runs     []models.TaskRun
+	err      error
+}
+
+type taskDetailMsg struct {
+	task models.TaskRun
+	err  error
+}
+
+type taskActionMsg struct {
+	action string
+	req    control.TaskActionRequest
+	result control.TaskActionResult
+	err    error
+}
+
+type taskOps struct {
+	taskPath   string
+	runs       []models.TaskRun
+	loading    bool
+	err        error
+	selected   int
+	inspect    *models.TaskRun
+	inspectErr error
+}
+
+func (m *Model) taskDetailActive() bool {
+	return m.detailActive && m.detailViews[m.detailIndex%len(m.detailViews)] == "Tasks"
+}
+
+func (m *Model) failingTaskPaths() []string {
+	if !m.statsAvailable() {
+		return nil
+	}
+	paths := make([]string, 0, len(m.lastStats.TopFailing))
+	for _, task := range m.lastStats.TopFailing {
+		paths = append(paths, task.TaskPath)
+	}
+	return paths
+}
+
+func (m *Model) selectedTaskPath() (string, bool) {
+	if m.taskOps != nil {
+		return m.taskOps.taskPath, true
+	}
+	paths := m.failingTaskPaths()
+	if len(paths) == 0 {
+		return "", false
+	}
+	if m.taskSelected >= len(paths) {
+		m.taskSelected = len(paths) - 1
+	}
+	return paths[m.taskSelected], true
+}
+
+func (m *Model) selectedTaskRun() (models.TaskRun, bool) {
+	if m.taskOps == nil || len(m.taskOps.runs) == 0 {
+		return models.TaskRun{}, false
+	}
+	if m.taskOps.selected >= len(m.taskOps.runs) {
+		m.taskOps.selected = len(m.taskOps.runs) - 1
+	}
+	return m.taskOps.runs[m.taskOps.selected], true
+}
+
+func (m *Model) handleTaskKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch {
+	case msg.Type == tea.KeyEsc && m.taskOps != nil:
+		if m.taskOps.inspect != nil || m.taskOps.inspectErr != nil {
+			m.taskOps.inspect = nil
+			m.taskOps.inspectErr = nil
+		} else {
+			m.taskOps = nil
+		}
+		return nil, true
+	case key.Matches(msg, m.keymap.EventUp):
+		m.moveTaskSelection(-1)
+		return nil, true
+	case key.Matches(msg, m.keymap.EventDown):
+		m.moveTaskSelection(1)
+		return nil, true
+	case key.Matches(msg, m.keymap.EventExpand):
+		return m.openTaskSelection(), true
+	case key.Matches(msg, m.keymap.TaskRetry):
+		return m.confirmRetrySelected(), true
+	case key.Matches(msg, m.keymap.TaskRetryAll):
+		return m.confirmTaskPathAction(control.ActionRetry), true
+	case key.Matches(msg, m.keymap.TaskCancel):
+		return m.confirmTaskPathAction(control.ActionCancel), true
+	}
+	return nil, false
+}
+
+func (m *Model) moveTaskSelection(delta int) {
+	if m.taskOps != nil {
+		if m.taskOps.inspect != nil || len(m.taskOps.runs) == 0 {
+			return
+		}
+		m.taskOps.selected = (m.taskOps.selected + delta + len(m.taskOps.runs)) % len(m.taskOps.runs)
+		return
+	}
+	paths := m.failingTaskPaths()
+	if len(paths) == 0 {
+		m.taskSelected = 0
+		return
+	}
+	m.taskSelected = (m.taskSelected + delta + len(paths)) % len(paths)
+}
+
+func (m *Model) openTaskSelection() tea.Cmd {
+	baseURL := m.actionBaseURL()
+	if baseURL == "" {
+		return m.showToast("Task operations need a Django URL", 3*time.Second)
+	}
+	if m.taskOps == nil {
+		path, ok := m.selectedTaskPath()
+		if !ok {
+			return m.showToast("No failing tasks reported", 2*time.Second)
+		}
+		m.taskOps = &taskOps{taskPath: path, loading: true}
+		return listFailedTasksCmd(m.cfg, m.endpoints.django, baseURL, path, m.dbScope)
+	}
+	run, ok := m.selectedTaskRun()
+	if !ok {
+		return nil
+	}
+	if m.taskOps.inspect != nil {
+		m.taskOps.inspect = nil
+		return nil
+	}
+	return fetchTaskCmd(m.cfg, m.endpoints.django, baseURL, run.ResultID)
+}
+
+func (m *Model) confirmRetrySelected() tea.Cmd {
+	run, ok := m.selectedTaskRun()
+	if !ok {
+		return m.showToast("Open failed runs with enter to retry one", 2*time.Second)
+	}
+	req := control.TaskActionRequest{ResultIDs: []int64{run.ResultID}, Database: m.dbScope}
+	return m.confirmTaskAction(control.ActionRetry, fmt.Sprintf("Retry task #%d (%s)?", run.ResultID, truncate(run.TaskPath, 32)), req)
+}
+
+func (m *Model) confirmTaskPathAction(action string) tea.Cmd {
+	path, ok := m.selectedTaskPath()
+	if !ok {
+		return m.showToast("No failing tasks reported", 2*time.Second)
+	}
+	req := control.TaskActionRequest{TaskPath: path, Database: m.dbScope}
+	message := fmt.Sprintf("Retry all failed %s?", truncate(path, 40))
+	if action == control.ActionCancel {
+		message = fmt.Sprintf("Cancel queued %s?", truncate(path, 40))
+	}
+	return m.confirmTaskAction(action, message, req)
+}
+
+func (m *Model) confirmTaskAction(action, message string, req control.TaskActionRequest) tea.Cmd {
+	baseURL := m.actionBaseURL()
+	if baseURL == "" {
+		return m.showToast("Task operations need a Django URL", 3*time.Second)
+	}
+	m.openConfirm(message, func(reason string) tea.Cmd {
+		req.Reason = reason
+		return taskActionCmd(m.cfg, m.endpoints.django, baseURL, action, req)
+	})
+	return nil
+}
+
+func (m *Model) handleTaskRuns(msg taskRunsMsg) tea.Cmd {
+	if m.taskOps == nil || m.taskOps.taskPath != msg.taskPath {
+		return nil
+	}
+	m.taskOps.loading = false
+	m.taskOps.err = msg.err
+	if msg.err == nil {
+		m.taskOps.runs = msg.runs
+		m.taskOps.selected = minInt(m.taskOps.selected, maxInt(0, len(msg.runs)-1))
+	}
+	if isAuthError(msg.err) {
+		return m.showToast("Task listing denied: press l to sign in", 3*time.Second)
+	}
+	return nil
+}
+
+func (m *Model) handleTaskDetail(msg taskDetailMsg) {
+	if m.taskOps == nil {
+		return
+	}
+	m.taskOps.inspectErr = msg.err
+	if msg.err == nil {
+		task := msg.task
+		m.taskOps.inspect = &task
+	}
+}
+
+func (m *Model) handleTaskAction(msg taskActionMsg) tea.Cmd {
+	target := msg.req.TaskPath
+	if len(msg.req.ResultIDs) > 0 {
+		ids := make([]string, 0, len(msg.req.ResultIDs))
+		for _, id := range msg.req.ResultIDs {
+			ids = append(ids, fmt.Sprintf("#%d", id))
+		}
+		target = strings.Join(ids, ",")
+	}
+	entry := control.AuditEntry{
+		Action:   "task." + msg.action,
+		Target:   target,
+		Database: msg.req.Database,
+		Reason:   msg.req.Reason,
+		Affected: msg.result.Affected,
+	}
+	if msg.err != nil {
+		entry.Error = msg.err.Error()
+	}
+	m.recordAudit(entry)
+	if msg.err != nil {
+		if isAuthError(msg.err) {
+			return m.showToast("Task action denied: press l to sign in", 3*time.Second)
+		}
+		return m.showToast(fmt.Sprintf("%s failed: %v", msg.action, msg.err), 3*time.Second)
+	}
+	verb := "Retried"
+	if msg.action == control.ActionCancel {
+		verb = "Canceled"
+	}
+	cmds := []tea.Cmd{m.showToast(fmt.Sprintf("%s %d task(s)", verb, msg.result.Affected), 2*time.Second)}
+	if m.taskOps != nil {
+		m.taskOps.inspect = nil
+		m.taskOps.loading = true
+		cmds = append(cmds, listFailedTasksCmd(m.cfg, m.endpoints.django, m.actionBaseURL(), m.taskOps.taskPath, m.dbScope))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) renderTaskOps() string {
+	ops := m.taskOps
+	lines := []string{m.labelValue("Task", truncate(ops.taskPath, 48))}
+	if ops.inspect != nil {
+		return strings.Join(append(lines, m.renderTaskRun(*ops.inspect)...), "\n")
+	}
+	if ops.inspectErr != nil {
+		lines = append(lines, m.theme.Styles.StatusDown.Render(truncate("Inspect failed: "+ops.inspectErr.Error(), 60)))
+	}
+	switch {
+	case ops.loading && len(ops.runs) == 0:
+		lines = append(lines, "", m.theme.Styles.Muted.Render("Loading failed runs..."))
+	case ops.err != nil:
+		lines = append(lines, "", m.theme.Styles.StatusDown.Render(truncate("Load failed: "+ops.err.Error(), 60)))
+	case len(ops.runs) == 0:
+		lines = append(lines, "", m.theme.Styles.Muted.Render("No failed runs."))
+	default:
+		lines = append(lines, "", fmt.Sprintf("Failed runs (%d)", len(ops.runs)))
+		start := maxInt(0, ops.selected-9)
+		for i, run := range ops.runs {
+			if i < start || i >= start+10 {
+				continue
+			}
+			marker := "  "
+			if i == ops.selected {
+				marker = "> "
+			}
+			when := formatRelative(run.FinishedAt)
+			line := fmt.Sprintf("%s#%-7d %-8s x%d %s", marker, run.ResultID, when, run.Attempts, exceptionSummary(run.Exception))
+			lines = append(lines, truncate(line, 64))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) renderTaskRun(run models.TaskRun) []string {
+	lines := []string{
+		m.labelValue("Result", fmt.Sprintf("#%d (%s)", run.ResultID, strings.ToLower(run.Status))),
+		m.labelValue("Queue", firstNonEmptyString(run.QueueName, "-")),
+		m.labelValue("Attempts", fmt.Sprintf("%d", run.Attempts)),
+		m.labelValue("Finished", formatRelative(run.FinishedAt)),
+		m.labelValue("Args", truncate(compactJSON(run.Args), 48)),
+		m.labelValue("Kwargs", truncate(compactJSON(run.Kwargs), 48)),
+	}
+	if run.Exception != "" {
+		lines = append(lines, m.theme.Styles.StatusDown.Render(truncate(run.Exception, 60)))
+	}
+	if run.Traceback != "" {
+		lines = append(lines, "", "Traceback")
+		tb := strings.Split(strings.TrimRight(run.Traceback, "\n"), "\n")
+		if len(tb) > 8 {
+			tb = tb[len(tb)-8:]
+		}
+		for _, line := range tb {
+			lines = append(lines, m.theme.Styles.Muted.Render(truncate(line, 64)))
+		}
+	}
+	return lines
+}
+
+func (m *Model) taskActionHint() string {
+	if !m.taskDetailActive() {
+		return ""
+	}
+	if m.taskOps != nil {
+		if m.taskOps.inspect != nil {
+			return "enter/esc back | R retry"
+		}
+		return "enter inspect | R retry | A retry all | C cancel queued | esc back"
+	}
+	if len(m.failingTaskPaths()) == 0 {
+		return ""
+	}
+	return "j/k select | enter runs | A retry all | C cancel"
+}
+
+func exceptionSummary(exception string) string {
+	summary := strings.TrimSpace(exception)
+	if idx := strings.IndexByte(summary, '\n'); idx >= 0 {
+		summary = summary[:idx]
+	}
+	if summary == "" {
+		return "-"
+	}
+	return summary
+}
+
+func compactJSON(raw json.RawMessage) string {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return "-"
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
+
+func firstNonEmptyString(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func listFailedTasksCmd(cfg config.Config, httpClient *client.Client, baseURL, taskPath, database string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+		defer cancel()
+		runs, err := control.ListTasks(ctx, httpClient, baseURL, control.TaskQuery{
+			TaskPath: taskPath,
+			Status:   "FAILED",
+			Database: database,
+			Limit:    failedRunsLimit,
+		})
+		return taskRunsMsg{taskPath: taskPath, runs: runs, err: err}
+	}
+}
+
+func fetchTaskCmd(cfg config.Config, httpClient *client.Client, baseURL string, resultID int64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+		defer cancel()
+		task, err := control.GetTask(ctx, httpClient, baseURL, resultID)
+		return taskDetailMsg{task: task, err: err}
+	}
+}
+
+func taskActionCmd(cfg config.Config, httpClient *client.Client, baseURL, action string, req control.TaskActionRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+		defer cancel()
+		var (
+			result control.TaskActionResult
+			err    error
+		)
+		if action == control.ActionCancel {
+			result, err = control.CancelTasks(ctx, httpClient, baseURL, req)
+		} else {
+			result, err = control.RetryTasks(ctx, httpClient, baseURL, req)
+		}
+		return taskActionMsg{action: action, req: req, result: result, err: err}
+	}
+}
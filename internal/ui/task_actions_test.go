@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/internal/control"
+	"github.com/adpena/reproq-tui/pkg/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTaskOperationsInspectAndRetryAll(t *testing.T) {
+	var retried control.TaskActionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/reproq/tasks/":
+			_, _ = w.Write([]byte(`{"tasks":[{"result_id":11,"task_path":"app.tasks.sync","status":"FAILED","attempts":2,"exception":"KeyError: 'sku'\nmore"}]}`))
+		case "/reproq/tasks/11/":
+			_, _ = w.Write([]byte(`{"result_id":11,"task_path":"app.tasks.sync","status":"FAILED","args":["sku-1"],"traceback":"Traceback\n  File x\nKeyError: 'sku'"}`))
+		case "/reproq/tasks/retry/":
+			_ = json.NewDecoder(r.Body).Decode(&retried)
+			_, _ = w.Write([]byte(`{"affected":3}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoStatsURL = server.URL + "/reproq/stats/"
+	cfg.AuditLogFile = filepath.Join(t.TempDir(), "audit.jsonl")
+	model := newTestModel(t, cfg)
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	model = updated.(*Model)
+
+	now := time.Now()
+	updated, _ = model.Update(statsMsg{attempted: now, stats: models.DjangoStats{
+		FetchedAt: now,
+		TopFailing: []models.FailingTask{
+			{TaskPath: "app.tasks.email", Count: 9},
+			{TaskPath: "app.tasks.sync", Count: 3},
+		},
+	}})
+	model = updated.(*Model)
+
+	send := func(msg tea.Msg) tea.Cmd {
+		updated, cmd := model.Update(msg)
+		model = updated.(*Model)
+		return cmd
+	}
+	runes := func(value string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(value)}
+	}
+
+	send(runes("d"))
+	for model.detailViews[model.detailIndex] != "Tasks" {
+		send(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	send(runes("j"))
+	cmd := send(tea.KeyMsg{Type: tea.KeyEnter})
+	if model.taskOps == nil || model.taskOps.taskPath != "app.tasks.sync" {
+		t.Fatalf("expected failed runs for app.tasks.sync, got %+v", model.taskOps)
+	}
+	send(cmd())
+	view := model.View()
+	if !strings.Contains(view, "#11") || !strings.Contains(view, "KeyError: 'sku'") || strings.Contains(view, "more") {
+		t.Fatalf("expected failed run with exception summary, got:\n%s", view)
+	}
+
+	send(send(tea.KeyMsg{Type: tea.KeyEnter})())
+	if model.taskOps.inspect == nil || !strings.Contains(model.View(), `["sku-1"]`) {
+		t.Fatalf("expected inspected args and traceback")
+	}
+	send(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.taskOps == nil || model.taskOps.inspect != nil || !model.detailActive {
+		t.Fatalf("expected esc to return to the run list")
+	}
+
+	send(runes("A"))
+	if model.confirm == nil {
+		t.Fatalf("expected retry-all to require confirmation")
+	}
+	for _, r := range "bad deploy" {
+		send(runes(string(r)))
+	}
+	cmd = send(tea.KeyMsg{Type: tea.KeyEnter})
+	if retried.TaskPath != "" {
+		t.Fatalf("expected no request before the command runs")
+	}
+	send(cmd())
+	if retried.TaskPath != "app.tasks.sync" || retried.Reason != "bad deploy" {
+		t.Fatalf("unexpected retry request %+v", retried)
+	}
+	if !strings.Contains(model.toast, "Retried 3") {
+		t.Fatalf("expected retry toast, got %q", model.toast)
+	}
+
+	data, err := os.ReadFile(cfg.AuditLogFile)
+	if err != nil {
+		t.Fatalf("read audit log: %v", err)
+	}
+	var entry control.AuditEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		t.Fatalf("decode audit entry: %v", err)
+	}
+	if entry.Action != "task.retry" || entry.Target != "app.tasks.sync" || entry.Affected != 3 || entry.Reason != "bad deploy" {
+		t.Fatalf("unexpected audit entry %+v", entry)
+	}
+}
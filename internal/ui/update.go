@@ -15,6 +15,7 @@ import (
 
 	"github.com/adpena/reproq-tui/internal/auth"
 	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/internal/events"
 	"github.com/adpena/reproq-tui/internal/health"
 	"github.com/adpena/reproq-tui/internal/metrics"
 	"github.com/adpena/reproq-tui/internal/stats"
@@ -43,6 +44,7 @@ type healthTickMsg struct{}
 
 type statsMsg struct {
 	stats     models.DjangoStats
+	info      stats.FetchInfo
 	err       error
 	attempted time.Time
 	latency   time.Duration
@@ -94,6 +96,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.filterActive {
 			return m.handleFilterInput(msg)
 		}
+		if m.confirm != nil {
+			return m.handleConfirmInput(msg)
+		}
 		return m.handleKey(msg)
 	case metricsMsg:
 		if m.setupActive {
@@ -102,24 +107,30 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.lastScrapeAt = msg.attempted
 		m.lastScrapeDelay = msg.latency
 		m.lastScrapeErr = msg.err
+		m.breakers.metrics.Record(msg.err, msg.attempted)
+		m.recordScrape(msg.attempted, msg.err)
 		autoLogin := m.noteAuthError(msg.err)
 		if msg.err == nil {
+			m.prevSnapshot = m.lastSnapshot
 			m.lastSnapshot = msg.snapshot
 			m.applySnapshot(msg.snapshot)
 			m.authNeeded = false
 			m.authErr = nil
 		}
 		if !m.paused {
-			tick := tea.Tick(m.cfg.Interval, func(time.Time) tea.Msg {
+			now := time.Now()
+			delay := m.breakers.metrics.Delay(m.cfg.Interval, now)
+			m.breakers.metricsGap = newBackoffGap(now, m.cfg.Interval, delay)
+			tick := tea.Tick(delay, func(time.Time) tea.Msg {
 				return metricsTickMsg{}
 			})
 			if autoLogin {
-				return m, tea.Batch(tick, startAuthCmd(m.cfg, m.client))
+				return m, tea.Batch(tick, startAuthCmd(m.cfg, m.endpoints.django))
 			}
 			return m, tick
 		}
 		if autoLogin {
-			return m, startAuthCmd(m.cfg, m.client)
+			return m, startAuthCmd(m.cfg, m.endpoints.django)
 		}
 		return m, nil
 	case healthMsg:
@@ -128,18 +139,23 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.lastHealth = msg.status
 		m.lastHealthErr = msg.err
+		m.breakers.health.Record(msg.err, time.Now())
+		m.recordHealth(msg.status.CheckedAt)
 		autoLogin := m.noteAuthError(msg.err)
 		if !m.paused {
-			tick := tea.Tick(m.cfg.HealthInterval, func(time.Time) tea.Msg {
+			now := time.Now()
+			delay := m.breakers.health.Delay(m.cfg.HealthInterval, now)
+			m.breakers.healthGap = newBackoffGap(now, m.cfg.HealthInterval, delay)
+			tick := tea.Tick(delay, func(time.Time) tea.Msg {
 				return healthTickMsg{}
 			})
 			if autoLogin {
-				return m, tea.Batch(tick, startAuthCmd(m.cfg, m.client))
+				return m, tea.Batch(tick, startAuthCmd(m.cfg, m.endpoints.django))
 			}
 			return m, tick
 		}
 		if autoLogin {
-			return m, startAuthCmd(m.cfg, m.client)
+			return m, startAuthCmd(m.cfg, m.endpoints.django)
 		}
 		return m, nil
 	case statsMsg:
@@ -148,45 +164,67 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.lastStatsAt = msg.attempted
 		m.lastStatsDelay = msg.latency
+		m.lastStatsInfo = msg.info
 		m.lastStatsErr = msg.err
+		m.breakers.stats.Record(msg.err, msg.attempted)
 		autoLogin := m.noteAuthError(msg.err)
 		if msg.err == nil {
-			m.lastStats = msg.stats
+			m.rawStats = msg.stats
+			m.reconcileQueueActions(msg.attempted)
+			m.applyDatabaseScope()
+			m.rollout.ObserveWorkers(msg.attempted, msg.stats.Workers)
+			m.statsHistory.Record(msg.stats, msg.attempted)
 		}
 		if !m.paused {
-			tick := tea.Tick(m.cfg.StatsInterval, func(time.Time) tea.Msg {
+			tick := tea.Tick(m.breakers.stats.Delay(m.cfg.StatsInterval, time.Now()), func(time.Time) tea.Msg {
 				return statsTickMsg{}
 			})
 			if autoLogin {
-				return m, tea.Batch(tick, startAuthCmd(m.cfg, m.client))
+				return m, tea.Batch(tick, startAuthCmd(m.cfg, m.endpoints.django))
 			}
 			return m, tick
 		}
 		if autoLogin {
-			return m, startAuthCmd(m.cfg, m.client)
+			return m, startAuthCmd(m.cfg, m.endpoints.django)
 		}
 		return m, nil
+	case queueActionMsg:
+		return m, m.handleQueueAction(msg)
+	case taskRunsMsg:
+		return m, m.handleTaskRuns(msg)
+	case taskDetailMsg:
+		m.handleTaskDetail(msg)
+		return m, nil
+	case taskActionMsg:
+		return m, m.handleTaskAction(msg)
+	case periodicActionMsg:
+		return m, m.handlePeriodicAction(msg)
 	case metricsTickMsg:
 		if m.paused || m.setupActive || m.cfg.WorkerMetricsURL == "" {
 			return m, nil
 		}
-		return m, pollMetricsCmd(m.cfg, m.client, m.catalog)
+		return m, pollMetricsCmd(m.cfg, m.endpoints.metrics, m.catalog)
 	case healthTickMsg:
 		if m.paused || m.setupActive || m.cfg.WorkerHealthURL == "" {
 			return m, nil
 		}
-		return m, pollHealthCmd(m.cfg, m.client)
+		return m, pollHealthCmd(m.cfg, m.endpoints.health)
 	case statsTickMsg:
 		if m.paused || m.setupActive || !m.statsEnabled {
 			return m, nil
 		}
-		return m, pollStatsCmd(m.cfg, m.client)
+		return m, pollStatsCmd(m.cfg, m.statsFetcher)
+	case historyMsg:
+		return m, m.handleHistory(msg)
+	case eventRateTickMsg:
+		if !m.eventsEnabled {
+			return m, nil
+		}
+		m.eventRates.Flush(time.Now())
+		return m, eventRateTickCmd(m.cfg.Interval)
 	case eventMsg:
 		if m.eventsEnabled {
-			m.eventsBuffer.Add(msg.event)
-			if !msg.event.Timestamp.IsZero() {
-				m.lastEventAt = msg.event.Timestamp
-			}
+			m.recordEvent(msg.event)
 			return m, listenEventsCmd(m.eventsCh)
 		}
 		return m, nil
@@ -202,6 +240,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			})
 		}
 		m.authPair = msg.pair
+		m.deviceAuth = auth.DeviceAuthorization{}
 		m.authFlowActive = true
 		m.authErr = nil
 		m.applyLowMemoryMode(msg.pair.LowMemoryMode)
@@ -232,6 +271,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		} else {
 			m.applyLowMemoryMode(msg.cfg.LowMemoryMode)
+			cmds = append(cmds, m.rememberTokenConfig(msg.cfg))
 			m.setupNotice = ""
 			if cmd := m.applyWorkerConfigFromConfig(msg.cfg); cmd != nil {
 				cmds = append(cmds, cmd)
@@ -242,17 +282,30 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.cfg.AutoLogin && m.authHeaderManaged && m.authEnabled && m.authToken.Value == "" && !m.authFlowActive {
 			m.authFlowActive = true
 			m.authPair = auth.Pairing{}
-			cmds = append(cmds, startAuthCmd(m.cfg, m.client))
+			cmds = append(cmds, startAuthCmd(m.cfg, m.endpoints.django))
 		}
 		if len(cmds) == 0 {
 			return m, nil
 		}
 		return m, tea.Batch(cmds...)
+	case authExpiryTickMsg:
+		return m, tea.Batch(m.checkAuthExpiry(time.Now()), authExpiryTickCmd())
+	case authRefreshMsg:
+		return m, m.handleAuthRefresh(msg)
+	case authSaveMsg:
+		return m, m.handleAuthSave(msg)
 	case authTickMsg:
 		if !m.authFlowActive {
 			return m, nil
 		}
-		return m, pollAuthCmd(m.cfg, m.client, m.authPair.Code)
+		if m.deviceAuth.DeviceCode != "" {
+			return m, pollDeviceCmd(m.cfg, m.deviceConfig, m.deviceAuth.DeviceCode)
+		}
+		return m, pollAuthCmd(m.cfg, m.endpoints.django, m.authPair.Code)
+	case deviceAuthMsg:
+		return m, m.handleDeviceAuth(msg)
+	case deviceTokenMsg:
+		return m, m.handleDeviceToken(msg)
 	case authStatusMsg:
 		if msg.err != nil {
 			if client.IsStatus(msg.err, http.StatusNotFound) {
@@ -273,31 +326,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.status.Status {
 		case "approved":
 			token := auth.Token{Value: msg.status.Token, ExpiresAt: msg.status.ExpiresAt, DjangoURL: m.cfg.DjangoURL}
-			if err := m.applyAuthToken(token); err != nil {
-				m.toast = fmt.Sprintf("Auth save failed: %v", err)
-			} else {
-				m.toast = "Signed in"
-			}
-			m.authFlowActive = false
-			m.authPair = auth.Pairing{}
-			m.toastExpiry = time.Now().Add(3 * time.Second)
-			cmds := []tea.Cmd{
-				tea.Tick(3*time.Second, func(time.Time) tea.Msg {
-					return toastClearMsg{}
-				}),
-			}
-			if !m.paused {
-				if m.cfg.WorkerMetricsURL != "" {
-					cmds = append(cmds, pollMetricsCmd(m.cfg, m.client, m.catalog))
-				}
-				if m.cfg.WorkerHealthURL != "" {
-					cmds = append(cmds, pollHealthCmd(m.cfg, m.client))
-				}
-				if m.statsEnabled {
-					cmds = append(cmds, pollStatsCmd(m.cfg, m.client))
-				}
-			}
-			return m, tea.Batch(cmds...)
+			return m, m.finishSignIn(token)
 		case "pending":
 			return m, tea.Tick(time.Second, func(time.Time) tea.Msg {
 				return authTickMsg{}
@@ -338,18 +367,28 @@ func (m *Model) handleFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.Type {
 	case tea.KeyEsc:
 		m.filterActive = false
+		m.filterErr = nil
 		return m, nil
 	case tea.KeyEnter:
 		raw := strings.TrimSpace(m.filterInput.Value())
-		parsed := parseSSEFilter(raw)
+		parsed, err := parseSSEFilter(raw)
+		if err != nil {
+			m.filterErr = err
+			return m, nil
+		}
 		m.filter = raw
-		m.filterLocal = parsed.local
+		m.filterQuery = parsed.query
+		if events.IsLocalSource(m.eventsBaseURL) {
+			m.filterQuery, _ = parseEventQuery(raw)
+		}
+		m.filterErr = nil
 		m.applyEventFilter(parsed)
 		m.filterActive = false
-		return m, nil
+		return m, m.searchHistoryCmd()
 	}
 	var cmd tea.Cmd
 	m.filterInput, cmd = m.filterInput.Update(msg)
+	_, m.filterErr = parseSSEFilter(m.filterInput.Value())
 	return m, cmd
 }
 
@@ -394,7 +433,7 @@ func (m *Model) handleSetupInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if raw == "" {
 			if strings.TrimSpace(m.cfg.DjangoURL) != "" {
 				m.setupNotice = "Checking Django config..."
-				return m, fetchTUIConfigCmd(m.cfg, m.client)
+				return m, fetchTUIConfigCmd(m.cfg, m.endpoints.django)
 			}
 			m.setupNotice = "Enter a worker URL or full /metrics URL."
 			return m, nil
@@ -455,6 +494,7 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if msg.Type == tea.KeyEsc || key.Matches(msg, m.keymap.Auth) {
 			m.authFlowActive = false
 			m.authPair = auth.Pairing{}
+			m.deviceAuth = auth.DeviceAuthorization{}
 			m.toast = "Auth canceled"
 			m.toastExpiry = time.Now().Add(2 * time.Second)
 			return m, tea.Tick(2*time.Second, func(time.Time) tea.Msg {
@@ -462,6 +502,21 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			})
 		}
 	}
+	if m.taskDetailActive() {
+		if cmd, handled := m.handleTaskKey(msg); handled {
+			return m, cmd
+		}
+	}
+	if m.periodicDetailActive() {
+		if cmd, handled := m.handlePeriodicKey(msg); handled {
+			return m, cmd
+		}
+	}
+	if m.workerDetailActive() {
+		if cmd, handled := m.handleWorkerKey(msg); handled {
+			return m, cmd
+		}
+	}
 	if m.detailActive {
 		switch {
 		case msg.Type == tea.KeyEsc || key.Matches(msg, m.keymap.Drilldown):
@@ -471,6 +526,31 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.detailIndex = (m.detailIndex + 1) % len(m.detailViews)
 			return m, nil
 		}
+		if m.queueDetailActive() {
+			switch {
+			case key.Matches(msg, m.keymap.EventUp):
+				m.moveQueueSelection(-1)
+				return m, nil
+			case key.Matches(msg, m.keymap.EventDown):
+				m.moveQueueSelection(1)
+				return m, nil
+			case key.Matches(msg, m.keymap.QueueAction):
+				return m, m.startQueuePrompt()
+			}
+		}
+	}
+	if m.focus == focusRight && m.showEvents && m.groupEvents {
+		switch {
+		case key.Matches(msg, m.keymap.EventUp):
+			m.moveEventSelection(-1)
+			return m, nil
+		case key.Matches(msg, m.keymap.EventDown):
+			m.moveEventSelection(1)
+			return m, nil
+		case key.Matches(msg, m.keymap.EventExpand):
+			m.toggleEventExpansion()
+			return m, nil
+		}
 	}
 	switch {
 	case key.Matches(msg, m.keymap.Help):
@@ -483,24 +563,26 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.paused = !m.paused
 		if !m.paused {
 			cmds := []tea.Cmd{
-				pollMetricsCmd(m.cfg, m.client, m.catalog),
-				pollHealthCmd(m.cfg, m.client),
+				pollMetricsCmd(m.cfg, m.endpoints.metrics, m.catalog),
+				pollHealthCmd(m.cfg, m.endpoints.health),
 			}
 			if m.statsEnabled {
-				cmds = append(cmds, pollStatsCmd(m.cfg, m.client))
+				cmds = append(cmds, pollStatsCmd(m.cfg, m.statsFetcher))
 			}
 			return m, tea.Batch(cmds...)
 		}
 		return m, nil
 	case key.Matches(msg, m.keymap.Refresh):
 		cmds := []tea.Cmd{
-			pollMetricsCmd(m.cfg, m.client, m.catalog),
-			pollHealthCmd(m.cfg, m.client),
+			pollMetricsCmd(m.cfg, m.endpoints.metrics, m.catalog),
+			pollHealthCmd(m.cfg, m.endpoints.health),
 		}
 		if m.statsEnabled {
-			cmds = append(cmds, pollStatsCmd(m.cfg, m.client))
+			cmds = append(cmds, pollStatsCmd(m.cfg, m.statsFetcher))
 		}
 		return m, tea.Batch(cmds...)
+	case key.Matches(msg, m.keymap.Database):
+		return m, m.cycleDatabaseScope()
 	case key.Matches(msg, m.keymap.WindowShort):
 		m.windowIndex = 0
 		return m, nil
@@ -518,6 +600,7 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case key.Matches(msg, m.keymap.Filter):
 		m.filterActive = true
+		m.filterErr = nil
 		m.filterInput.SetValue(m.filter)
 		m.filterInput.CursorEnd()
 		return m, nil
@@ -527,6 +610,18 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.focus = focusLeft
 		}
 		return m, nil
+	case key.Matches(msg, m.keymap.GroupEvents):
+		m.groupEvents = !m.groupEvents
+		m.eventsExpanded = ""
+		if m.groupEvents {
+			m.toast = "Events grouped"
+		} else {
+			m.toast = "Events ungrouped"
+		}
+		m.toastExpiry = time.Now().Add(2 * time.Second)
+		return m, tea.Tick(2*time.Second, func(time.Time) tea.Msg {
+			return toastClearMsg{}
+		})
 	case key.Matches(msg, m.keymap.ToggleTheme):
 		m.cfg.Theme = toggleTheme(m.cfg.Theme)
 		m.theme = theme.Resolve(m.cfg.Theme)
@@ -548,16 +643,11 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return toastClearMsg{}
 			})
 		}
-		if m.authToken.Value != "" {
-			if err := m.clearAuthToken(); err != nil {
-				m.toast = fmt.Sprintf("Sign out failed: %v", err)
-			} else {
-				m.toast = "Signed out"
-			}
-			m.toastExpiry = time.Now().Add(3 * time.Second)
-			return m, tea.Tick(3*time.Second, func(time.Time) tea.Msg {
-				return toastClearMsg{}
-			})
+		// A token close to expiry is renewed by signing in again rather
+		// than signed out, as the expiry toast suggests.
+		if _, soon := m.authExpiresSoon(time.Now()); m.authToken.Valid(time.Now()) && !soon {
+			signOut := m.clearAuthToken()
+			return m, tea.Batch(signOut, m.showToast("Signed out", 3*time.Second))
 		}
 		if !m.authEnabled {
 			m.authURLActive = true
@@ -569,7 +659,7 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.authFlowActive = true
 		m.authPair = auth.Pairing{}
-		return m, startAuthCmd(m.cfg, m.client)
+		return m, startAuthCmd(m.cfg, m.endpoints.django)
 	}
 	return m, nil
 }
@@ -606,11 +696,11 @@ func (m *Model) applyDjangoSetup(djangoURL string) tea.Cmd {
 		m.cfg.DjangoStatsURL = config.DeriveDjangoStatsURL(djangoURL)
 	}
 	m.statsEnabled = m.cfg.DjangoStatsURL != ""
-	m.authEnabled = strings.TrimSpace(djangoURL) != ""
+	m.authEnabled = strings.TrimSpace(djangoURL) != "" || deviceConfig(m.cfg).Enabled()
 	if m.authToken.Value != "" && m.authToken.DjangoURL != "" && !strings.EqualFold(m.authToken.DjangoURL, djangoURL) {
-		_ = m.clearAuthToken()
+		return tea.Batch(m.clearAuthToken(), fetchTUIConfigCmd(m.cfg, m.endpoints.django))
 	}
-	return fetchTUIConfigCmd(m.cfg, m.client)
+	return fetchTUIConfigCmd(m.cfg, m.endpoints.django)
 }
 
 func (m *Model) applyWorkerSetup(workerURL string) tea.Cmd {
@@ -644,9 +734,9 @@ func (m *Model) applyWorkerSetup(workerURL string) tea.Cmd {
 		m.authFlowActive = true
 		m.authPair = auth.Pairing{}
 		if cmd == nil {
-			return startAuthCmd(m.cfg, m.client)
+			return startAuthCmd(m.cfg, m.endpoints.django)
 		}
-		return tea.Batch(cmd, startAuthCmd(m.cfg, m.client))
+		return tea.Batch(cmd, startAuthCmd(m.cfg, m.endpoints.django))
 	}
 	return cmd
 }
@@ -901,7 +991,7 @@ func pollHealthCmd(cfg config.Config, httpClient *client.Client) tea.Cmd {
 	}
 }
 
-func pollStatsCmd(cfg config.Config, httpClient *client.Client) tea.Cmd {
+func pollStatsCmd(cfg config.Config, fetcher *stats.Fetcher) tea.Cmd {
 	return func() tea.Msg {
 		if cfg.DjangoStatsURL == "" {
 			return nil
@@ -909,9 +999,10 @@ func pollStatsCmd(cfg config.Config, httpClient *client.Client) tea.Cmd {
 		start := time.Now()
 		ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
 		defer cancel()
-		statsSnapshot, err := stats.Fetch(ctx, httpClient, cfg.DjangoStatsURL)
+		statsSnapshot, info, err := fetcher.Fetch(ctx, cfg.DjangoStatsURL)
 		return statsMsg{
 			stats:     statsSnapshot,
+			info:      info,
 			err:       err,
 			attempted: time.Now(),
 			latency:   time.Since(start),
@@ -930,6 +1021,9 @@ func listenEventsCmd(ch <-chan models.Event) tea.Cmd {
 }
 
 func startAuthCmd(cfg config.Config, httpClient *client.Client) tea.Cmd {
+	if deviceConfig(cfg).Enabled() {
+		return startDeviceCmd(cfg)
+	}
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
 		defer cancel()
@@ -970,9 +1064,11 @@ func (m *Model) applySnapshot(snapshot models.MetricSnapshot) {
 	m.updateCounter(metrics.MetricTasksFailed, ts, seriesErrors)
 }
 
-func (m *Model) applyAuthToken(token auth.Token) error {
+// applyAuthToken switches requests to token and returns the command that
+// persists it; a failed save comes back as an authSaveMsg.
+func (m *Model) applyAuthToken(token auth.Token) tea.Cmd {
 	if m.authHeaderManaged {
-		m.client.SetHeader("Authorization", "Bearer "+token.Value)
+		m.endpoints.setLoginToken(token.Value)
 	}
 	if token.DjangoURL == "" {
 		token.DjangoURL = m.cfg.DjangoURL
@@ -980,27 +1076,65 @@ func (m *Model) applyAuthToken(token auth.Token) error {
 	m.authToken = token
 	m.authNeeded = false
 	m.authErr = nil
-	if m.authStore != nil {
-		if err := m.authStore.Save(token); err != nil {
-			return err
-		}
+	m.authExpiryNotice = authExpiryNone
+	if token.RefreshToken != "" {
+		m.authRefreshUnsupported = false
 	}
-	return nil
+	return m.persistAuthCmd(token)
 }
 
-func (m *Model) clearAuthToken() error {
-	if m.authStore != nil {
-		if err := m.authStore.Clear(); err != nil {
-			return err
+// finishSignIn stores a token from pairing or the device flow, ends the
+// login prompt, and re-polls endpoints that were failing without it.
+func (m *Model) finishSignIn(token auth.Token) tea.Cmd {
+	save := m.applyAuthToken(token)
+	m.toast = "Signed in"
+	m.authFlowActive = false
+	m.authPair = auth.Pairing{}
+	m.deviceAuth = auth.DeviceAuthorization{}
+	m.toastExpiry = time.Now().Add(3 * time.Second)
+	cmds := []tea.Cmd{
+		save,
+		tea.Tick(3*time.Second, func(time.Time) tea.Msg {
+			return toastClearMsg{}
+		}),
+	}
+	if !m.paused {
+		if m.cfg.WorkerMetricsURL != "" {
+			cmds = append(cmds, pollMetricsCmd(m.cfg, m.endpoints.metrics, m.catalog))
+		}
+		if m.cfg.WorkerHealthURL != "" {
+			cmds = append(cmds, pollHealthCmd(m.cfg, m.endpoints.health))
+		}
+		if m.statsEnabled {
+			cmds = append(cmds, pollStatsCmd(m.cfg, m.statsFetcher))
 		}
 	}
+	return tea.Batch(cmds...)
+}
+
+// rememberTokenConfig records the worker endpoints on the stored token so
+// `auth list` can show which cluster each profile points at.
+func (m *Model) rememberTokenConfig(cfg auth.TUIConfig) tea.Cmd {
+	if m.authStore == nil || m.authToken.Value == "" || !hasWorkerConfig(cfg) {
+		return nil
+	}
+	if m.authToken.Config != nil && *m.authToken.Config == cfg {
+		return nil
+	}
+	m.authToken.Config = &cfg
+	return m.persistAuthCmd(m.authToken)
+}
+
+// clearAuthToken signs out now and returns the command that clears the
+// store; a failure comes back as an authSaveMsg.
+func (m *Model) clearAuthToken() tea.Cmd {
 	m.authToken = auth.Token{}
 	m.authNeeded = false
 	m.authErr = nil
 	if m.authHeaderManaged {
-		m.client.ClearHeader("Authorization")
+		m.endpoints.setLoginToken("")
 	}
-	return nil
+	return m.persistAuthCmd(auth.Token{})
 }
 
 func (m *Model) noteAuthError(err error) bool {
@@ -1022,7 +1156,7 @@ func (m *Model) noteAuthError(err error) bool {
 		return true
 	}
 	if !wasNeeded && m.authEnabled && m.authHeaderManaged && m.authToken.Value == "" {
-		m.toast = "Auth required: press L to sign in"
+		m.toast = fmt.Sprintf("Auth required: press %s to sign in", m.keymap.Auth.Help().Key)
 		m.toastExpiry = time.Now().Add(3 * time.Second)
 	}
 	return false
@@ -1035,8 +1169,20 @@ func isAuthError(err error) bool {
 	return client.IsStatus(err, http.StatusUnauthorized, http.StatusForbidden)
 }
 
+func (m *Model) recordEvent(event models.Event) {
+	m.eventsBuffer.Add(event)
+	m.eventGroups.Add(event)
+	m.eventRates.Observe(event)
+	if m.eventHistory != nil {
+		m.historyErr = m.eventHistory.Append(event)
+	}
+	if !event.Timestamp.IsZero() {
+		m.lastEventAt = event.Timestamp
+	}
+}
+
 func (m *Model) applyEventFilter(filter sseFilter) {
-	if !m.eventsEnabled || m.eventsBaseURL == "" {
+	if !m.eventsEnabled || m.eventsBaseURL == "" || events.IsLocalSource(m.eventsBaseURL) {
 		return
 	}
 	nextURL := buildEventsURL(m.eventsBaseURL, filter)
@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/adpena/reproq-tui/internal/charts"
+	"github.com/adpena/reproq-tui/internal/events"
 	"github.com/adpena/reproq-tui/internal/metrics"
+	"github.com/adpena/reproq-tui/internal/stats"
 	"github.com/adpena/reproq-tui/pkg/models"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -141,7 +143,11 @@ func (m *Model) renderAuthPrompt() string {
 	} else {
 		lines = append(lines, m.theme.Styles.Muted.Render("Starting login..."))
 	}
-	lines = append(lines, "", "2) Sign in as a superuser and approve.")
+	if m.deviceAuth.DeviceCode != "" {
+		lines = append(lines, "", "2) Sign in with your identity provider and confirm the code.")
+	} else {
+		lines = append(lines, "", "2) Sign in as a superuser and approve.")
+	}
 	if m.authPair.Code != "" {
 		lines = append(lines, fmt.Sprintf("Code: %s", m.theme.Styles.Accent.Render(m.authPair.Code)))
 	}
@@ -165,7 +171,19 @@ func (m *Model) renderDetails() string {
 	view := m.detailViews[m.detailIndex%len(m.detailViews)]
 	title := fmt.Sprintf("Details: %s", view)
 	body := m.detailBody(view)
-	footer := m.theme.Styles.Muted.Render("tab to switch | esc to close")
+	if prompt := m.renderConfirmPrompt(); len(prompt) > 0 {
+		body = strings.Join(prompt, "\n") + "\n" + body
+	}
+	hints := "tab to switch | esc to close"
+	if (m.taskDetailActive() && m.taskOps != nil) || (m.periodicDetailActive() && m.periodicExpanded) || (m.workerDetailActive() && m.workerExpanded) {
+		hints = firstNonEmptyString(m.taskActionHint(), m.periodicActionHint(), m.workerActionHint())
+	} else if hint := firstNonEmptyString(m.queueActionHint(), m.taskActionHint(), m.periodicActionHint(), m.workerActionHint()); hint != "" {
+		hints = hint + " | " + hints
+	}
+	footer := m.theme.Styles.Muted.Render(hints)
+	if m.toast != "" && time.Now().Before(m.toastExpiry) {
+		footer += "  " + m.theme.Styles.AccentAlt.Render(m.toast)
+	}
 	content := strings.Join([]string{m.theme.Styles.CardTitle.Render(title), body, "", footer}, "\n")
 	width := maxInt(30, minInt(70, m.width-6))
 	available := m.contentHeight()
@@ -183,6 +201,9 @@ func (m *Model) detailBody(view string) string {
 			m.labelValue("Total depth", formatNumber(queueDepth)),
 			m.labelValue("Trend (1m)", formatNumber(trend)),
 		}
+		if growth := m.statsGrowth(m.scopedTaskKey(stats.StatusFailed)); growth != "-" {
+			lines = append(lines, m.labelValue("Failed "+formatWindow(m.currentWindow()), growth))
+		}
 		if ready, ok := m.statsTaskCount("READY"); ok {
 			lines = append(lines, m.labelValue("Ready", formatCount(ready)))
 		}
@@ -203,20 +224,31 @@ func (m *Model) detailBody(view string) string {
 		if len(summaries) > 0 {
 			lines = append(lines, "")
 			lines = append(lines, "Top queues")
+			selected := minInt(m.queueSelected, len(summaries)-1)
+			start := maxInt(0, selected-4)
 			for i, summary := range summaries {
-				if i >= 5 {
-					break
+				if i < start || i >= start+5 {
+					continue
+				}
+				marker := "  "
+				if i == selected {
+					marker = "> "
+				}
+				name := summary.Name
+				if m.queuePaused(m.dbScope, summary.Name) {
+					name += " [paused]"
 				}
 				line := fmt.Sprintf(
-					"%s %s (R%s W%s Ru%s F%s)",
-					summary.Name,
+					"%s%s %s (R%s W%s Ru%s F%s)",
+					marker,
+					name,
 					formatCount(summary.Total),
 					formatCount(summary.Ready),
 					formatCount(summary.Waiting),
 					formatCount(summary.Running),
 					formatCount(summary.Failed),
 				)
-				lines = append(lines, truncate(line, 60))
+				lines = append(lines, withTrend(line, m.queueTrendSuffix(summary.Name), 60))
 			}
 		}
 		paused := m.statsPausedQueues()
@@ -240,92 +272,11 @@ func (m *Model) detailBody(view string) string {
 		}
 		return strings.Join(lines, "\n")
 	case "Workers":
-		inUse := m.latestValue(metrics.MetricConcurrencyInUse)
-		limit := m.latestValue(metrics.MetricConcurrencyLimit)
-		gauge := charts.Gauge(inUse, limit, 20)
-		lines := []string{
-			m.labelValue("Workers", formatNumber(m.workerCountValue())),
-			m.labelValue("Concurrency", fmt.Sprintf("%s/%s", formatNumber(inUse), formatNumber(limit))),
-			"",
-			fmt.Sprintf("Usage  %s", gauge),
-		}
-		workers := m.statsWorkersByRecent()
-		now := m.referenceTime()
-		active, stale := m.splitWorkersByStatus(workers, now)
-		lines = append(lines, "")
-		if len(workers) == 0 {
-			if m.statsAvailable() {
-				lines = append(lines, m.theme.Styles.Muted.Render("No workers reported."))
-			} else {
-				lines = append(lines, m.theme.Styles.Muted.Render("No Django stats configured."))
-			}
-			return strings.Join(lines, "\n")
-		}
-		if len(active) > 0 {
-			lines = append(lines, fmt.Sprintf("Active workers (%d)", len(active)))
-			for i, worker := range active {
-				if i >= 4 {
-					break
-				}
-				seen := formatTimestamp(worker.LastSeenAt)
-				line := fmt.Sprintf("%s (%s) c=%d %s", worker.WorkerID, worker.Hostname, worker.Concurrency, seen)
-				lines = append(lines, truncate(line, 60))
-			}
-		} else {
-			lines = append(lines, m.theme.Styles.Muted.Render("No active workers."))
-		}
-		if len(stale) > 0 {
-			lines = append(lines, "")
-			lines = append(lines, fmt.Sprintf("Stale workers (%d)", len(stale)))
-			for i, worker := range stale {
-				if i >= 3 {
-					break
-				}
-				seen := formatTimestamp(worker.LastSeenAt)
-				line := fmt.Sprintf("%s (%s) c=%d %s", worker.WorkerID, worker.Hostname, worker.Concurrency, seen)
-				lines = append(lines, m.theme.Styles.Muted.Render(truncate(line, 60)))
-			}
-		}
-		return strings.Join(lines, "\n")
+		return m.renderWorkerDetail()
 	case "Periodic":
-		periodic := m.statsPeriodicByNextRun()
-		if len(periodic) == 0 {
-			if m.statsAvailable() {
-				return m.theme.Styles.Muted.Render("No periodic tasks reported.")
-			}
-			return m.theme.Styles.Muted.Render("No Django stats configured.")
-		}
-		ref := m.referenceTime()
-		lines := []string{
-			m.labelValue("Total tasks", formatCount(int64(len(periodic)))),
-		}
-		if sched, ok := m.statsScheduler(); ok {
-			lines = append(lines,
-				m.labelValue("Scheduler", formatScheduler(sched)),
-				m.labelValue("Beat", formatYesNo(sched.BeatEnabled)),
-				m.labelValue("pg_cron", formatYesNo(sched.PgCronAvailable)),
-			)
-			if sched.Warning != "" {
-				lines = append(lines, "", m.theme.Styles.StatusWarn.Render(sched.Warning))
-			}
-		}
-		lines = append(lines, "")
-		for i, task := range periodic {
-			if i >= 10 { // Show more tasks in detail view
-				break
-			}
-			next := formatScheduleDetailed(task.NextRunAt, ref)
-			statusIcon := m.theme.Styles.AccentAlt.Render("●")
-			if !task.Enabled {
-				statusIcon = m.theme.Styles.Muted.Render("○")
-			}
-			line := fmt.Sprintf("%s %-24s %s", statusIcon, truncate(task.Name, 24), next)
-			if !task.Enabled {
-				line = m.theme.Styles.Muted.Render(line)
-			}
-			lines = append(lines, line)
-		}
-		return strings.Join(lines, "\n")
+		return m.renderPeriodicDetail()
+	case "Scheduler":
+		return m.renderSchedulerDetail()
 	case "Databases":
 		summaries := m.statsDatabaseSummaries()
 		if len(summaries) == 0 {
@@ -354,10 +305,13 @@ func (m *Model) detailBody(view string) string {
 				summary.Queues,
 				summary.Periodic,
 			)
-			lines = append(lines, truncate(line, 60))
+			lines = append(lines, withTrend(line, m.databaseTrendSuffix(summary.Alias), 60))
 		}
 		return strings.Join(lines, "\n")
 	case "Tasks":
+		if m.taskOps != nil {
+			return m.renderTaskOps()
+		}
 		bar := charts.Bar(m.seriesValues(seriesThroughput), 24, 4)
 		lines := []string{}
 		if ready, ok := m.statsTaskCount("READY"); ok {
@@ -385,9 +339,17 @@ func (m *Model) detailBody(view string) string {
 			m.labelValue("P95 latency", formatDuration(time.Duration(m.currentLatencyP95()*float64(time.Second)))),
 		)
 		if m.statsAvailable() && len(m.lastStats.TopFailing) > 0 {
-			lines = append(lines, "", "Top Failing")
-			for _, task := range m.lastStats.TopFailing {
-				line := fmt.Sprintf("%-32s %s", truncate(task.TaskPath, 32), formatCount(task.Count))
+			title := "Top Failing"
+			if m.dbScope != "" {
+				title += " (all databases)"
+			}
+			lines = append(lines, "", title)
+			for i, task := range m.lastStats.TopFailing {
+				marker := "  "
+				if i == m.taskSelected {
+					marker = "> "
+				}
+				line := fmt.Sprintf("%s%-32s %s", marker, truncate(task.TaskPath, 32), formatCount(task.Count))
 				lines = append(lines, m.theme.Styles.StatusDown.Render(line))
 			}
 		}
@@ -399,6 +361,16 @@ func (m *Model) detailBody(view string) string {
 		return strings.Join(lines, "\n")
 	case "Errors":
 		return m.renderErrorList()
+	case "Event rates":
+		return m.renderEventRatesDetail()
+	case "Health":
+		return m.renderHealthDetail()
+	case "SLO":
+		return m.renderSLODetail()
+	case "Rollout":
+		return m.renderRolloutDetail()
+	case "Diagnostics":
+		return m.renderDiagnosticsDetail()
 	default:
 		return m.theme.Styles.Muted.Render("No detail view available.")
 	}
@@ -408,20 +380,12 @@ func (m *Model) renderErrorList() string {
 	if !m.eventsEnabled {
 		return m.theme.Styles.Muted.Render("No events stream configured.")
 	}
-	events := m.eventsBuffer.Items()
 	lines := []string{}
-	for _, event := range events {
-		level := strings.ToLower(event.Level)
-		if level != "error" && level != "warn" && level != "warning" {
+	for _, group := range m.eventGroups.Groups() {
+		if events.LevelRank(group.Level) < events.LevelRank("warn") {
 			continue
 		}
-		timestamp := formatTimestamp(event.Timestamp)
-		meta := formatEventMeta(event.Metadata)
-		line := fmt.Sprintf("%s %s", timestamp, event.Message)
-		if meta != "" {
-			line = fmt.Sprintf("%s [%s] %s", timestamp, meta, event.Message)
-		}
-		lines = append(lines, line)
+		lines = append(lines, truncate(m.groupLine(group), 64))
 	}
 	if len(lines) == 0 {
 		return m.theme.Styles.Muted.Render("No recent errors.")
@@ -445,6 +409,9 @@ func (m *Model) renderStatusBar() string {
 		worker := fmt.Sprintf("%s %s", m.theme.Styles.Muted.Render("worker"), m.theme.Styles.AccentAlt.Render(host))
 		parts = append(parts, worker)
 	}
+	if scope := m.databaseScopeBadge(); scope != "" {
+		parts = append(parts, scope)
+	}
 	if m.lastHealth.Version != "" {
 		parts = append(parts, m.theme.Styles.Accent.Render("v"+m.lastHealth.Version))
 	}
@@ -457,14 +424,41 @@ func (m *Model) renderStatusBar() string {
 		scrape := fmt.Sprintf("scrape %s (%s)", formatRelative(m.lastScrapeAt), formatDuration(m.lastScrapeDelay))
 		parts = append(parts, m.theme.Styles.Muted.Render(scrape))
 	}
-	if m.lastScrapeErr != nil {
+	now := time.Now()
+	if badge := m.breakerBadge("metrics", m.breakers.metrics, now); badge != "" {
+		parts = append(parts, badge)
+	} else if m.lastScrapeErr != nil {
 		parts = append(parts, m.theme.Styles.StatusWarn.Render("metrics err"))
 	}
-	if m.lastHealthErr != nil {
+	if badge := m.breakerBadge("health", m.breakers.health, now); badge != "" {
+		parts = append(parts, badge)
+	} else if m.lastHealthErr != nil {
 		parts = append(parts, m.theme.Styles.StatusWarn.Render("health err"))
 	}
-	if m.statsEnabled && m.lastStatsErr != nil {
-		parts = append(parts, m.theme.Styles.StatusWarn.Render("stats err"))
+	if flapping := m.flappingComponents(now); len(flapping) > 0 {
+		parts = append(parts, m.theme.Styles.StatusWarn.Render("flapping "+strings.Join(flapping, ",")))
+	}
+	if versions := m.mixedVersions(); versions > 0 {
+		parts = append(parts, m.theme.Styles.StatusWarn.Render(fmt.Sprintf("mixed versions %d", versions)))
+	}
+	if badge := m.sloStatusBadge(now); badge != "" {
+		parts = append(parts, badge)
+	}
+	if badge := m.schedulerStatusBadge(); badge != "" {
+		parts = append(parts, badge)
+	}
+	if m.auditErr != nil {
+		parts = append(parts, m.theme.Styles.StatusDown.Render("audit log err"))
+	}
+	if m.historyErr != nil {
+		parts = append(parts, m.theme.Styles.StatusDown.Render("history err"))
+	}
+	if m.statsEnabled {
+		if badge := m.breakerBadge("stats", m.breakers.stats, now); badge != "" {
+			parts = append(parts, badge)
+		} else if m.lastStatsErr != nil {
+			parts = append(parts, m.theme.Styles.StatusWarn.Render("stats err"))
+		}
 	}
 	line := strings.Join(parts, "  ")
 	return m.theme.Styles.StatusBar.Width(m.width).Render(line)
@@ -588,8 +582,17 @@ func (m *Model) renderLeftPane(width, height int) string {
 }
 
 func (m *Model) renderCenterPane(width, height int) string {
+	if m.eventRatesFallback() {
+		return m.renderEventRateCards(width, height)
+	}
 	gap := 1
 	cardHeight := maxInt(6, (height-gap)/2)
+	// The error budget card joins once health checks or scrapes have been
+	// recorded and there is room for three cards.
+	showSLO := len(m.slo.Signals()) > 0 && (height-2*gap)/3 >= 6
+	if showSLO {
+		cardHeight = (height - 2*gap) / 3
+	}
 	chartWidth := maxInt(10, width-6)
 	loading := m.lastScrapeAt.IsZero()
 
@@ -618,6 +621,11 @@ func (m *Model) renderCenterPane(width, height int) string {
 	first := m.chartCard("Throughput", val(formatRate(m.currentThroughput())), renderSparkline(throughput, m.theme.Styles.Accent), width, cardHeight, m.focus == focusCenter, m.lastScrapeAt)
 	second := m.chartCard("Queue depth", val(formatNumber(m.latestValue(metrics.MetricQueueDepth))), renderSparkline(queueDepth, m.theme.Styles.AccentAlt), width, cardHeight, false, m.lastScrapeAt)
 
+	if showSLO {
+		third := m.sloCard(width, cardHeight, chartWidth)
+		return lipgloss.JoinVertical(lipgloss.Left, first, strings.Repeat("\n", gap), second, strings.Repeat("\n", gap), third)
+	}
+
 	remaining := height - (cardHeight*2 + gap)
 	if remaining >= cardHeight {
 		third := m.chartCard("Errors", val(formatRate(m.latestValue(seriesErrors))), renderSparkline(errors, m.theme.Styles.StatusWarn), width, cardHeight, false, m.lastScrapeAt)
@@ -648,6 +656,9 @@ func (m *Model) renderRightPane(width, height int) string {
 func (m *Model) renderFooter() string {
 	if m.filterActive {
 		filterLine := fmt.Sprintf("Filter: %s (enter to apply, esc to cancel)", m.filterInput.View())
+		if m.filterErr != nil {
+			filterLine = joinRight(filterLine, m.theme.Styles.StatusWarn.Render(truncate(m.filterErr.Error(), maxInt(10, m.width/3))), m.width)
+		}
 		return m.theme.Styles.KeyHint.Width(m.width).Render(filterLine)
 	}
 	help := m.help.ShortHelpView(m.keymap.ShortHelp())
@@ -683,6 +694,9 @@ func (m *Model) authStatusSummary() string {
 		if !m.authToken.ExpiresAt.IsZero() && time.Now().After(m.authToken.ExpiresAt) {
 			return m.theme.Styles.StatusWarn.Render("Auth expired")
 		}
+		if remaining, soon := m.authExpiresSoon(time.Now()); soon && !m.authRefreshing {
+			return m.theme.Styles.StatusWarn.Render("Auth expires " + formatRemaining(remaining))
+		}
 		return m.theme.Styles.Accent.Render("Auth signed in")
 	}
 	if m.authNeeded {
@@ -758,6 +772,12 @@ func (m *Model) renderEvents(width, height int) []string {
 			filtered = append(filtered, m.theme.Styles.Muted.Render(hint))
 		}
 	}
+	if m.groupEvents {
+		grouped := m.renderGroupedEvents(renderWidth)
+		filtered = append(filtered, grouped...)
+		eventLines = len(grouped)
+		events = nil
+	}
 	for _, event := range events {
 		if !m.matchFilter(event) {
 			continue
@@ -769,17 +789,7 @@ func (m *Model) renderEvents(width, height int) []string {
 			rawLine = fmt.Sprintf("%s [%s] %s", timestamp, meta, event.Message)
 		}
 		rawLine = truncate(rawLine, renderWidth)
-		line := rawLine
-		level := strings.ToLower(event.Level)
-		switch level {
-		case "error":
-			line = m.theme.Styles.StatusDown.Render(rawLine)
-		case "warn", "warning":
-			line = m.theme.Styles.StatusWarn.Render(rawLine)
-		default:
-			line = m.theme.Styles.Muted.Render(rawLine)
-		}
-		filtered = append(filtered, line)
+		filtered = append(filtered, m.levelStyle(event.Level).Render(rawLine))
 		eventLines++
 	}
 	if eventLines == 0 {
@@ -815,20 +825,7 @@ func (m *Model) eventsStatus() string {
 }
 
 func (m *Model) matchFilter(event models.Event) bool {
-	if strings.TrimSpace(m.filterLocal) == "" {
-		return true
-	}
-	needle := strings.ToLower(m.filterLocal)
-	hay := strings.ToLower(strings.Join([]string{
-		event.Message,
-		event.Type,
-		event.Level,
-		event.Queue,
-		event.TaskID,
-		event.WorkerID,
-		flattenMeta(event.Metadata),
-	}, " "))
-	return strings.Contains(hay, needle)
+	return m.filterQuery.match(event, time.Now())
 }
 
 func flattenMeta(meta map[string]string) string {
@@ -119,19 +119,24 @@ func TestDashboardViewGolden(t *testing.T) {
 		FetchedAt: base,
 	}
 
-	model.eventsBuffer.Add(models.Event{
-		Timestamp: time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC),
-		Level:     "error",
-		Message:   "task failed",
-		Metadata: map[string]string{
-			"role": "worker",
+	for _, event := range []models.Event{
+		{
+			Timestamp: time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC),
+			Level:     "error",
+			Message:   "task failed",
+			Metadata: map[string]string{
+				"role": "worker",
+			},
+		},
+		{
+			Timestamp: time.Date(2024, 1, 1, 9, 31, 0, 0, time.UTC),
+			Level:     "warn",
+			Message:   "retry scheduled",
 		},
-	})
-	model.eventsBuffer.Add(models.Event{
-		Timestamp: time.Date(2024, 1, 1, 9, 31, 0, 0, time.UTC),
-		Level:     "warn",
-		Message:   "retry scheduled",
-	})
+	} {
+		model.eventsBuffer.Add(event)
+		model.eventGroups.Add(event)
+	}
 
 	view := normalizeView(model.View())
 	goldenPath := filepath.Join("testdata", "dashboard.golden")
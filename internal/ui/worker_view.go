@@ -0,0 +1,322 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/charts"
+	"github.com/adpena/reproq-tui/internal/metrics"
+	"github.com/adpena/reproq-tui/internal/stats"
+	"github.com/adpena/reproq-tui/pkg/models"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	workerTableRows   = 8
+	workerEventsShown = 5
+)
+
+type workerSortMode int
+
+const (
+	workerSortLastSeen workerSortMode = iota
+	workerSortConcurrency
+	workerSortVersion
+	workerSortHostname
+	workerSortQueues
+	workerSortModes
+)
+
+func (s workerSortMode) String() string {
+	switch s {
+	case workerSortConcurrency:
+		return "concurrency"
+	case workerSortVersion:
+		return "version"
+	case workerSortHostname:
+		return "hostname"
+	case workerSortQueues:
+		return "queues"
+	default:
+		return "last seen"
+	}
+}
+
+func (m *Model) workerDetailActive() bool {
+	return m.detailActive && m.detailViews[m.detailIndex%len(m.detailViews)] == "Workers"
+}
+
+// sortedWorkers orders every reported worker by the current sort mode; ties
+// fall back to the worker ID so rows stay put between polls.
+func (m *Model) sortedWorkers() []models.WorkerInfo {
+	workers := m.statsWorkersByRecent()
+	less := func(a, b models.WorkerInfo) (bool, bool) {
+		switch m.workerSort {
+		case workerSortConcurrency:
+			return a.Concurrency > b.Concurrency, a.Concurrency != b.Concurrency
+		case workerSortVersion:
+			cmp := stats.CompareVersions(a.Version, b.Version)
+			return cmp > 0, cmp != 0
+		case workerSortHostname:
+			return a.Hostname < b.Hostname, a.Hostname != b.Hostname
+		case workerSortQueues:
+			qa, qb := strings.Join(a.Queues, ","), strings.Join(b.Queues, ",")
+			return qa < qb, qa != qb
+		default:
+			return a.LastSeenAt.After(b.LastSeenAt), !a.LastSeenAt.Equal(b.LastSeenAt)
+		}
+	}
+	sort.SliceStable(workers, func(i, j int) bool {
+		if result, decided := less(workers[i], workers[j]); decided {
+			return result
+		}
+		return workers[i].WorkerID < workers[j].WorkerID
+	})
+	return workers
+}
+
+func (m *Model) selectedWorkerIndex(workers []models.WorkerInfo) int {
+	for i, worker := range workers {
+		if worker.WorkerID == m.workerSelected {
+			return i
+		}
+	}
+	return 0
+}
+
+func (m *Model) selectedWorker() (models.WorkerInfo, bool) {
+	workers := m.sortedWorkers()
+	if len(workers) == 0 {
+		return models.WorkerInfo{}, false
+	}
+	return workers[m.selectedWorkerIndex(workers)], true
+}
+
+func (m *Model) handleWorkerKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch {
+	case msg.Type == tea.KeyEsc && m.workerExpanded:
+		m.workerExpanded = false
+		return nil, true
+	case key.Matches(msg, m.keymap.EventUp), key.Matches(msg, m.keymap.EventDown):
+		workers := m.sortedWorkers()
+		if len(workers) > 0 {
+			delta := 1
+			if key.Matches(msg, m.keymap.EventUp) {
+				delta = -1
+			}
+			idx := (m.selectedWorkerIndex(workers) + delta + len(workers)) % len(workers)
+			m.workerSelected = workers[idx].WorkerID
+		}
+		return nil, true
+	case key.Matches(msg, m.keymap.EventExpand):
+		if worker, ok := m.selectedWorker(); ok {
+			m.workerSelected = worker.WorkerID
+			m.workerExpanded = !m.workerExpanded
+		}
+		return nil, true
+	case key.Matches(msg, m.keymap.WorkerSort):
+		m.workerSort = (m.workerSort + 1) % workerSortModes
+		return m.showToast("Sorted workers by "+m.workerSort.String(), 2*time.Second), true
+	}
+	return nil, false
+}
+
+func (m *Model) renderWorkerDetail() string {
+	if m.workerExpanded {
+		if worker, ok := m.selectedWorker(); ok && worker.WorkerID == m.workerSelected {
+			return m.renderWorker(worker)
+		}
+		m.workerExpanded = false
+	}
+	inUse := m.latestValue(metrics.MetricConcurrencyInUse)
+	limit := m.latestValue(metrics.MetricConcurrencyLimit)
+	lines := []string{
+		m.labelValue("Workers", formatNumber(m.workerCountValue())),
+		m.labelValue("Concurrency", fmt.Sprintf("%s/%s", formatNumber(inUse), formatNumber(limit))),
+		"",
+		fmt.Sprintf("Usage  %s", charts.Gauge(inUse, limit, 20)),
+	}
+	if alive := m.statsTrendValues(stats.WorkersAlive); len(alive) > 1 {
+		lines = append(lines, fmt.Sprintf("Alive  %s dead %s", charts.Sparkline(alive, 20), m.statsGrowth(stats.WorkersDead)))
+	}
+	workers := m.sortedWorkers()
+	lines = append(lines, "")
+	if len(workers) == 0 {
+		if m.statsAvailable() {
+			lines = append(lines, m.theme.Styles.Muted.Render("No workers reported."))
+		} else {
+			lines = append(lines, m.theme.Styles.Muted.Render("No Django stats configured."))
+		}
+		return strings.Join(lines, "\n")
+	}
+	ref := m.referenceTime()
+	cutoff := m.workerActiveCutoff(ref)
+	active, stale := m.splitWorkersByStatus(workers, ref)
+	lines = append(lines,
+		fmt.Sprintf("%d active, %d stale (after %s) | sort: %s", len(active), len(stale), formatWindow(m.workerStaleAfter()), m.workerSort),
+		m.theme.Styles.Muted.Render(fmt.Sprintf("    %-12s %-10s %3s %-8s %-10s %s", "worker", "host", "c", "version", "queues", "seen")),
+	)
+	selected := m.selectedWorkerIndex(workers)
+	start := maxInt(0, selected-workerTableRows+1)
+	for i, worker := range workers {
+		if i < start || i >= start+workerTableRows {
+			continue
+		}
+		marker := "  "
+		if i == selected {
+			marker = "> "
+		}
+		isStale := workerIsStale(worker, cutoff)
+		dot := m.theme.Styles.StatusOK.Render("●")
+		if isStale {
+			dot = m.theme.Styles.StatusDown.Render("○")
+		}
+		row := fmt.Sprintf("%-12s %-10s %3d %-8s %-10s %s",
+			truncate(worker.WorkerID, 12),
+			truncate(firstNonEmptyString(worker.Hostname, "-"), 10),
+			worker.Concurrency,
+			truncate(firstNonEmptyString(worker.Version, "-"), 8),
+			truncate(firstNonEmptyString(strings.Join(worker.Queues, ","), "-"), 10),
+			formatSeen(worker.LastSeenAt, ref),
+		)
+		if isStale {
+			row = m.theme.Styles.Muted.Render(row)
+		}
+		lines = append(lines, marker+dot+" "+row)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) renderWorker(worker models.WorkerInfo) string {
+	ref := m.referenceTime()
+	status := m.theme.Styles.StatusOK.Render("active")
+	if workerIsStale(worker, m.workerActiveCutoff(ref)) {
+		status = m.theme.Styles.StatusDown.Render(fmt.Sprintf("stale (no heartbeat for %s)", formatWindow(m.workerStaleAfter())))
+	}
+	version := firstNonEmptyString(worker.Version, "-")
+	if groups := m.rolloutGroups(); len(groups) > 1 && stats.NormalizeVersion(worker.Version) != groups[0].Version {
+		version = m.theme.Styles.StatusWarn.Render(fmt.Sprintf("%s (behind %s)", version, groups[0].Version))
+	}
+	lines := []string{
+		m.labelValue("Worker", truncate(worker.WorkerID, 48)),
+		m.labelValue("Host", firstNonEmptyString(worker.Hostname, "-")),
+		m.labelValue("Status", status),
+		m.labelValue("Last seen", fmt.Sprintf("%s (%s)", formatTimestamp(worker.LastSeenAt), formatSeen(worker.LastSeenAt, ref))),
+		m.labelValue("Version", version),
+		m.labelValue("Concurrency", fmt.Sprintf("%d", worker.Concurrency)),
+		m.labelValue("Queues", truncate(firstNonEmptyString(strings.Join(worker.Queues, ", "), "-"), 48)),
+		"",
+	}
+	if values, ok := m.lastSnapshot.Workers[worker.WorkerID]; ok {
+		lines = append(lines, "Metrics")
+		lines = append(lines, m.workerMetricLines(worker.WorkerID, values)...)
+	} else {
+		lines = append(lines, m.theme.Styles.Muted.Render("No per-worker metrics (exporter has no worker_id label)."))
+	}
+	lines = append(lines, "")
+	recent := m.workerEvents(worker.WorkerID, workerEventsShown)
+	if len(recent) == 0 {
+		lines = append(lines, m.theme.Styles.Muted.Render("No recent events from this worker."))
+		return strings.Join(lines, "\n")
+	}
+	lines = append(lines, fmt.Sprintf("Recent events (%d)", len(recent)))
+	for _, event := range recent {
+		line := fmt.Sprintf("%s %-5s %s", formatTimestamp(event.Timestamp), truncate(event.Level, 5), firstNonEmptyString(event.Message, event.Type))
+		lines = append(lines, "  "+truncate(line, 60))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) workerMetricLines(workerID string, values map[string]float64) []string {
+	var lines []string
+	add := func(label, value string) {
+		lines = append(lines, "  "+m.labelValue(label, value))
+	}
+	if total, ok := values[metrics.MetricTasksTotal]; ok {
+		add("Processed", formatNumber(total)+m.workerRateSuffix(workerID, metrics.MetricTasksTotal, total))
+	}
+	if failed, ok := values[metrics.MetricTasksFailed]; ok {
+		add("Failed", formatNumber(failed)+m.workerRateSuffix(workerID, metrics.MetricTasksFailed, failed))
+	}
+	if running, ok := values[metrics.MetricTasksRunning]; ok {
+		add("Running", formatNumber(running))
+	}
+	if inUse, ok := values[metrics.MetricConcurrencyInUse]; ok {
+		label := formatNumber(inUse)
+		if limit, ok := values[metrics.MetricConcurrencyLimit]; ok {
+			label += "/" + formatNumber(limit)
+		}
+		add("In use", label)
+	}
+	if p95, ok := values[metrics.MetricLatencyP95]; ok && !math.IsNaN(p95) {
+		add("Latency p95", formatDuration(time.Duration(p95*float64(time.Second))))
+	}
+	if mem, ok := values[metrics.MetricWorkerMemUsage]; ok && !math.IsNaN(mem) {
+		add("Memory", formatBytes(mem))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, m.theme.Styles.Muted.Render("  No catalog metrics for this worker."))
+	}
+	return lines
+}
+
+// workerRateSuffix derives a per-second rate for a worker counter from the
+// previous scrape, since rates are only tracked for fleet-wide series.
+func (m *Model) workerRateSuffix(workerID, metric string, current float64) string {
+	prev, ok := m.prevSnapshot.Workers[workerID][metric]
+	if !ok {
+		return ""
+	}
+	elapsed := m.lastSnapshot.CollectedAt.Sub(m.prevSnapshot.CollectedAt).Seconds()
+	if elapsed <= 0 || current < prev {
+		return ""
+	}
+	return " (" + formatRate((current-prev)/elapsed) + ")"
+}
+
+func (m *Model) workerEvents(workerID string, limit int) []models.Event {
+	items := m.eventsBuffer.Items()
+	out := make([]models.Event, 0, limit)
+	for i := len(items) - 1; i >= 0 && len(out) < limit; i-- {
+		if items[i].WorkerID == workerID {
+			out = append(out, items[i])
+		}
+	}
+	return out
+}
+
+func (m *Model) workerActionHint() string {
+	if !m.workerDetailActive() || len(m.sortedWorkers()) == 0 {
+		return ""
+	}
+	if m.workerExpanded {
+		return "esc back"
+	}
+	return fmt.Sprintf("j/k select | enter details | %s sort", m.keymap.WorkerSort.Help().Key)
+}
+
+func workerIsStale(worker models.WorkerInfo, cutoff time.Time) bool {
+	return worker.LastSeenAt.IsZero() || worker.LastSeenAt.Before(cutoff)
+}
+
+func formatSeen(t, ref time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	ago := ref.Sub(t)
+	switch {
+	case ago < time.Second:
+		return "now"
+	case ago < time.Minute:
+		return fmt.Sprintf("%ds ago", int(ago.Seconds()))
+	case ago < time.Hour:
+		return fmt.Sprintf("%dm ago", int(ago.Minutes()))
+	case ago < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(ago.Hours()))
+	}
+	return fmt.Sprintf("%dd ago", int(ago.Hours()/24))
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adpena/reproq-tui/internal/config"
+	"github.com/adpena/reproq-tui/internal/metrics"
+	"github.com/adpena/reproq-tui/pkg/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWorkerTableSortAndDetail(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkerMetricsURL = "http://worker.local/metrics"
+	cfg.DjangoStatsURL = "http://django.local/reproq/stats/"
+	cfg.WorkerStaleAfter = time.Minute
+	model := newTestModel(t, cfg)
+	send := func(msg tea.Msg) tea.Cmd {
+		updated, cmd := model.Update(msg)
+		model = updated.(*Model)
+		return cmd
+	}
+	send(tea.WindowSizeMsg{Width: 160, Height: 50})
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	send(metricsMsg{attempted: now.Add(-5 * time.Second), snapshot: models.MetricSnapshot{
+		CollectedAt: now.Add(-5 * time.Second),
+		Values:      map[string]float64{},
+		Workers:     map[string]map[string]float64{"w-big": {metrics.MetricTasksTotal: 100}},
+	}})
+	send(metricsMsg{attempted: now, snapshot: models.MetricSnapshot{
+		CollectedAt: now,
+		Values:      map[string]float64{},
+		Workers: map[string]map[string]float64{"w-big": {
+			metrics.MetricTasksTotal:       150,
+			metrics.MetricConcurrencyInUse: 6,
+		}},
+	}})
+	send(statsMsg{attempted: now, stats: models.DjangoStats{
+		FetchedAt: now,
+		Workers: []models.WorkerInfo{
+			{WorkerID: "w-recent", Hostname: "alpha", Concurrency: 2, Version: "1.2.0", LastSeenAt: now.Add(-5 * time.Second)},
+			{WorkerID: "w-big", Hostname: "bravo", Concurrency: 8, Version: "1.2.0", Queues: []string{"default"}, LastSeenAt: now.Add(-20 * time.Second)},
+			{WorkerID: "w-gone", Hostname: "charlie", Concurrency: 4, Version: "1.1.0", LastSeenAt: now.Add(-10 * time.Minute)},
+		},
+	}})
+	model.eventsBuffer.Add(models.Event{Timestamp: now, Level: "error", Type: "task_failed", Message: "boom on big", WorkerID: "w-big"})
+	model.eventsBuffer.Add(models.Event{Timestamp: now, Level: "info", Type: "task_done", Message: "fine elsewhere", WorkerID: "w-recent"})
+
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	for model.detailViews[model.detailIndex] != "Workers" {
+		send(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	view := model.View()
+	if !strings.Contains(view, "2 active, 1 stale (after 1m)") || !strings.Contains(view, "10m ago") {
+		t.Fatalf("expected worker table with stale worker, got:\n%s", view)
+	}
+	if worker, _ := model.selectedWorker(); worker.WorkerID != "w-recent" {
+		t.Fatalf("expected most recent worker first, got %s", worker.WorkerID)
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if model.workerSort != workerSortConcurrency {
+		t.Fatalf("expected concurrency sort, got %s", model.workerSort)
+	}
+	workers := model.sortedWorkers()
+	if workers[0].WorkerID != "w-big" || workers[2].WorkerID != "w-recent" {
+		t.Fatalf("unexpected concurrency order %+v", workers)
+	}
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if model.workerSelected != "w-recent" {
+		t.Fatalf("expected selection to follow sorted rows, got %q", model.workerSelected)
+	}
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if model.workerSelected != "w-big" {
+		t.Fatalf("expected selection to wrap, got %q", model.workerSelected)
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	view = model.View()
+	for _, want := range []string{"bravo", "10.0/s", "boom on big", "Recent events (1)"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in worker detail, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "fine elsewhere") {
+		t.Fatalf("expected events from other workers to be filtered out")
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.workerExpanded || !model.detailActive {
+		t.Fatalf("expected esc to close the worker detail only")
+	}
+}
+
+func TestWorkerStaleAfterDefault(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.StatsInterval = 30 * time.Second
+	model := newTestModel(t, cfg)
+	if got := model.workerStaleAfter(); got != 3*time.Minute {
+		t.Fatalf("expected 6x stats interval, got %s", got)
+	}
+	model.cfg.WorkerStaleAfter = 20 * time.Second
+	if got := model.workerStaleAfter(); got != 20*time.Second {
+		t.Fatalf("expected configured threshold, got %s", got)
+	}
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	// BreakerProbing means the cooldown has passed and the next request
+	// decides whether the breaker closes or opens again.
+	BreakerProbing
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerProbing:
+		return "probing"
+	default:
+		return "closed"
+	}
+}
+
+// Breaker tracks consecutive failures for one endpoint so pollers stop
+// hammering it while it is down. After threshold failures it opens for a
+// cooldown that starts at base and doubles with each failed probe, up to
+// maxWait. A threshold of zero or less never opens.
+type Breaker struct {
+	threshold int
+	base      time.Duration
+	maxWait   time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	opens     int
+	openUntil time.Time
+}
+
+func NewBreaker(threshold int, base, maxWait time.Duration) *Breaker {
+	if base <= 0 {
+		base = time.Second
+	}
+	if maxWait < base {
+		maxWait = base
+	}
+	return &Breaker{threshold: threshold, base: base, maxWait: maxWait}
+}
+
+// Record notes the outcome of a request. Only errors Unavailable reports
+// count as failures; any other outcome means the endpoint answered.
+func (b *Breaker) Record(err error, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !Unavailable(err) {
+		b.failures, b.opens, b.openUntil = 0, 0, time.Time{}
+		return
+	}
+	b.failures++
+	if b.threshold <= 0 || b.failures < b.threshold {
+		return
+	}
+	cooldown := b.base << b.opens
+	if cooldown <= 0 || cooldown > b.maxWait {
+		cooldown = b.maxWait
+	} else {
+		b.opens++
+	}
+	b.openUntil = now.Add(cooldown)
+}
+
+func (b *Breaker) State(now time.Time) BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case b.openUntil.IsZero():
+		return BreakerClosed
+	case now.Before(b.openUntil):
+		return BreakerOpen
+	default:
+		return BreakerProbing
+	}
+}
+
+// RetryIn is how long the breaker stays open; zero once it may be probed.
+func (b *Breaker) RetryIn(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.openUntil.IsZero() || !now.Before(b.openUntil) {
+		return 0
+	}
+	return b.openUntil.Sub(now)
+}
+
+// Delay returns when to poll next: interval while closed, or the end of the
+// cooldown when that is later, so the next poll is the recovery probe.
+func (b *Breaker) Delay(interval time.Duration, now time.Time) time.Duration {
+	if remaining := b.RetryIn(now); remaining > interval {
+		return remaining
+	}
+	return interval
+}
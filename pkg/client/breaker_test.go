@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBreakerBacksOffAndRecovers(t *testing.T) {
+	var up atomic.Bool
+	up.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	c := New(Options{Timeout: time.Second})
+	poll := func() error {
+		resp, err := c.Get(context.Background(), server.URL)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return StatusError{URL: server.URL, Code: resp.StatusCode}
+		}
+		return nil
+	}
+
+	breaker := NewBreaker(2, time.Second, 3*time.Second)
+	now := time.Now()
+	up.Store(false)
+	breaker.Record(poll(), now)
+	if breaker.State(now) != BreakerClosed || breaker.Delay(time.Second, now) != time.Second {
+		t.Fatalf("expected one failure to keep the breaker closed")
+	}
+	breaker.Record(poll(), now)
+	if breaker.State(now) != BreakerOpen || breaker.RetryIn(now) != time.Second {
+		t.Fatalf("expected breaker to open for 1s, got %s %v", breaker.State(now), breaker.RetryIn(now))
+	}
+	if d := breaker.Delay(200*time.Millisecond, now); d != time.Second {
+		t.Fatalf("expected polling to wait out the cooldown, got %v", d)
+	}
+
+	now = now.Add(time.Second)
+	if breaker.State(now) != BreakerProbing {
+		t.Fatalf("expected probe after cooldown, got %s", breaker.State(now))
+	}
+	breaker.Record(poll(), now)
+	if breaker.RetryIn(now) != 2*time.Second {
+		t.Fatalf("expected failed probe to double the cooldown, got %v", breaker.RetryIn(now))
+	}
+	now = now.Add(2 * time.Second)
+	breaker.Record(poll(), now)
+	now = now.Add(3 * time.Second)
+	breaker.Record(poll(), now)
+	if breaker.RetryIn(now) != 3*time.Second {
+		t.Fatalf("expected cooldown to cap at 3s, got %v", breaker.RetryIn(now))
+	}
+
+	up.Store(true)
+	now = now.Add(3 * time.Second)
+	breaker.Record(poll(), now)
+	if breaker.State(now) != BreakerClosed || breaker.Delay(time.Second, now) != time.Second {
+		t.Fatalf("expected successful probe to close the breaker, got %s", breaker.State(now))
+	}
+
+	server.Close()
+	breaker.Record(poll(), now)
+	breaker.Record(poll(), now)
+	if breaker.State(now) != BreakerOpen {
+		t.Fatalf("expected connection errors to open the breaker")
+	}
+	breaker.Record(StatusError{Code: http.StatusUnauthorized}, now)
+	if breaker.State(now) != BreakerClosed {
+		t.Fatalf("expected an answered request to close the breaker")
+	}
+}
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"crypto/tls"
 	"net/http"
 	"strings"
 	"sync"
@@ -13,20 +12,37 @@ type Options struct {
 	Timeout            time.Duration
 	Headers            map[string]string
 	InsecureSkipVerify bool
+	// CAFile is a PEM bundle that replaces the system roots.
+	CAFile string
+	// CertFile and KeyFile are the PEM client certificate and key for mTLS;
+	// KeyFile defaults to CertFile for combined PEM files.
+	CertFile string
+	KeyFile  string
+	// ServerName overrides SNI and the name the server certificate is
+	// checked against.
+	ServerName string
+	// Retry applies to Do; streams reconnect on their own.
+	Retry RetryPolicy
 }
 
 type Client struct {
-	httpClient *http.Client
-	headers    http.Header
-	mu         sync.RWMutex
+	httpClient   *http.Client
+	streamClient *http.Client
+	transport    *http.Transport
+	tlsFiles     *tlsFiles
+	retry        RetryPolicy
+	headers      http.Header
+	mu           sync.RWMutex
+	// parent and creds are set on endpoint clients; see WithCredentials.
+	parent *Client
+	creds  *credentialSet
 }
 
 func New(opts Options) *Client {
+	tlsConfig, files := newTLSConfig(opts)
 	transport := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: opts.InsecureSkipVerify,
-		},
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
 	}
 	headers := http.Header{}
 	for key, val := range opts.Headers {
@@ -41,11 +57,53 @@ func New(opts Options) *Client {
 			Timeout:   timeout,
 			Transport: transport,
 		},
-		headers: headers,
+		streamClient: &http.Client{
+			Transport: transport,
+		},
+		transport: transport,
+		tlsFiles:  files,
+		retry:     opts.Retry,
+		headers:   headers,
 	}
 }
 
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	c.reloadTLS()
+	if err := c.applyHeaders(req); err != nil {
+		return nil, err
+	}
+	resp, err := c.doWithRetry(c.httpClient, req)
+	c.checkRejected(resp)
+	return resp, err
+}
+
+func (c *Client) DoStream(req *http.Request) (*http.Response, error) {
+	c.reloadTLS()
+	if err := c.applyHeaders(req); err != nil {
+		return nil, err
+	}
+	resp, err := c.streamClient.Do(req)
+	c.checkRejected(resp)
+	return resp, err
+}
+
+// reloadTLS picks up rotated certificate files. Pooled connections still use
+// the old ones, so they are dropped and the next request handshakes again.
+func (c *Client) reloadTLS() {
+	if c.tlsFiles != nil && c.tlsFiles.refresh() {
+		c.transport.CloseIdleConnections()
+	}
+}
+
+func (c *Client) applyHeaders(req *http.Request) error {
+	if c.parent != nil {
+		if err := c.parent.applyHeaders(req); err != nil {
+			return err
+		}
+		// Shared Authorization belongs to the parent's targets; an endpoint
+		// only sends its own.
+		req.Header.Del("Authorization")
+	}
 	c.mu.RLock()
 	for key, values := range c.headers {
 		for _, value := range values {
@@ -53,7 +111,18 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 		}
 	}
 	c.mu.RUnlock()
-	return c.httpClient.Do(req)
+	if c.creds != nil {
+		return c.creds.apply(req)
+	}
+	return nil
+}
+
+// checkRejected drops cached command secrets on a 401 so the next request
+// runs the command again.
+func (c *Client) checkRejected(resp *http.Response) {
+	if resp != nil && resp.StatusCode == http.StatusUnauthorized && c.creds != nil {
+		c.creds.expire()
+	}
 }
 
 func (c *Client) Get(ctx context.Context, url string) (*http.Response, error) {
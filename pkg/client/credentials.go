@@ -0,0 +1,191 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// secretCommandTTL bounds how long command output is reused. A 401 drops
+	// it early so a rotated token is picked up by the next request.
+	secretCommandTTL     = 5 * time.Minute
+	secretCommandTimeout = 10 * time.Second
+)
+
+// Secret is a credential kept out of the config file. It is read from File,
+// or from the trimmed stdout of Command run through the shell.
+type Secret struct {
+	File    string
+	Command string
+}
+
+func (s Secret) IsZero() bool {
+	return s.File == "" && s.Command == ""
+}
+
+// Credentials authenticate requests to one endpoint. Token is sent as a
+// bearer token; Username and Password use basic auth. The parent client's
+// Authorization header is never sent with them.
+type Credentials struct {
+	Token    Secret
+	Username string
+	Password Secret
+	Headers  map[string]string
+}
+
+func (c Credentials) IsZero() bool {
+	return c.Token.IsZero() && c.Username == "" && c.Password.IsZero() && len(c.Headers) == 0
+}
+
+// WithCredentials returns a client for one endpoint. It shares c's transport
+// and headers except Authorization, which is left to creds and to headers set
+// on the returned client.
+func (c *Client) WithCredentials(creds Credentials) *Client {
+	return &Client{
+		httpClient:   c.httpClient,
+		streamClient: c.streamClient,
+		transport:    c.transport,
+		tlsFiles:     c.tlsFiles,
+		retry:        c.retry,
+		headers:      http.Header{},
+		parent:       c,
+		creds:        newCredentialSet(creds),
+	}
+}
+
+type credentialSet struct {
+	token    *secretSource
+	username string
+	password *secretSource
+	headers  http.Header
+}
+
+func newCredentialSet(creds Credentials) *credentialSet {
+	set := &credentialSet{username: creds.Username, headers: http.Header{}}
+	for key, val := range creds.Headers {
+		set.headers.Set(key, val)
+	}
+	if !creds.Token.IsZero() {
+		set.token = &secretSource{secret: creds.Token}
+	}
+	if !creds.Password.IsZero() {
+		set.password = &secretSource{secret: creds.Password}
+	}
+	return set
+}
+
+func (s *credentialSet) apply(req *http.Request) error {
+	for key, values := range s.headers {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	switch {
+	case s.token != nil:
+		token, err := s.token.value(req.Context())
+		if err != nil {
+			return fmt.Errorf("endpoint token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	case s.username != "":
+		password := ""
+		if s.password != nil {
+			var err error
+			if password, err = s.password.value(req.Context()); err != nil {
+				return fmt.Errorf("endpoint password: %w", err)
+			}
+		}
+		req.SetBasicAuth(s.username, password)
+	}
+	return nil
+}
+
+// expire forgets command output after the server rejected it.
+func (s *credentialSet) expire() {
+	for _, source := range []*secretSource{s.token, s.password} {
+		if source != nil {
+			source.expire()
+		}
+	}
+}
+
+// secretSource caches one Secret. Files are re-read when they change on disk;
+// command output is reused for secretCommandTTL.
+type secretSource struct {
+	secret Secret
+
+	mu        sync.Mutex
+	cached    string
+	stamp     fileStamp
+	fetchedAt time.Time
+}
+
+func (s *secretSource) value(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.secret.File != "" {
+		stamp, err := statFile(s.secret.File)
+		if err != nil {
+			return "", fmt.Errorf("read secret file: %w", err)
+		}
+		if stamp != s.stamp || s.cached == "" {
+			data, err := os.ReadFile(s.secret.File)
+			if err != nil {
+				return "", fmt.Errorf("read secret file: %w", err)
+			}
+			value := strings.TrimSpace(string(data))
+			if value == "" {
+				return "", fmt.Errorf("secret file %s is empty", s.secret.File)
+			}
+			s.cached, s.stamp = value, stamp
+		}
+		return s.cached, nil
+	}
+	if s.cached != "" && time.Since(s.fetchedAt) < secretCommandTTL {
+		return s.cached, nil
+	}
+	value, err := runSecretCommand(ctx, s.secret.Command)
+	if err != nil {
+		return "", err
+	}
+	s.cached, s.fetchedAt = value, time.Now()
+	return value, nil
+}
+
+func (s *secretSource) expire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.secret.Command != "" {
+		s.cached = ""
+	}
+}
+
+func runSecretCommand(ctx context.Context, command string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, secretCommandTimeout)
+	defer cancel()
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	out, err := exec.CommandContext(ctx, shell, flag, command).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			if msg := strings.TrimSpace(string(exitErr.Stderr)); msg != "" {
+				return "", fmt.Errorf("secret command: %w: %s", err, strings.SplitN(msg, "\n", 2)[0])
+			}
+		}
+		return "", fmt.Errorf("secret command: %w", err)
+	}
+	value := strings.TrimSpace(string(out))
+	if value == "" {
+		return "", errors.New("secret command printed nothing")
+	}
+	return value, nil
+}
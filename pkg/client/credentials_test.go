@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEndpointCredentials(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[string]http.Header{}
+	rejectNext := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen[r.URL.Path] = r.Header.Clone()
+		if rejectNext {
+			rejectNext = false
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	tokenPath := filepath.Join(dir, "worker-token")
+	writeRotated(t, tokenPath, []byte("worker-1\n"), 0)
+	counterPath := filepath.Join(dir, "runs")
+	command := "echo run >> " + counterPath + "; echo django-pass"
+
+	base := New(Options{Timeout: 2 * time.Second, Headers: map[string]string{"X-Shared": "yes"}})
+	base.SetHeader("Authorization", "Bearer login")
+	metrics := base.WithCredentials(Credentials{Token: Secret{File: tokenPath}})
+	django := base.WithCredentials(Credentials{Username: "ops", Password: Secret{Command: command}, Headers: map[string]string{"X-Tenant": "blue"}})
+	health := base.WithCredentials(Credentials{Headers: map[string]string{"X-Probe": "1"}})
+
+	get := func(c *Client, path string) http.Header {
+		t.Helper()
+		resp, err := c.Get(context.Background(), server.URL+path)
+		if err != nil {
+			t.Fatalf("get %s: %v", path, err)
+		}
+		resp.Body.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		return seen[path]
+	}
+	runs := func() int {
+		data, _ := os.ReadFile(counterPath)
+		return strings.Count(string(data), "run")
+	}
+
+	if got := get(metrics, "/metrics"); got.Get("Authorization") != "Bearer worker-1" || got.Get("X-Shared") != "yes" {
+		t.Fatalf("unexpected metrics headers %v", got)
+	}
+	got := get(django, "/stats")
+	if user, pass, ok := (&http.Request{Header: got}).BasicAuth(); !ok || user != "ops" || pass != "django-pass" {
+		t.Fatalf("expected basic auth for django, got %v", got)
+	}
+	if strings.Contains(got.Get("Authorization"), "worker-1") || got.Get("X-Tenant") != "blue" {
+		t.Fatalf("worker token leaked to django: %v", got)
+	}
+	if got := get(health, "/healthz"); got.Get("Authorization") != "" || got.Get("X-Probe") != "1" {
+		t.Fatalf("expected the shared token to stay off endpoint clients, got %v", got)
+	}
+
+	writeRotated(t, tokenPath, []byte("worker-2\n"), 1)
+	if got := get(metrics, "/metrics"); got.Get("Authorization") != "Bearer worker-2" {
+		t.Fatalf("expected rotated token file to be re-read, got %v", got)
+	}
+
+	get(django, "/stats")
+	if runs() != 1 {
+		t.Fatalf("expected command output to be cached, ran %d times", runs())
+	}
+	mu.Lock()
+	rejectNext = true
+	mu.Unlock()
+	get(django, "/stats")
+	get(django, "/stats")
+	if runs() != 2 {
+		t.Fatalf("expected a 401 to rerun the command once, ran %d times", runs())
+	}
+
+	failing := base.WithCredentials(Credentials{Token: Secret{Command: "echo denied >&2; exit 3"}})
+	if _, err := failing.Get(context.Background(), server.URL+"/events"); err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Fatalf("expected command failure to surface stderr, got %v", err)
+	}
+}
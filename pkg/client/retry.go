@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RetryPolicy retries idempotent requests that failed in transport or hit a
+// gateway error (502/504). 503 is left alone because health endpoints use it
+// to report an unhealthy worker. Delays double from BaseDelay up to MaxDelay
+// with full jitter, and retries stop once the request context is done.
+type RetryPolicy struct {
+	Retries   int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	base := p.BaseDelay
+	if base <= 0 {
+		base = 100 * time.Millisecond
+	}
+	limit := p.MaxDelay
+	if limit <= 0 {
+		limit = 2 * time.Second
+	}
+	d := base << attempt
+	if d <= 0 || d > limit {
+		d = limit
+	}
+	return rand.N(d) + 1
+}
+
+func (p RetryPolicy) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		return false
+	}
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusBadGateway || resp.StatusCode == http.StatusGatewayTimeout
+}
+
+func (c *Client) doWithRetry(httpClient *http.Client, req *http.Request) (*http.Response, error) {
+	resp, err := httpClient.Do(req)
+	for attempt := 0; attempt < c.retry.Retries && c.retry.retryable(req, resp, err); attempt++ {
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+		if err := sleepContext(req.Context(), c.retry.delay(attempt)); err != nil {
+			return nil, err
+		}
+		resp, err = httpClient.Do(req)
+	}
+	return resp, err
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Unavailable reports whether err means the endpoint could not be reached,
+// as opposed to an answer the caller did not like.
+func Unavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if IsStatus(err, http.StatusBadGateway, http.StatusGatewayTimeout) {
+		return true
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer fails the first failures requests, alternating between a
+// dropped connection and a 502, then answers 200.
+func flakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := hits.Add(1)
+		if n > failures {
+			_, _ = w.Write([]byte("ok"))
+			return
+		}
+		if n%2 == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+func TestRetryPolicy(t *testing.T) {
+	policy := RetryPolicy{Retries: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	server, hits := flakyServer(t, 2, http.StatusBadGateway)
+	c := New(Options{Timeout: time.Second, Retry: policy})
+	resp, err := c.Get(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("expected retries to recover, got %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || hits.Load() != 3 {
+		t.Fatalf("expected success on the third attempt, got %d after %d", resp.StatusCode, hits.Load())
+	}
+
+	server, hits = flakyServer(t, 10, http.StatusGatewayTimeout)
+	resp, err = New(Options{Timeout: time.Second, Retry: RetryPolicy{Retries: 1, BaseDelay: time.Millisecond}}).Get(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("expected the last response after exhausting retries, got %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusGatewayTimeout || hits.Load() != 2 {
+		t.Fatalf("expected one retry ending in 504, got %d after %d requests", resp.StatusCode, hits.Load())
+	}
+
+	server, hits = flakyServer(t, 1, http.StatusBadGateway)
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("{}"))
+	if resp, err := c.Do(req); err == nil {
+		resp.Body.Close()
+	}
+	if hits.Load() != 1 {
+		t.Fatalf("expected POST not to be retried, got %d requests", hits.Load())
+	}
+
+	var unhealthy atomic.Int32
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		unhealthy.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	resp, err = c.Get(context.Background(), down.URL)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	resp.Body.Close()
+	if unhealthy.Load() != 1 {
+		t.Fatalf("expected 503 to be returned without retrying, got %d requests", unhealthy.Load())
+	}
+
+	server, _ = flakyServer(t, 10, http.StatusBadGateway)
+	slow := New(Options{Timeout: time.Second, Retry: RetryPolicy{Retries: 5, BaseDelay: time.Second}})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := slow.Get(ctx, server.URL); err == nil {
+		t.Fatalf("expected context deadline to stop retries")
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Fatalf("expected backoff to stop at the deadline, took %v", time.Since(start))
+	}
+}
+
+func TestRetryDelayJitter(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 40 * time.Millisecond}
+	for attempt, limit := range []time.Duration{10, 20, 40, 40, 40} {
+		limit *= time.Millisecond
+		for i := 0; i < 50; i++ {
+			if d := policy.delay(attempt); d <= 0 || d > limit {
+				t.Fatalf("attempt %d: delay %v outside (0, %v]", attempt, d, limit)
+			}
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// fileStamp identifies one version of a file on disk; secret mounts rotate by
+// swapping the file, which changes both.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func statFile(path string) (fileStamp, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+// tlsFiles holds the CA bundle and client key pair loaded from PEM files and
+// reloads them when the files change, so rotated certificates apply without
+// restarting.
+type tlsFiles struct {
+	caFile   string
+	certFile string
+	keyFile  string
+
+	mu        sync.Mutex
+	caStamp   fileStamp
+	certStamp fileStamp
+	keyStamp  fileStamp
+	pool      *x509.CertPool
+	cert      *tls.Certificate
+	caErr     error
+	certErr   error
+}
+
+// refresh reloads any file whose stamp changed and reports whether it did.
+// A failed load keeps the last good material and records the error.
+func (f *tlsFiles) refresh() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	changed := false
+	if f.caFile != "" {
+		f.caErr = nil
+		stamp, err := statFile(f.caFile)
+		switch {
+		case err != nil:
+			f.caErr = fmt.Errorf("read ca file: %w", err)
+		case stamp != f.caStamp || f.pool == nil:
+			if pool, err := loadCertPool(f.caFile); err != nil {
+				f.caErr = err
+			} else {
+				f.pool, f.caStamp, changed = pool, stamp, true
+			}
+		}
+	}
+	if f.certFile != "" {
+		f.certErr = nil
+		certStamp, err := statFile(f.certFile)
+		if err != nil {
+			f.certErr = fmt.Errorf("read client cert: %w", err)
+			return changed
+		}
+		keyStamp, err := statFile(f.keyFile)
+		if err != nil {
+			f.certErr = fmt.Errorf("read client key: %w", err)
+			return changed
+		}
+		if certStamp != f.certStamp || keyStamp != f.keyStamp || f.cert == nil {
+			// A pair caught mid-rotation fails to load; the next request retries.
+			if cert, err := tls.LoadX509KeyPair(f.certFile, f.keyFile); err != nil {
+				f.certErr = fmt.Errorf("load client certificate: %w", err)
+			} else {
+				f.cert, f.certStamp, f.keyStamp, changed = &cert, certStamp, keyStamp, true
+			}
+		}
+	}
+	return changed
+}
+
+func (f *tlsFiles) clientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	f.refresh()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cert == nil {
+		if f.certErr != nil {
+			return nil, f.certErr
+		}
+		return &tls.Certificate{}, nil
+	}
+	return f.cert, nil
+}
+
+// verify checks the server chain against the CA bundle. It stands in for the
+// standard verification so the pool can change between handshakes.
+func (f *tlsFiles) verify(cs tls.ConnectionState, serverName string) error {
+	f.refresh()
+	f.mu.Lock()
+	pool, loadErr := f.pool, f.caErr
+	f.mu.Unlock()
+	if pool == nil {
+		if loadErr != nil {
+			return loadErr
+		}
+		return errors.New("no CA certificates loaded")
+	}
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("server presented no certificate")
+	}
+	if serverName == "" {
+		serverName = cs.ServerName
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         pool,
+		Intermediates: intermediates,
+		DNSName:       serverName,
+	})
+	return err
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read ca file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return pool, nil
+}
+
+func newTLSConfig(opts Options) (*tls.Config, *tlsFiles) {
+	cfg := &tls.Config{
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+		ServerName:         opts.ServerName,
+	}
+	if opts.CAFile == "" && opts.CertFile == "" {
+		return cfg, nil
+	}
+	files := &tlsFiles{caFile: opts.CAFile, certFile: opts.CertFile, keyFile: opts.KeyFile}
+	if files.keyFile == "" {
+		files.keyFile = files.certFile
+	}
+	files.refresh()
+	if opts.CertFile != "" {
+		cfg.GetClientCertificate = files.clientCertificate
+	}
+	if opts.CAFile != "" && !opts.InsecureSkipVerify {
+		// Chain verification moves to VerifyConnection so a rotated CA bundle
+		// takes effect on the next handshake.
+		cfg.InsecureSkipVerify = true
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			return files.verify(cs, opts.ServerName)
+		}
+	}
+	return cfg, files
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T, name string) testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create ca: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns PEM cert and key signed by the CA.
+func (ca testCA) issue(t *testing.T, serial int64, usage x509.ExtKeyUsage, dnsNames ...string) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "reproq-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		DNSNames:     dnsNames,
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("create cert: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeRotated(t *testing.T, path string, data []byte, version int) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+	stamp := time.Now().Add(time.Duration(version) * time.Minute)
+	if err := os.Chtimes(path, stamp, stamp); err != nil {
+		t.Fatalf("touch %s: %v", path, err)
+	}
+}
+
+func TestClientMutualTLSReload(t *testing.T) {
+	serverCA := newTestCA(t, "server-ca")
+	clientCA := newTestCA(t, "client-ca")
+	otherCA := newTestCA(t, "other-ca")
+
+	serverCert, serverKey := serverCA.issue(t, 2, x509.ExtKeyUsageServerAuth, "worker.internal")
+	pair, err := tls.X509KeyPair(serverCert, serverKey)
+	if err != nil {
+		t.Fatalf("server key pair: %v", err)
+	}
+	clientPool := x509.NewCertPool()
+	clientPool.AddCert(clientCA.cert)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].SerialNumber.String()))
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{pair},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientPool,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	dir := t.TempDir()
+	caPath := filepath.Join(dir, "ca.pem")
+	certPath := filepath.Join(dir, "client.pem")
+	keyPath := filepath.Join(dir, "client.key")
+	writeRotated(t, caPath, otherCA.pem, 0)
+	cert, key := clientCA.issue(t, 10, x509.ExtKeyUsageClientAuth)
+	writeRotated(t, certPath, cert, 0)
+	writeRotated(t, keyPath, key, 0)
+
+	c := New(Options{Timeout: 2 * time.Second, CAFile: caPath, CertFile: certPath, KeyFile: keyPath, ServerName: "worker.internal"})
+	get := func() (string, error) {
+		resp, err := c.Get(context.Background(), server.URL)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		buf := make([]byte, 16)
+		n, _ := resp.Body.Read(buf)
+		return string(buf[:n]), nil
+	}
+
+	if _, err := get(); err == nil {
+		t.Fatalf("expected server cert from an untrusted CA to be rejected")
+	}
+
+	writeRotated(t, caPath, serverCA.pem, 1)
+	if serial, err := get(); err != nil || serial != "10" {
+		t.Fatalf("expected request with first client cert, got %q (%v)", serial, err)
+	}
+
+	cert, key = clientCA.issue(t, 11, x509.ExtKeyUsageClientAuth)
+	writeRotated(t, certPath, cert, 2)
+	writeRotated(t, keyPath, key, 2)
+	if serial, err := get(); err != nil || serial != "11" {
+		t.Fatalf("expected rotated client cert to be used, got %q (%v)", serial, err)
+	}
+
+	wrongName := New(Options{Timeout: 2 * time.Second, CAFile: caPath, CertFile: certPath, KeyFile: keyPath, ServerName: "other.internal"})
+	if resp, err := wrongName.Get(context.Background(), server.URL); err == nil {
+		resp.Body.Close()
+		t.Fatalf("expected server name mismatch to fail verification")
+	}
+}
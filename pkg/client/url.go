@@ -0,0 +1,28 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+func JoinURL(baseURL, suffix string) (string, error) {
+	if strings.TrimSpace(baseURL) == "" {
+		return "", fmt.Errorf("base url is required")
+	}
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	basePath := strings.TrimSuffix(parsed.Path, "/")
+	if !strings.HasPrefix(suffix, "/") {
+		suffix = "/" + suffix
+	}
+	if strings.HasSuffix(basePath, "/reproq") && strings.HasPrefix(suffix, "/reproq/") {
+		suffix = strings.TrimPrefix(suffix, "/reproq")
+	}
+	parsed.Path = basePath + suffix
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	return parsed.String(), nil
+}
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Sample struct {
 	Timestamp time.Time `json:"timestamp"`
@@ -11,17 +14,28 @@ type MetricSnapshot struct {
 	CollectedAt time.Time          `json:"collected_at"`
 	Latency     time.Duration      `json:"latency"`
 	Values      map[string]float64 `json:"values"`
+	// Workers breaks catalog values down by worker label when the exporter
+	// reports one; keyed by worker ID, then catalog key.
+	Workers map[string]map[string]float64 `json:"workers,omitempty"`
 }
 
 type HealthStatus struct {
-	Healthy   bool          `json:"healthy"`
-	Status    string        `json:"status"`
-	Version   string        `json:"version,omitempty"`
-	Build     string        `json:"build,omitempty"`
-	Commit    string        `json:"commit,omitempty"`
-	Message   string        `json:"message,omitempty"`
-	CheckedAt time.Time     `json:"checked_at"`
-	Latency   time.Duration `json:"latency"`
+	Healthy   bool                   `json:"healthy"`
+	Status    string                 `json:"status"`
+	Version   string                 `json:"version,omitempty"`
+	Build     string                 `json:"build,omitempty"`
+	Commit    string                 `json:"commit,omitempty"`
+	Message   string                 `json:"message,omitempty"`
+	Checks    map[string]HealthCheck `json:"checks,omitempty"`
+	CheckedAt time.Time              `json:"checked_at"`
+	Latency   time.Duration          `json:"latency"`
+}
+
+type HealthCheck struct {
+	Healthy bool          `json:"healthy"`
+	Status  string        `json:"status"`
+	Message string        `json:"message,omitempty"`
+	Latency time.Duration `json:"latency,omitempty"`
 }
 
 type Event struct {
@@ -46,6 +60,17 @@ type DjangoStats struct {
 	TopFailing    []FailingTask               `json:"top_failing"`
 	Databases     []DatabaseStats             `json:"databases,omitempty"`
 	FetchedAt     time.Time                   `json:"fetched_at,omitempty"`
+	Delta         bool                        `json:"delta,omitempty"`
+	Removed       *StatsRemovals              `json:"removed,omitempty"`
+}
+
+// StatsRemovals lists what was deleted since a delta's cursor. Queue controls
+// are matched on QueueName and Database only.
+type StatsRemovals struct {
+	Workers       []string       `json:"workers,omitempty"`
+	Periodic      []string       `json:"periodic,omitempty"`
+	QueueControls []QueueControl `json:"queue_controls,omitempty"`
+	Databases     []string       `json:"databases,omitempty"`
 }
 
 type SchedulerStatus struct {
@@ -99,3 +124,19 @@ type DatabaseStats struct {
 	Workers  []WorkerInfo                `json:"workers"`
 	Periodic []PeriodicTask              `json:"periodic"`
 }
+
+type TaskRun struct {
+	ResultID   int64           `json:"result_id"`
+	TaskPath   string          `json:"task_path"`
+	QueueName  string          `json:"queue_name"`
+	Status     string          `json:"status"`
+	Attempts   int             `json:"attempts"`
+	Exception  string          `json:"exception,omitempty"`
+	Traceback  string          `json:"traceback,omitempty"`
+	Args       json.RawMessage `json:"args,omitempty"`
+	Kwargs     json.RawMessage `json:"kwargs,omitempty"`
+	EnqueuedAt time.Time       `json:"enqueued_at"`
+	FinishedAt time.Time       `json:"finished_at,omitempty"`
+	WorkerID   string          `json:"worker_id,omitempty"`
+	Database   string          `json:"database,omitempty"`
+}